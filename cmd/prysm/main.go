@@ -20,6 +20,6 @@ func init() {
 func main() {
 	if err := cmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, style.Error.Render("Error: "+err.Error()))
-		os.Exit(1)
+		os.Exit(cmd.ExitCode(err))
 	}
 }
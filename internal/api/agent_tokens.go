@@ -0,0 +1,65 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// AgentToken is a credential a cluster agent uses to authenticate to the
+// backend. The secret is only returned when the token is created.
+type AgentToken struct {
+	ID         int64      `json:"id"`
+	ClusterID  int64      `json:"cluster_id"`
+	Prefix     string     `json:"prefix,omitempty"` // leading characters, for identification
+	Token      string     `json:"token,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+// Active reports whether the token has not been revoked.
+func (t AgentToken) Active() bool {
+	return t.RevokedAt == nil
+}
+
+// ListAgentTokens returns the agent tokens issued for a cluster, including
+// revoked ones.
+func (c *Client) ListAgentTokens(ctx context.Context, clusterID int64) ([]AgentToken, error) {
+	var resp struct {
+		Tokens []AgentToken `json:"tokens"`
+	}
+	if _, err := c.Do(ctx, "GET", fmt.Sprintf("/clusters/%d/agent-tokens", clusterID), nil, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Tokens == nil {
+		return []AgentToken{}, nil
+	}
+	return resp.Tokens, nil
+}
+
+// CreateAgentToken issues a new agent token for a cluster. Existing tokens
+// stay valid until revoked.
+func (c *Client) CreateAgentToken(ctx context.Context, clusterID int64) (*AgentToken, error) {
+	var resp struct {
+		Token AgentToken `json:"token"`
+	}
+	if _, err := c.Do(ctx, "POST", fmt.Sprintf("/clusters/%d/agent-tokens", clusterID), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Token, nil
+}
+
+// PushAgentToken has the backend hand a token to the cluster's connected
+// agent over its current session; the agent switches to it on receipt.
+func (c *Client) PushAgentToken(ctx context.Context, clusterID, tokenID int64) error {
+	_, err := c.Do(ctx, "POST", fmt.Sprintf("/clusters/%d/agent-tokens/%d/push", clusterID, tokenID), nil, nil)
+	return err
+}
+
+// RevokeAgentToken revokes an agent token immediately; agents using it are
+// disconnected.
+func (c *Client) RevokeAgentToken(ctx context.Context, tokenID int64) error {
+	_, err := c.Do(ctx, "DELETE", fmt.Sprintf("/agent-tokens/%d", tokenID), nil, nil)
+	return err
+}
@@ -0,0 +1,56 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prysmsh/cli/internal/api"
+)
+
+func TestAgentTokenLifecycle(t *testing.T) {
+	var calls []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v1/clusters/7/agent-tokens":
+			json.NewEncoder(w).Encode(map[string]any{"tokens": []any{
+				map[string]any{"id": 1, "cluster_id": 7, "prefix": "pat_ab"},
+				map[string]any{"id": 2, "cluster_id": 7, "prefix": "pat_cd", "revoked_at": "2026-01-02T03:04:05Z"},
+			}})
+		case "POST /api/v1/clusters/7/agent-tokens":
+			json.NewEncoder(w).Encode(map[string]any{"token": map[string]any{"id": 3, "cluster_id": 7, "token": "pat_secret"}})
+		case "POST /api/v1/clusters/7/agent-tokens/3/push", "DELETE /api/v1/agent-tokens/1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	c := api.NewClient(srv.URL)
+	tokens, err := c.ListAgentTokens(ctx, 7)
+	if err != nil {
+		t.Fatalf("ListAgentTokens: %v", err)
+	}
+	if len(tokens) != 2 || !tokens[0].Active() || tokens[1].Active() {
+		t.Errorf("tokens = %+v", tokens)
+	}
+	tok, err := c.CreateAgentToken(ctx, 7)
+	if err != nil || tok.ID != 3 || tok.Token != "pat_secret" {
+		t.Fatalf("CreateAgentToken = %+v, %v", tok, err)
+	}
+	if err := c.PushAgentToken(ctx, 7, 3); err != nil {
+		t.Fatalf("PushAgentToken: %v", err)
+	}
+	if err := c.RevokeAgentToken(ctx, 1); err != nil {
+		t.Fatalf("RevokeAgentToken: %v", err)
+	}
+	if len(calls) != 4 {
+		t.Errorf("calls = %v", calls)
+	}
+}
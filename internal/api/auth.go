@@ -57,6 +57,22 @@ func (c *Client) Login(ctx context.Context, req LoginRequest) (*LoginResponse, e
 	return &resp, nil
 }
 
+// ExchangeAuthKey trades a pre-authorized enrollment key for a session, so
+// unattended machines such as CI runners can join without an interactive
+// login. The key is bound to deviceID by the backend.
+func (c *Client) ExchangeAuthKey(ctx context.Context, authKey, deviceID string) (*LoginResponse, error) {
+	body := map[string]string{"auth_key": authKey, "device_id": deviceID}
+	var resp LoginResponse
+	if _, err := c.Do(ctx, "POST", "/auth/auth-keys/exchange", body, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Token == "" {
+		return nil, fmt.Errorf("exchange response missing token")
+	}
+	c.SetToken(resp.Token)
+	return &resp, nil
+}
+
 // Logout revokes tokens server-side.
 func (c *Client) Logout(ctx context.Context) error {
 	_, err := c.Do(ctx, "POST", "/auth/logout", nil, nil)
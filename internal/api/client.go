@@ -29,8 +29,9 @@ type Client struct {
 	insecureSkipVerify bool
 	dialOverride       string
 
-	mu    sync.RWMutex
-	token string
+	mu         sync.RWMutex
+	token      string
+	requestIDs []string
 }
 
 // Option mutates client configuration.
@@ -208,6 +209,24 @@ func (c *Client) BasePublicURL() string {
 	return c.baseURL.Scheme + "://" + c.baseURL.Host
 }
 
+// RequestIDs returns the X-Request-ID values the API returned so far, in
+// request order.
+func (c *Client) RequestIDs() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return append([]string(nil), c.requestIDs...)
+}
+
+func (c *Client) recordRequestID(resp *http.Response) {
+	id := strings.TrimSpace(resp.Header.Get("X-Request-ID"))
+	if id == "" {
+		return
+	}
+	c.mu.Lock()
+	c.requestIDs = append(c.requestIDs, id)
+	c.mu.Unlock()
+}
+
 // Do issues an HTTP request against the API and decodes the response into v when provided.
 func (c *Client) Do(ctx context.Context, method, endpoint string, payload interface{}, v interface{}) (*http.Response, error) {
 	req, err := c.newRequest(ctx, method, endpoint, payload)
@@ -234,6 +253,7 @@ func (c *Client) Do(ctx context.Context, method, endpoint string, payload interf
 	if c.debug {
 		fmt.Fprintf(os.Stderr, "[debug] Response status: %s\n", resp.Status)
 	}
+	c.recordRequestID(resp)
 
 	defer func() {
 		if resp.Body != nil {
@@ -277,6 +297,7 @@ func (c *Client) DoRaw(ctx context.Context, method, endpoint, contentType string
 	if err != nil {
 		return nil, fmt.Errorf("perform request: %w", err)
 	}
+	c.recordRequestID(resp)
 	defer func() {
 		if resp.Body != nil {
 			io.Copy(io.Discard, resp.Body)
@@ -333,6 +354,7 @@ func (c *Client) DoStream(ctx context.Context, method, endpoint string, headers
 	if err != nil {
 		return nil, fmt.Errorf("perform request: %w", err)
 	}
+	c.recordRequestID(resp)
 	return resp, nil
 }
 
@@ -199,6 +199,32 @@ func TestClientDoAPIError(t *testing.T) {
 	}
 }
 
+func TestClientRequestIDs(t *testing.T) {
+	n := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n++
+		if n != 2 {
+			w.Header().Set("X-Request-ID", "req-"+string(rune('0'+n)))
+		}
+		if n == 3 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte("{}"))
+	}))
+	defer srv.Close()
+
+	client := api.NewClient(srv.URL)
+	for i := 0; i < 3; i++ {
+		client.Do(context.Background(), "GET", "/ping", nil, nil)
+	}
+
+	ids := client.RequestIDs()
+	if len(ids) != 2 || ids[0] != "req-1" || ids[1] != "req-3" {
+		t.Errorf("RequestIDs = %v, want [req-1 req-3]", ids)
+	}
+}
+
 func TestClientDoContextCancellation(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		time.Sleep(5 * time.Second)
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// MeshDevice is an entry in the organization's device registry: a machine
+// that enrolled in the mesh with a WireGuard key.
+type MeshDevice struct {
+	ID           int64      `json:"id"`
+	DeviceID     string     `json:"device_id"`
+	Name         string     `json:"name"`
+	PeerType     string     `json:"peer_type"`
+	Owner        string     `json:"owner,omitempty"` // email of the enrolling user
+	Tags         []string   `json:"tags,omitempty"`
+	Ephemeral    bool       `json:"ephemeral,omitempty"` // expired by the backend on disconnect
+	Address      string     `json:"address,omitempty"`
+	Status       string     `json:"status"`
+	LastSeen     *time.Time `json:"last_seen,omitempty"`
+	KeyExpiresAt *time.Time `json:"key_expires_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// KeyExpired reports whether the device's key has expired, so it must
+// re-authenticate before it can rejoin the mesh.
+func (d MeshDevice) KeyExpired() bool {
+	return d.KeyExpiresAt != nil && !d.KeyExpiresAt.After(time.Now())
+}
+
+// ListMeshDevices returns the devices enrolled in the organization's mesh.
+func (c *Client) ListMeshDevices(ctx context.Context) ([]MeshDevice, error) {
+	var resp struct {
+		Devices []MeshDevice `json:"devices"`
+	}
+	if _, err := c.Do(ctx, "GET", "/mesh/devices", nil, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Devices == nil {
+		return []MeshDevice{}, nil
+	}
+	return resp.Devices, nil
+}
+
+// MeshEnrollRequest registers a device and its WireGuard key in the device
+// registry ahead of its first connect.
+type MeshEnrollRequest struct {
+	DeviceID  string   `json:"device_id"`
+	PublicKey string   `json:"public_key"`
+	Hostname  string   `json:"hostname,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+	Ephemeral bool     `json:"ephemeral,omitempty"`
+	// PresharedKeys asks the control plane to set up preshared keys with
+	// peers that join after this device.
+	PresharedKeys bool `json:"preshared_keys,omitempty"`
+}
+
+// EnrollMeshDevice adds a device to the registry. Enrolling an existing
+// device ID updates its key, tags and ephemeral flag.
+func (c *Client) EnrollMeshDevice(ctx context.Context, req MeshEnrollRequest) (*MeshDevice, error) {
+	var resp struct {
+		Device MeshDevice `json:"device"`
+	}
+	if _, err := c.Do(ctx, "POST", "/mesh/devices/enroll", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Device, nil
+}
+
+// RenameMeshDevice sets a device's display name.
+func (c *Client) RenameMeshDevice(ctx context.Context, deviceID, name string) (*MeshDevice, error) {
+	payload := map[string]string{"name": name}
+	var resp struct {
+		Device MeshDevice `json:"device"`
+	}
+	if _, err := c.Do(ctx, "PATCH", meshDevicePath(deviceID), payload, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Device, nil
+}
+
+// RemoveMeshDevice deletes a device from the registry and revokes its key;
+// peers drop it at their next sync.
+func (c *Client) RemoveMeshDevice(ctx context.Context, deviceID string) error {
+	_, err := c.Do(ctx, "DELETE", meshDevicePath(deviceID), nil, nil)
+	return err
+}
+
+// ExpireMeshDevice expires a device's key immediately. The device stays
+// registered but must re-authenticate to rejoin the mesh.
+func (c *Client) ExpireMeshDevice(ctx context.Context, deviceID string) (*MeshDevice, error) {
+	var resp struct {
+		Device MeshDevice `json:"device"`
+	}
+	if _, err := c.Do(ctx, "POST", meshDevicePath(deviceID)+"/expire", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Device, nil
+}
+
+func meshDevicePath(deviceID string) string {
+	return fmt.Sprintf("/mesh/devices/%s", deviceID)
+}
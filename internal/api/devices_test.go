@@ -0,0 +1,136 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prysmsh/cli/internal/api"
+)
+
+func TestRenameMeshDevice(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.EscapedPath() != "/api/v1/mesh/devices/old%20laptop-cli" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.EscapedPath())
+		}
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if body["name"] != "spare" {
+			t.Fatalf("unexpected body: %v", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"device": map[string]any{"id": 3, "device_id": "old laptop-cli", "name": "spare"},
+		})
+	}))
+	defer srv.Close()
+
+	dev, err := api.NewClient(srv.URL).RenameMeshDevice(context.Background(), "old laptop-cli", "spare")
+	if err != nil {
+		t.Fatalf("RenameMeshDevice: %v", err)
+	}
+	if dev.ID != 3 || dev.Name != "spare" {
+		t.Errorf("device = %+v", dev)
+	}
+}
+
+func TestRemoveMeshDevice(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/api/v1/mesh/devices/laptop-1-cli" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	if err := api.NewClient(srv.URL).RemoveMeshDevice(context.Background(), "laptop-1-cli"); err != nil {
+		t.Fatalf("RemoveMeshDevice: %v", err)
+	}
+}
+
+func TestMeshDeviceKeyExpired(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+	future := time.Now().Add(time.Hour)
+	if (api.MeshDevice{}).KeyExpired() {
+		t.Error("device without expiry should not be expired")
+	}
+	if !(api.MeshDevice{KeyExpiresAt: &past}).KeyExpired() {
+		t.Error("past expiry should be expired")
+	}
+	if (api.MeshDevice{KeyExpiresAt: &future}).KeyExpired() {
+		t.Error("future expiry should not be expired")
+	}
+}
+
+func TestEnrollMeshDevice(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/mesh/devices/enroll" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var body api.MeshEnrollRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if body.DeviceID != "runner-cli" || !body.Ephemeral || len(body.Tags) != 1 || body.Tags[0] != "ci-runner" {
+			t.Fatalf("unexpected body: %+v", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"device": map[string]any{"device_id": "runner-cli", "tags": body.Tags, "ephemeral": true},
+		})
+	}))
+	defer srv.Close()
+
+	dev, err := api.NewClient(srv.URL).EnrollMeshDevice(context.Background(), api.MeshEnrollRequest{
+		DeviceID: "runner-cli", PublicKey: "pub", Tags: []string{"ci-runner"}, Ephemeral: true,
+	})
+	if err != nil {
+		t.Fatalf("EnrollMeshDevice: %v", err)
+	}
+	if !dev.Ephemeral || len(dev.Tags) != 1 {
+		t.Errorf("device = %+v", dev)
+	}
+}
+
+func TestExchangeAuthKey(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/auth/auth-keys/exchange":
+			var body map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if body["auth_key"] != "pak_123" || body["device_id"] != "runner-cli" {
+				t.Fatalf("unexpected body: %v", body)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]any{"token": "tok-1", "organization": map[string]any{"id": 4, "name": "acme"}})
+		case "/api/v1/mesh/devices":
+			if got := r.Header.Get("Authorization"); got != "Bearer tok-1" {
+				t.Errorf("Authorization = %q, want exchanged token", got)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]any{"devices": []any{}})
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	client := api.NewClient(srv.URL)
+	resp, err := client.ExchangeAuthKey(context.Background(), "pak_123", "runner-cli")
+	if err != nil {
+		t.Fatalf("ExchangeAuthKey: %v", err)
+	}
+	if resp.Organization.ID != 4 {
+		t.Errorf("organization = %+v", resp.Organization)
+	}
+	if _, err := client.ListMeshDevices(context.Background()); err != nil {
+		t.Fatalf("ListMeshDevices: %v", err)
+	}
+}
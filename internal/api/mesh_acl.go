@@ -0,0 +1,71 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrEndpointUnavailable is returned when the control plane does not offer
+// an endpoint, so callers can fall back to something else.
+var ErrEndpointUnavailable = errors.New("endpoint not available on this control plane")
+
+// MeshACL is the organization's mesh access policy. Rules are evaluated in
+// order and the first rule matching source, destination, protocol and port
+// decides; traffic no rule matches gets DefaultAction.
+type MeshACL struct {
+	DefaultAction string        `json:"default_action"` // accept or deny; empty means deny
+	Rules         []MeshACLRule `json:"rules"`
+	UpdatedAt     time.Time     `json:"updated_at"`
+}
+
+// MeshACLRule is a single mesh access rule. Sources and destinations are
+// selectors: "*", "device:<id>", "cluster:<name>", "type:<client|cluster>",
+// an IP or a CIDR; a bare value is treated as a device ID.
+type MeshACLRule struct {
+	Name         string   `json:"name,omitempty"`
+	Action       string   `json:"action"` // accept or deny
+	Sources      []string `json:"src"`
+	Destinations []string `json:"dst"`
+	Ports        []string `json:"ports,omitempty"` // "443" or "8000-9000"; empty matches all ports
+	Protocol     string   `json:"proto,omitempty"` // tcp or udp; empty matches both
+}
+
+// GetMeshACL fetches the organization's mesh access policy.
+func (c *Client) GetMeshACL(ctx context.Context) (*MeshACL, error) {
+	var resp struct {
+		Policy MeshACL `json:"policy"`
+	}
+	if _, err := c.Do(ctx, "GET", "/mesh/acl", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Policy, nil
+}
+
+// MeshACLCheck describes one connection to evaluate against the policy.
+type MeshACLCheck struct {
+	SrcDeviceID string `json:"src_device_id"`
+	DstDeviceID string `json:"dst_device_id"`
+	Port        int    `json:"port,omitempty"`  // 0 matches any port
+	Protocol    string `json:"proto,omitempty"` // tcp or udp; empty matches both
+}
+
+// MeshACLVerdict is the control plane's decision for a MeshACLCheck.
+type MeshACLVerdict struct {
+	Allowed bool   `json:"allowed"`
+	Rule    string `json:"rule"` // deciding rule, or "default <action>"
+}
+
+// EvaluateMeshACL asks the control plane to evaluate the mesh access policy
+// for a connection without sending traffic. It returns ErrEndpointUnavailable
+// when the control plane cannot evaluate policies.
+func (c *Client) EvaluateMeshACL(ctx context.Context, check MeshACLCheck) (*MeshACLVerdict, error) {
+	var resp MeshACLVerdict
+	if _, err := c.Do(ctx, "POST", "/mesh/acl/evaluate", check, &resp); err != nil {
+		if isEndpointUnavailable(err) {
+			return nil, ErrEndpointUnavailable
+		}
+		return nil, err
+	}
+	return &resp, nil
+}
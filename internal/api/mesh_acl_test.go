@@ -0,0 +1,49 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prysmsh/cli/internal/api"
+)
+
+func TestEvaluateMeshACL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/mesh/acl/evaluate" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var check api.MeshACLCheck
+		if err := json.NewDecoder(r.Body).Decode(&check); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if check != (api.MeshACLCheck{SrcDeviceID: "laptop-1", DstDeviceID: "db", Port: 5432, Protocol: "tcp"}) {
+			t.Fatalf("unexpected body: %+v", check)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"allowed": true, "rule": "dev-to-db"})
+	}))
+	defer srv.Close()
+
+	v, err := api.NewClient(srv.URL).EvaluateMeshACL(context.Background(), api.MeshACLCheck{SrcDeviceID: "laptop-1", DstDeviceID: "db", Port: 5432, Protocol: "tcp"})
+	if err != nil {
+		t.Fatalf("EvaluateMeshACL: %v", err)
+	}
+	if !v.Allowed || v.Rule != "dev-to-db" {
+		t.Errorf("verdict = %+v", v)
+	}
+}
+
+func TestEvaluateMeshACL_Unavailable(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	if _, err := api.NewClient(srv.URL).EvaluateMeshACL(context.Background(), api.MeshACLCheck{SrcDeviceID: "a", DstDeviceID: "b"}); !errors.Is(err, api.ErrEndpointUnavailable) {
+		t.Errorf("err = %v, want ErrEndpointUnavailable", err)
+	}
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// SubnetRoute is a LAN or VPC CIDR advertised into the mesh by a gateway
+// device. Clients reach it through the gateway once they accept the route.
+type SubnetRoute struct {
+	ID             int64     `json:"id"`
+	OrganizationID int64     `json:"organization_id"`
+	DeviceID       string    `json:"device_id"` // gateway advertising the route
+	CIDR           string    `json:"cidr"`
+	Status         string    `json:"status"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// ListSubnetRoutes returns the subnet routes advertised in the organization.
+func (c *Client) ListSubnetRoutes(ctx context.Context) ([]SubnetRoute, error) {
+	var resp struct {
+		Routes []SubnetRoute `json:"routes"`
+	}
+	if _, err := c.Do(ctx, "GET", "/mesh/subnet-routes", nil, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Routes == nil {
+		return []SubnetRoute{}, nil
+	}
+	return resp.Routes, nil
+}
+
+// AdvertiseSubnetRoute registers cidr as reachable through deviceID.
+func (c *Client) AdvertiseSubnetRoute(ctx context.Context, deviceID, cidr string) (*SubnetRoute, error) {
+	payload := map[string]string{"device_id": deviceID, "cidr": cidr}
+	var resp struct {
+		Route SubnetRoute `json:"route"`
+	}
+	if _, err := c.Do(ctx, "POST", "/mesh/subnet-routes", payload, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Route, nil
+}
+
+// DeleteSubnetRoute withdraws an advertised subnet route.
+func (c *Client) DeleteSubnetRoute(ctx context.Context, id int64) error {
+	_, err := c.Do(ctx, "DELETE", fmt.Sprintf("/mesh/subnet-routes/%d", id), nil, nil)
+	return err
+}
@@ -0,0 +1,55 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prysmsh/cli/internal/api"
+)
+
+func TestAdvertiseSubnetRoute(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/mesh/subnet-routes" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if body["device_id"] != "gw-1" || body["cidr"] != "10.1.0.0/16" {
+			t.Fatalf("unexpected body: %v", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"route": map[string]any{"id": 9, "device_id": "gw-1", "cidr": "10.1.0.0/16", "status": "active"},
+		})
+	}))
+	defer srv.Close()
+
+	route, err := api.NewClient(srv.URL).AdvertiseSubnetRoute(context.Background(), "gw-1", "10.1.0.0/16")
+	if err != nil {
+		t.Fatalf("AdvertiseSubnetRoute: %v", err)
+	}
+	if route.ID != 9 || route.CIDR != "10.1.0.0/16" || route.Status != "active" {
+		t.Errorf("route = %+v", route)
+	}
+}
+
+func TestListSubnetRoutes_Nil(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"routes": nil})
+	}))
+	defer srv.Close()
+
+	routes, err := api.NewClient(srv.URL).ListSubnetRoutes(context.Background())
+	if err != nil {
+		t.Fatalf("ListSubnetRoutes: %v", err)
+	}
+	if routes == nil || len(routes) != 0 {
+		t.Errorf("routes = %v, want empty slice", routes)
+	}
+}
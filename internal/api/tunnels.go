@@ -23,6 +23,7 @@ type Tunnel struct {
 	ExternalURL     string    `json:"external_url"`
 	IsPublic        bool      `json:"is_public"`
 	PublicSubdomain string    `json:"public_subdomain,omitempty"`
+	Hostnames       []string   `json:"hostnames,omitempty"`
 	TargetService   string     `json:"target_service,omitempty"`
 	TargetNamespace string     `json:"target_namespace,omitempty"`
 	LastHeartbeatAt *time.Time `json:"last_heartbeat_at,omitempty"`
@@ -44,6 +45,10 @@ type TunnelCreateRequest struct {
 	TargetNamespace   string `json:"target_namespace,omitempty"`
 	BasicAuthUser     string `json:"basic_auth_user,omitempty"`
 	BasicAuthPassword string `json:"basic_auth_password,omitempty"`
+	// Hostnames are extra hosts the public edge routes to this tunnel, e.g.
+	// from --route api.example.com=/:8080. Single labels are expanded under
+	// the tunnel domain.
+	Hostnames []string `json:"hostnames,omitempty"`
 }
 
 // CreateTunnel creates a new tunnel exposing a device port.
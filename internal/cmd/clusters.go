@@ -0,0 +1,347 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/prysmsh/cli/internal/api"
+	"github.com/prysmsh/cli/internal/charts"
+	"github.com/prysmsh/cli/internal/style"
+	"github.com/prysmsh/cli/internal/ui"
+	"github.com/prysmsh/cli/internal/util"
+)
+
+func newClustersCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "clusters",
+		Aliases: []string{"cluster"},
+		Short:   "Manage connected Kubernetes clusters",
+	}
+	cmd.AddCommand(newClusterTokenCommand())
+	return cmd
+}
+
+func newClusterTokenCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "token",
+		Short: "Manage the tokens cluster agents authenticate with",
+	}
+	cmd.AddCommand(
+		newClusterTokenListCommand(),
+		newClusterTokenRotateCommand(),
+		newClusterTokenRevokeCommand(),
+	)
+	return cmd
+}
+
+func newClusterTokenListCommand() *cobra.Command {
+	var outputFormat string
+
+	cmd := &cobra.Command{
+		Use:   "list <cluster>",
+		Short: "List a cluster's agent tokens",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app := MustApp()
+			ctx, cancel := context.WithTimeout(cmd.Context(), 20*time.Second)
+			defer cancel()
+
+			cluster, err := resolveClusterForTunnel(ctx, app, args[0])
+			if err != nil {
+				return err
+			}
+			tokens, err := app.API.ListAgentTokens(ctx, cluster.ID)
+			if err != nil {
+				return fmt.Errorf("list agent tokens: %w", err)
+			}
+			if wantsJSONOutput(outputFormat) {
+				return writeJSON(tokens)
+			}
+			if len(tokens) == 0 {
+				fmt.Println(style.MutedStyle.Render(fmt.Sprintf("No agent tokens for %s.", cluster.Name)))
+				return nil
+			}
+			data := make([][]string, len(tokens))
+			for i, t := range tokens {
+				status := "active"
+				if !t.Active() {
+					status = "revoked"
+				}
+				data[i] = []string{strconv.FormatInt(t.ID, 10), dashIfEmpty(t.Prefix), t.CreatedAt.Local().Format("2006-01-02 15:04"), meshDeviceTime(t.LastUsedAt), status}
+			}
+			ui.PrintTable([]string{"ID", "PREFIX", "CREATED", "LAST USED", "STATUS"}, data)
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "", "output format (table, json)")
+	return cmd
+}
+
+func newClusterTokenRotateCommand() *cobra.Command {
+	var (
+		push        string
+		release     string
+		namespace   string
+		kubeContext string
+		wait        time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "rotate <cluster>",
+		Short: "Issue a new agent token, hand it to the agent and revoke the old ones",
+		Long: `Issue a new agent token for a cluster, deliver it to the agent, and revoke the
+tokens it used before once the agent has connected with the new one.
+
+With --push backend (the default) the backend hands the token to the connected
+agent over its current session. With --push helm the token is written to the
+agent release's values (helm upgrade --reuse-values with the chart bundled in
+this CLI), which restarts the agent; this needs helm, access to the cluster and
+a release that manages its own config secret.
+
+If the agent does not come back with the new token within --wait, the old
+tokens are left active so the cluster stays connected.`,
+		Example: `  prysm clusters token rotate prod-eu
+  prysm clusters token rotate prod-eu --push helm --namespace prysm-system --kube-context prod`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if push != "backend" && push != "helm" {
+				return fmt.Errorf("--push must be backend or helm (got %q)", push)
+			}
+			app := MustApp()
+			ctx := cmd.Context()
+
+			lookupCtx, cancel := context.WithTimeout(ctx, 20*time.Second)
+			cluster, err := resolveClusterForTunnel(lookupCtx, app, args[0])
+			cancel()
+			if err != nil {
+				return err
+			}
+
+			deliver := func(ctx context.Context, tok *api.AgentToken) error {
+				if push == "helm" {
+					ns := namespace
+					if ns == "" {
+						ns = cluster.Namespace
+					}
+					return pushAgentTokenHelm(ctx, tok.Token, release, ns, kubeContext)
+				}
+				return app.API.PushAgentToken(ctx, cluster.ID, tok.ID)
+			}
+
+			var rot *agentTokenRotation
+			err = ui.WithSpinner(fmt.Sprintf("Rotating the agent token of %s...", cluster.Name), func() error {
+				var rotErr error
+				rot, rotErr = rotateAgentToken(ctx, app.API, cluster.ID, deliver, wait, 5*time.Second)
+				return rotErr
+			})
+			if rot != nil && rot.Token != nil {
+				fmt.Println(style.Success.Render(fmt.Sprintf("✓ Issued token %d and delivered it via %s", rot.Token.ID, push)))
+			}
+			if err != nil {
+				return err
+			}
+			if len(rot.Revoked) > 0 {
+				ids := make([]string, len(rot.Revoked))
+				for i, id := range rot.Revoked {
+					ids[i] = strconv.FormatInt(id, 10)
+				}
+				fmt.Println(style.Success.Render(fmt.Sprintf("✓ Agent reconnected; revoked old token(s) %s", strings.Join(ids, ", "))))
+			} else {
+				fmt.Println(style.Success.Render("✓ Agent reconnected with the new token"))
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&push, "push", "backend", "how to deliver the new token: backend or helm")
+	cmd.Flags().StringVar(&release, "release", "prysm-agent", "Helm release of the agent (with --push helm)")
+	cmd.Flags().StringVar(&namespace, "namespace", "", "namespace of the agent release (default: the cluster's namespace)")
+	cmd.Flags().StringVar(&kubeContext, "kube-context", "", "kubeconfig context to use (with --push helm)")
+	cmd.Flags().DurationVar(&wait, "wait", 3*time.Minute, "how long to wait for the agent to use the new token before giving up")
+	return cmd
+}
+
+func newClusterTokenRevokeCommand() *cobra.Command {
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "revoke <id>",
+		Short: "Revoke an agent token",
+		Long: `Revoke an agent token immediately. Agents still using it are disconnected
+until they get a new one (see ` + "`prysm clusters token rotate`" + `). Token IDs are shown
+by ` + "`prysm clusters token list <cluster>`" + `.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil || id <= 0 {
+				return fmt.Errorf("invalid token id %q", args[0])
+			}
+			if !yes {
+				ok, err := util.PromptConfirm(fmt.Sprintf("Revoke agent token %d? Agents using it are disconnected.", id), false)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					fmt.Println(style.MutedStyle.Render("Revocation cancelled."))
+					return nil
+				}
+			}
+
+			app := MustApp()
+			ctx, cancel := context.WithTimeout(cmd.Context(), 20*time.Second)
+			defer cancel()
+			if err := app.API.RevokeAgentToken(ctx, id); err != nil {
+				return fmt.Errorf("revoke agent token: %w", err)
+			}
+			fmt.Println(style.Success.Render(fmt.Sprintf("✓ Agent token %d revoked", id)))
+			return nil
+		},
+	}
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "do not ask for confirmation")
+	return cmd
+}
+
+type agentTokenRotation struct {
+	Token   *api.AgentToken
+	Revoked []int64
+}
+
+// rotateAgentToken issues a new token, delivers it, waits until the agent
+// has authenticated with it and then revokes the tokens that were active
+// before. A token that could not be delivered is revoked again; old tokens
+// are only revoked once the new one is in use.
+func rotateAgentToken(ctx context.Context, client *api.Client, clusterID int64, deliver func(context.Context, *api.AgentToken) error, wait, interval time.Duration) (*agentTokenRotation, error) {
+	reqCtx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	before, err := client.ListAgentTokens(reqCtx, clusterID)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("list agent tokens: %w", err)
+	}
+	tok, err := client.CreateAgentToken(reqCtx, clusterID)
+	cancel()
+	if err != nil {
+		return nil, fmt.Errorf("create agent token: %w", err)
+	}
+	rot := &agentTokenRotation{Token: tok}
+
+	pushCtx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	err = deliver(pushCtx, tok)
+	cancel()
+	if err != nil {
+		revokeCtx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+		defer cancel()
+		if revokeErr := client.RevokeAgentToken(revokeCtx, tok.ID); revokeErr != nil {
+			printDebug("revoke undelivered token %d: %v", tok.ID, revokeErr)
+		}
+		return nil, fmt.Errorf("deliver agent token: %w", err)
+	}
+
+	if err := waitForAgentToken(ctx, client, clusterID, tok.ID, wait, interval); err != nil {
+		return rot, fmt.Errorf("%w; the previous tokens are still active — check the agent, then revoke them with `prysm clusters token revoke <id>`", err)
+	}
+
+	var errs []error
+	for _, old := range before {
+		if !old.Active() || old.ID == tok.ID {
+			continue
+		}
+		reqCtx, cancel := context.WithTimeout(ctx, 20*time.Second)
+		err := client.RevokeAgentToken(reqCtx, old.ID)
+		cancel()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("revoke token %d: %w", old.ID, err))
+			continue
+		}
+		rot.Revoked = append(rot.Revoked, old.ID)
+	}
+	return rot, errors.Join(errs...)
+}
+
+// waitForAgentToken polls until the backend has seen the agent use tokenID.
+func waitForAgentToken(ctx context.Context, client *api.Client, clusterID, tokenID int64, wait, interval time.Duration) error {
+	deadline := time.Now().Add(wait)
+	for {
+		reqCtx, cancel := context.WithTimeout(ctx, 20*time.Second)
+		tokens, err := client.ListAgentTokens(reqCtx, clusterID)
+		cancel()
+		if err != nil {
+			printDebug("poll agent tokens: %v", err)
+		}
+		for _, t := range tokens {
+			if t.ID == tokenID && t.LastUsedAt != nil {
+				return nil
+			}
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("the agent has not connected with token %d after %s", tokenID, wait)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// pushAgentTokenHelm sets the agent token in the release's values. The token
+// goes through a private values file rather than --set, so it does not show
+// up in the process list.
+func pushAgentTokenHelm(ctx context.Context, token, release, namespace, kubeContext string) error {
+	helm, err := exec.LookPath("helm")
+	if err != nil {
+		return errors.New("helm not found in PATH; install it or use --push backend")
+	}
+	chartPath, cleanupDir, err := charts.ExtractAgentChart()
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(cleanupDir)
+
+	valuesPath, err := writeAgentTokenValues(cleanupDir, token)
+	if err != nil {
+		return err
+	}
+	out, err := exec.CommandContext(ctx, helm, agentTokenHelmArgs(release, chartPath, namespace, kubeContext, valuesPath)...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("helm upgrade %s: %s: %w", release, strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
+
+// writeAgentTokenValues writes a values file that only overrides the agent
+// token, readable by the current user alone.
+func writeAgentTokenValues(dir, token string) (string, error) {
+	// A JSON string is a valid YAML scalar and needs no further escaping.
+	quoted, err := json.Marshal(token)
+	if err != nil {
+		return "", err
+	}
+	f, err := os.CreateTemp(dir, "agent-token-*.yaml")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	if _, err := fmt.Fprintf(f, "configSecret:\n  data:\n    AGENT_TOKEN: %s\n", quoted); err != nil {
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+func agentTokenHelmArgs(release, chartPath, namespace, kubeContext, valuesPath string) []string {
+	args := []string{"upgrade", release, chartPath, "--reuse-values", "--values", valuesPath, "--wait"}
+	if namespace != "" {
+		args = append(args, "--namespace", namespace)
+	}
+	if kubeContext != "" {
+		args = append(args, "--kube-context", kubeContext)
+	}
+	return args
+}
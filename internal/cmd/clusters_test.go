@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prysmsh/cli/internal/api"
+)
+
+// fakeAgentTokens serves the agent token endpoints for cluster 7. Token 1 is
+// active and token 2 already revoked; created tokens get used as soon as they
+// are pushed when used is true.
+type fakeAgentTokens struct {
+	mu      sync.Mutex
+	tokens  []api.AgentToken
+	used    bool
+	revoked []int64
+}
+
+func newFakeAgentTokens(used bool) *fakeAgentTokens {
+	now := time.Now()
+	return &fakeAgentTokens{
+		used: used,
+		tokens: []api.AgentToken{
+			{ID: 1, ClusterID: 7, CreatedAt: now},
+			{ID: 2, ClusterID: 7, CreatedAt: now, RevokedAt: &now},
+		},
+	}
+}
+
+func (f *fakeAgentTokens) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v1/clusters/7/agent-tokens":
+		json.NewEncoder(w).Encode(map[string]interface{}{"tokens": f.tokens})
+	case r.Method == http.MethodPost && r.URL.Path == "/api/v1/clusters/7/agent-tokens":
+		tok := api.AgentToken{ID: 3, ClusterID: 7, Token: "agt_new", CreatedAt: time.Now()}
+		f.tokens = append(f.tokens, tok)
+		json.NewEncoder(w).Encode(map[string]interface{}{"token": tok})
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/api/v1/agent-tokens/"):
+		id, _ := strconv.ParseInt(strings.TrimPrefix(r.URL.Path, "/api/v1/agent-tokens/"), 10, 64)
+		for i := range f.tokens {
+			if f.tokens[i].ID == id {
+				now := time.Now()
+				f.tokens[i].RevokedAt = &now
+				f.revoked = append(f.revoked, f.tokens[i].ID)
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (f *fakeAgentTokens) markUsed() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.used {
+		return
+	}
+	now := time.Now()
+	for i := range f.tokens {
+		if f.tokens[i].ID == 3 {
+			f.tokens[i].LastUsedAt = &now
+		}
+	}
+}
+
+func TestRotateAgentToken(t *testing.T) {
+	fake := newFakeAgentTokens(true)
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	var delivered string
+	deliver := func(ctx context.Context, tok *api.AgentToken) error {
+		delivered = tok.Token
+		fake.markUsed()
+		return nil
+	}
+	rot, err := rotateAgentToken(context.Background(), api.NewClient(srv.URL), 7, deliver, time.Second, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("rotateAgentToken: %v", err)
+	}
+	if delivered != "agt_new" {
+		t.Errorf("delivered %q, want agt_new", delivered)
+	}
+	if rot.Token.ID != 3 {
+		t.Errorf("new token id = %d, want 3", rot.Token.ID)
+	}
+	// Only the previously active token is revoked; the new one is kept.
+	if len(fake.revoked) != 1 || fake.revoked[0] != 1 {
+		t.Errorf("revoked %v, want [1]", fake.revoked)
+	}
+}
+
+func TestRotateAgentTokenKeepsOldTokenWhenUnused(t *testing.T) {
+	fake := newFakeAgentTokens(false)
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	deliver := func(ctx context.Context, tok *api.AgentToken) error { return nil }
+	_, err := rotateAgentToken(context.Background(), api.NewClient(srv.URL), 7, deliver, 30*time.Millisecond, 10*time.Millisecond)
+	if err == nil {
+		t.Fatal("expected a timeout error")
+	}
+	if len(fake.revoked) != 0 {
+		t.Errorf("revoked %v, want none", fake.revoked)
+	}
+}
+
+func TestRotateAgentTokenRevokesUndeliveredToken(t *testing.T) {
+	fake := newFakeAgentTokens(true)
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	deliver := func(ctx context.Context, tok *api.AgentToken) error { return errors.New("agent offline") }
+	_, err := rotateAgentToken(context.Background(), api.NewClient(srv.URL), 7, deliver, time.Second, 10*time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "agent offline") {
+		t.Fatalf("err = %v, want delivery error", err)
+	}
+	if len(fake.revoked) != 1 || fake.revoked[0] != 3 {
+		t.Errorf("revoked %v, want [3]", fake.revoked)
+	}
+}
+
+func TestWriteAgentTokenValues(t *testing.T) {
+	dir := t.TempDir()
+	path, err := writeAgentTokenValues(dir, `agt_"x`)
+	if err != nil {
+		t.Fatalf("writeAgentTokenValues: %v", err)
+	}
+	if filepath.Dir(path) != dir {
+		t.Errorf("values file written to %s, want %s", filepath.Dir(path), dir)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "configSecret:\n  data:\n    AGENT_TOKEN: \"agt_\\\"x\"\n"
+	if string(data) != want {
+		t.Errorf("values = %q, want %q", data, want)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("values file mode = %o, want 600", perm)
+	}
+}
+
+func TestAgentTokenHelmArgs(t *testing.T) {
+	got := strings.Join(agentTokenHelmArgs("prysm-agent", "/tmp/chart", "prysm-system", "prod", "/tmp/v.yaml"), " ")
+	want := "upgrade prysm-agent /tmp/chart --reuse-values --values /tmp/v.yaml --wait --namespace prysm-system --kube-context prod"
+	if got != want {
+		t.Errorf("args = %q, want %q", got, want)
+	}
+	got = strings.Join(agentTokenHelmArgs("prysm-agent", "/tmp/chart", "", "", "/tmp/v.yaml"), " ")
+	if strings.Contains(got, "--namespace") || strings.Contains(got, "--kube-context") {
+		t.Errorf("args = %q, want no namespace or context", got)
+	}
+}
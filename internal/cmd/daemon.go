@@ -3,12 +3,16 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strconv"
 	"syscall"
+	"time"
 
 	"github.com/prysmsh/cli/internal/meshd"
+	"github.com/prysmsh/cli/internal/style"
 	"github.com/spf13/cobra"
 )
 
@@ -27,10 +31,17 @@ func newDaemonCommand() *cobra.Command {
 
 	runCmd := &cobra.Command{
 		Use:    "run",
-		Short:  "Run the daemon process (used by launchd)",
+		Short:  "Run the daemon process (used by launchd and systemd)",
 		Hidden: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			socketPath, _ := cmd.Flags().GetString("socket")
+			metricsAddr, _ := cmd.Flags().GetString("metrics-listen")
+			socketGroup, _ := cmd.Flags().GetString("socket-group")
+			socketModeStr, _ := cmd.Flags().GetString("socket-mode")
+			socketMode, err := parseSocketMode(socketModeStr)
+			if err != nil {
+				return err
+			}
 
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
@@ -43,17 +54,31 @@ func newDaemonCommand() *cobra.Command {
 				cancel()
 			}()
 
-			srv := meshd.NewServer(socketPath)
+			srv := meshd.NewServer(socketPath, meshd.WithSocketGroup(socketGroup), meshd.WithSocketMode(socketMode))
+			if metricsAddr != "" {
+				go func() {
+					if err := srv.ServeMetrics(ctx, metricsAddr); err != nil {
+						fmt.Fprintf(os.Stderr, "meshd: %v\n", err)
+						cancel()
+					}
+				}()
+			}
 			return srv.Serve(ctx)
 		},
 	}
 	runCmd.Flags().String("socket", meshd.SocketPath, "Unix domain socket path")
+	runCmd.Flags().String("metrics-listen", "", "also serve /healthz and Prometheus /metrics over TCP on this address (e.g. :9469, which binds to 127.0.0.1)")
+	runCmd.Flags().String("socket-group", "", "group (name or gid) that owns the socket; defaults to the sudo user's primary group")
+	runCmd.Flags().String("socket-mode", "0660", "socket permission bits (octal)")
 
 	installCmd := &cobra.Command{
 		Use:   "install",
 		Short: "Install and start the mesh daemon",
 		RunE:  runDaemonInstall,
 	}
+	installCmd.Flags().String("metrics-listen", "", "have the daemon also serve /healthz and Prometheus /metrics over TCP on this address (loopback unless a host is given)")
+	installCmd.Flags().String("socket-group", "", "let members of this group use the daemon without sudo (e.g. prysm)")
+	installCmd.Flags().String("socket-mode", "", "socket permission bits (octal, default 0660)")
 
 	uninstallCmd := &cobra.Command{
 		Use:   "uninstall",
@@ -67,7 +92,34 @@ func newDaemonCommand() *cobra.Command {
 		RunE:  runDaemonStatus,
 	}
 
-	cmd.AddCommand(runCmd, installCmd, uninstallCmd, statusCmd)
+	reloadCmd := &cobra.Command{
+		Use:   "reload",
+		Short: "Re-apply the last mesh config and recreate the tunnel interface",
+		RunE:  runDaemonReload,
+	}
+
+	restartCmd := &cobra.Command{
+		Use:   "restart",
+		Short: "Restart the daemon service",
+		RunE:  runDaemonRestart,
+	}
+
+	var logLines int
+	var logFollow bool
+	logsCmd := &cobra.Command{
+		Use:   "logs",
+		Short: "Show daemon log output",
+		Long: `Show recent daemon log output. Logs are read from the running daemon over its
+socket; when the daemon is not running they come from journald (Linux) or
+/var/log/prysm/meshd.log (macOS).`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDaemonLogs(logLines, logFollow)
+		},
+	}
+	logsCmd.Flags().IntVarP(&logLines, "lines", "n", 100, "number of recent lines to show")
+	logsCmd.Flags().BoolVarP(&logFollow, "follow", "f", false, "keep printing new log lines")
+
+	cmd.AddCommand(runCmd, installCmd, uninstallCmd, statusCmd, reloadCmd, restartCmd, logsCmd)
 	return cmd
 }
 
@@ -90,7 +142,32 @@ func runDaemonInstall(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	return installDaemon(prysmBin)
+	var runArgs []string
+	if addr, _ := cmd.Flags().GetString("metrics-listen"); addr != "" {
+		runArgs = append(runArgs, "--metrics-listen", addr)
+	}
+	if group, _ := cmd.Flags().GetString("socket-group"); group != "" {
+		if _, err := meshd.LookupGroupID(group); err != nil {
+			return fmt.Errorf("%w — create it with `groupadd %s` and add users with `usermod -aG %s <user>`", err, group, group)
+		}
+		runArgs = append(runArgs, "--socket-group", group)
+	}
+	if mode, _ := cmd.Flags().GetString("socket-mode"); mode != "" {
+		if _, err := parseSocketMode(mode); err != nil {
+			return err
+		}
+		runArgs = append(runArgs, "--socket-mode", mode)
+	}
+	return installDaemon(prysmBin, runArgs)
+}
+
+// parseSocketMode parses octal permission bits such as "0660" or "660".
+func parseSocketMode(s string) (os.FileMode, error) {
+	mode, err := strconv.ParseUint(s, 8, 32)
+	if err != nil || mode&^0o777 != 0 {
+		return 0, fmt.Errorf("invalid socket mode %q: want octal permission bits such as 0660", s)
+	}
+	return os.FileMode(mode), nil
 }
 
 func runDaemonUninstall(cmd *cobra.Command, args []string) error {
@@ -130,3 +207,110 @@ func runDaemonStatus(cmd *cobra.Command, args []string) error {
 	}
 	return nil
 }
+
+func runDaemonReload(cmd *cobra.Command, args []string) error {
+	if !meshd.IsRunning() {
+		return fmt.Errorf("daemon is not running — start it with `sudo prysm daemon install`")
+	}
+	resp, err := meshd.Reload()
+	if err != nil {
+		return fmt.Errorf("reload daemon: %w", err)
+	}
+	if resp.Error != "" {
+		return fmt.Errorf("meshd: %s", resp.Error)
+	}
+	if resp.OverlayIP != "" {
+		fmt.Println(style.Success.Render(fmt.Sprintf("Mesh reloaded (%s on %s)", resp.OverlayIP, resp.Interface)))
+	} else {
+		fmt.Println(style.Success.Render(fmt.Sprintf("Mesh reloaded (status: %s)", resp.Status)))
+	}
+	return nil
+}
+
+func runDaemonRestart(cmd *cobra.Command, args []string) error {
+	if os.Geteuid() != 0 {
+		return fmt.Errorf("daemon restart requires root — run with sudo")
+	}
+	wasConnected := false
+	if meshd.IsRunning() {
+		if st, err := meshd.GetStatus(); err == nil && st.Status != "disconnected" {
+			wasConnected = true
+		}
+	}
+
+	if err := restartDaemon(); err != nil {
+		return err
+	}
+	deadline := time.Now().Add(10 * time.Second)
+	for !meshd.IsRunning() {
+		if time.Now().After(deadline) {
+			return fmt.Errorf("daemon did not come back after restart — check `prysm daemon logs`")
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+
+	fmt.Println(style.Success.Render("Prysm mesh daemon restarted"))
+	if wasConnected {
+		fmt.Println(style.MutedStyle.Render("Run `prysm mesh connect` to rejoin the mesh."))
+	}
+	return nil
+}
+
+func runDaemonLogs(lines int, follow bool) error {
+	if !meshd.IsRunning() {
+		return daemonSystemLogs(lines, follow)
+	}
+
+	resp, err := meshd.Logs(lines, 0)
+	if err != nil {
+		return fmt.Errorf("query daemon: %w", err)
+	}
+	if resp.Error != "" {
+		return fmt.Errorf("meshd: %s", resp.Error)
+	}
+	for _, line := range resp.Logs {
+		fmt.Println(line)
+	}
+	if !follow {
+		return nil
+	}
+
+	return followDaemonLogs(os.Stdout, meshd.Logs, resp, time.Second)
+}
+
+// followDaemonLogs polls fetch for lines newer than last and writes them to
+// out. It waits for the daemon while it is unreachable, and after a restart
+// (a new log epoch) prints the new run's buffer from the start.
+func followDaemonLogs(out io.Writer, fetch func(lines int, since int64) (*meshd.Response, error), last *meshd.Response, interval time.Duration) error {
+	seq, epoch := last.LogSeq, last.LogEpoch
+	down := false
+	for {
+		time.Sleep(interval)
+		resp, err := fetch(0, seq)
+		if err != nil {
+			if !down {
+				fmt.Fprintln(os.Stderr, style.MutedStyle.Render("Daemon not responding; waiting for it to come back..."))
+				down = true
+			}
+			continue
+		}
+		down = false
+		if resp.Error != "" {
+			return fmt.Errorf("meshd: %s", resp.Error)
+		}
+		if resp.LogEpoch != epoch || resp.LogSeq < seq {
+			fmt.Fprintln(os.Stderr, style.MutedStyle.Render("Daemon restarted."))
+			// Since 0 asks for the whole buffer of the new run.
+			if resp, err = fetch(0, 0); err != nil {
+				continue
+			}
+			if resp.Error != "" {
+				return fmt.Errorf("meshd: %s", resp.Error)
+			}
+		}
+		for _, line := range resp.Logs {
+			fmt.Fprintln(out, line)
+		}
+		seq, epoch = resp.LogSeq, resp.LogEpoch
+	}
+}
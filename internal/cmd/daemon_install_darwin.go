@@ -5,6 +5,8 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/prysmsh/cli/internal/meshd"
 	"github.com/prysmsh/cli/internal/style"
@@ -15,7 +17,11 @@ const (
 	launchdPlistDir = "/Library/LaunchDaemons"
 )
 
-func installDaemon(prysmBin string) error {
+func installDaemon(prysmBin string, runArgs []string) error {
+	var extraArgs strings.Builder
+	for _, a := range runArgs {
+		fmt.Fprintf(&extraArgs, "\n        <string>%s</string>", a)
+	}
 	plist := fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
 <!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
 <plist version="1.0">
@@ -26,7 +32,7 @@ func installDaemon(prysmBin string) error {
     <array>
         <string>%s</string>
         <string>daemon</string>
-        <string>run</string>
+        <string>run</string>%s
     </array>
     <key>RunAtLoad</key>
     <true/>
@@ -48,7 +54,7 @@ func installDaemon(prysmBin string) error {
     </dict>
 </dict>
 </plist>
-`, launchdLabel, prysmBin, daemonLogDir, daemonLogDir, daemonStateDir)
+`, launchdLabel, prysmBin, extraArgs.String(), daemonLogDir, daemonLogDir, daemonStateDir)
 
 	plistPath := filepath.Join(launchdPlistDir, launchdLabel+".plist")
 	if err := os.WriteFile(plistPath, []byte(plist), 0644); err != nil {
@@ -80,3 +86,24 @@ func uninstallDaemon() error {
 	fmt.Println(style.Success.Render("Prysm mesh daemon uninstalled"))
 	return nil
 }
+
+func restartDaemon() error {
+	if out, err := exec.Command("launchctl", "kickstart", "-k", "system/"+launchdLabel).CombinedOutput(); err != nil {
+		return fmt.Errorf("launchctl kickstart: %s: %w", string(out), err)
+	}
+	return nil
+}
+
+// daemonSystemLogs shows the daemon's launchd log file.
+func daemonSystemLogs(lines int, follow bool) error {
+	args := []string{"-n", strconv.Itoa(lines)}
+	if follow {
+		args = append(args, "-F")
+	}
+	tail := exec.Command("tail", append(args, filepath.Join(daemonLogDir, "meshd.log"))...)
+	tail.Stdout, tail.Stderr = os.Stdout, os.Stderr
+	if err := tail.Run(); err != nil {
+		return fmt.Errorf("read daemon log: %w", err)
+	}
+	return nil
+}
@@ -4,13 +4,15 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"strconv"
 	"strings"
 
 	"github.com/prysmsh/cli/internal/meshd"
 	"github.com/prysmsh/cli/internal/style"
 )
 
-func installDaemon(prysmBin string) error {
+func installDaemon(prysmBin string, runArgs []string) error {
+	execStart := strings.Join(append([]string{prysmBin, "daemon", "run"}, runArgs...), " ")
 	unit := fmt.Sprintf(`[Unit]
 Description=Prysm Mesh Daemon
 After=network-online.target
@@ -18,7 +20,7 @@ Wants=network-online.target
 
 [Service]
 Type=simple
-ExecStart=%s daemon run
+ExecStart=%s
 Restart=on-failure
 RestartSec=5
 RuntimeDirectory=prysm
@@ -26,7 +28,7 @@ RuntimeDirectoryMode=0755
 
 [Install]
 WantedBy=multi-user.target
-`, prysmBin)
+`, execStart)
 
 	if err := os.WriteFile("/etc/systemd/system/prysm-meshd.service", []byte(unit), 0644); err != nil {
 		return fmt.Errorf("write systemd unit: %w", err)
@@ -59,3 +61,24 @@ func uninstallDaemon() error {
 	fmt.Println(style.Success.Render("Prysm mesh daemon uninstalled"))
 	return nil
 }
+
+func restartDaemon() error {
+	if out, err := exec.Command("systemctl", "restart", "prysm-meshd").CombinedOutput(); err != nil {
+		return fmt.Errorf("systemctl restart: %s: %w", strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
+
+// daemonSystemLogs shows the daemon's journald output.
+func daemonSystemLogs(lines int, follow bool) error {
+	args := []string{"-u", "prysm-meshd", "--no-pager", "-n", strconv.Itoa(lines)}
+	if follow {
+		args = append(args, "-f")
+	}
+	journal := exec.Command("journalctl", args...)
+	journal.Stdout, journal.Stderr = os.Stdout, os.Stderr
+	if err := journal.Run(); err != nil {
+		return fmt.Errorf("journalctl: %w", err)
+	}
+	return nil
+}
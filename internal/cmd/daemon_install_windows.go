@@ -2,10 +2,18 @@ package cmd
 
 import "fmt"
 
-func installDaemon(_ string) error {
-	return fmt.Errorf("daemon install not yet supported on Windows — use WSL or run prysm mesh join directly")
+func installDaemon(_ string, _ []string) error {
+	return fmt.Errorf("daemon install not yet supported on Windows — run `prysm daemon run` from an elevated prompt, or use WSL")
 }
 
 func uninstallDaemon() error {
 	return fmt.Errorf("daemon uninstall not yet supported on Windows")
 }
+
+func restartDaemon() error {
+	return fmt.Errorf("daemon restart not yet supported on Windows")
+}
+
+func daemonSystemLogs(_ int, _ bool) error {
+	return fmt.Errorf("daemon is not running")
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/prysmsh/cli/internal/meshd"
+)
+
+func TestParseSocketMode(t *testing.T) {
+	tests := map[string]os.FileMode{"0660": 0o660, "660": 0o660, "0600": 0o600, "0666": 0o666}
+	for in, want := range tests {
+		got, err := parseSocketMode(in)
+		if err != nil || got != want {
+			t.Errorf("parseSocketMode(%q) = %o, %v; want %o", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"", "rw-rw----", "0789", "4755"} {
+		if _, err := parseSocketMode(bad); err == nil {
+			t.Errorf("parseSocketMode(%q) should fail", bad)
+		}
+	}
+}
+
+func TestFollowDaemonLogs(t *testing.T) {
+	type reply struct {
+		since int64
+		resp  *meshd.Response
+		err   error
+	}
+	replies := []reply{
+		{since: 3, resp: &meshd.Response{Logs: []string{"c"}, LogSeq: 4, LogEpoch: 1}},
+		{since: 4, err: errors.New("connection refused")},
+		// Restarted daemon: a new epoch and a smaller sequence number.
+		{since: 4, resp: &meshd.Response{Logs: []string{"x"}, LogSeq: 2, LogEpoch: 2}},
+		{since: 0, resp: &meshd.Response{Logs: []string{"started", "x"}, LogSeq: 3, LogEpoch: 2}},
+		{since: 3, resp: &meshd.Response{Error: "shutting down"}},
+	}
+	fetch := func(lines int, since int64) (*meshd.Response, error) {
+		if len(replies) == 0 {
+			t.Fatal("unexpected fetch")
+		}
+		r := replies[0]
+		replies = replies[1:]
+		if since != r.since {
+			t.Errorf("fetch since = %d, want %d", since, r.since)
+		}
+		return r.resp, r.err
+	}
+
+	var out bytes.Buffer
+	err := followDaemonLogs(&out, fetch, &meshd.Response{LogSeq: 3, LogEpoch: 1}, 0)
+	if err == nil || err.Error() != "meshd: shutting down" {
+		t.Errorf("err = %v", err)
+	}
+	if out.String() != "c\nstarted\nx\n" {
+		t.Errorf("output = %q", out.String())
+	}
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
@@ -32,6 +33,33 @@ func isArgValidationError(msg string) bool {
 	return false
 }
 
+// exitCodeError makes the process exit with a specific status instead of 1.
+type exitCodeError struct {
+	code int
+	err  error
+}
+
+func (e *exitCodeError) Error() string { return e.err.Error() }
+func (e *exitCodeError) Unwrap() error { return e.err }
+
+// withExitCode attaches a process exit status to err.
+func withExitCode(code int, err error) error {
+	return &exitCodeError{code: code, err: err}
+}
+
+// ExitCode returns the process exit status for an error returned by Execute:
+// 0 for nil, the status attached by the command, or 1.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var ec *exitCodeError
+	if errors.As(err, &ec) {
+		return ec.code
+	}
+	return 1
+}
+
 // friendlyError rewrites cobra's terse validation errors into helpful messages.
 func friendlyError(err error) error {
 	msg := err.Error()
@@ -177,3 +177,19 @@ func TestRegexPatterns(t *testing.T) {
 		t.Error("reUnknownCmd should match")
 	}
 }
+
+func TestExitCode(t *testing.T) {
+	if got := ExitCode(nil); got != 0 {
+		t.Errorf("ExitCode(nil) = %d, want 0", got)
+	}
+	if got := ExitCode(fmt.Errorf("boom")); got != 1 {
+		t.Errorf("ExitCode(plain) = %d, want 1", got)
+	}
+	err := friendlyError(withExitCode(2, fmt.Errorf("mesh down")))
+	if got := ExitCode(err); got != 2 {
+		t.Errorf("ExitCode(withExitCode(2)) = %d, want 2", got)
+	}
+	if err.Error() != "mesh down" {
+		t.Errorf("message = %q", err.Error())
+	}
+}
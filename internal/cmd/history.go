@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/prysmsh/cli/internal/history"
+	"github.com/prysmsh/cli/internal/style"
+	"github.com/prysmsh/cli/internal/ui"
+)
+
+func newHistoryCommand() *cobra.Command {
+	var (
+		limit        int
+		command      string
+		failed       bool
+		since        string
+		outputFormat string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Show prysm commands recorded in the local journal",
+		Long: `Show prysm invocations recorded in $PRYSM_HOME/history.jsonl: arguments
+(with tokens, passwords and keys redacted), exit code, duration and the API
+request IDs each command generated.
+
+Recording is off by default. Enable it with "history: true" in config.yaml or
+PRYSM_HISTORY=1.`,
+		Example: `  prysm history
+  prysm history --command tunnel --since 7d
+  prysm history --failed -o json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app := MustApp()
+
+			filter := history.Filter{Command: strings.TrimSpace(command), Failed: failed, Limit: limit}
+			if since != "" {
+				d, err := parseHistorySince(since)
+				if err != nil {
+					return err
+				}
+				filter.Since = time.Now().Add(-d)
+			}
+
+			entries, err := historyJournal(app).Read(filter)
+			if err != nil {
+				return err
+			}
+
+			if wantsJSONOutput(outputFormat) {
+				if entries == nil {
+					entries = []history.Entry{}
+				}
+				return writeJSON(entries)
+			}
+
+			if !app.Config.History {
+				fmt.Fprintln(os.Stderr, style.MutedStyle.Render("History recording is disabled; set \"history: true\" in config.yaml or PRYSM_HISTORY=1 to enable it."))
+			}
+			if len(entries) == 0 {
+				fmt.Println(style.MutedStyle.Render("No recorded commands."))
+				return nil
+			}
+
+			rows := make([][]string, 0, len(entries))
+			for _, e := range entries {
+				exit := style.Success.Render("0")
+				if e.ExitCode != 0 {
+					exit = style.Warning.Render(strconv.Itoa(e.ExitCode))
+				}
+				rows = append(rows, []string{
+					e.Time.Local().Format("2006-01-02 15:04:05"),
+					strings.Join(e.Args, " "),
+					exit,
+					(time.Duration(e.DurationMS) * time.Millisecond).String(),
+					strings.Join(e.RequestIDs, ","),
+				})
+			}
+			ui.PrintTable([]string{"TIME", "COMMAND", "EXIT", "DURATION", "REQUEST IDS"}, rows)
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVarP(&limit, "limit", "n", 20, "show the most recent N commands (0 for all)")
+	cmd.Flags().StringVar(&command, "command", "", "only commands under this path (e.g. \"tunnel\" or \"mesh connect\")")
+	cmd.Flags().BoolVar(&failed, "failed", false, "only commands that exited non-zero")
+	cmd.Flags().StringVar(&since, "since", "", "only commands newer than this (e.g. 2h, 7d)")
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "", "output format (table, json)")
+	return cmd
+}
+
+func historyJournal(app *App) *history.Journal {
+	return history.NewJournal(filepath.Join(app.Config.HomeDir, history.File))
+}
+
+// parseHistorySince accepts Go durations plus a day suffix ("7d").
+func parseHistorySince(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid --since %q", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid --since %q (use e.g. 30m, 2h, 7d)", s)
+	}
+	return d, nil
+}
+
+// recordHistory appends the finished invocation to the local journal when
+// history is enabled. Shell completion requests are not recorded.
+func recordHistory(cmd *cobra.Command, start time.Time, cmdErr error) {
+	if app == nil || !app.Config.History || cmd == nil || isCompletionCommand() {
+		return
+	}
+	if cmd.Name() == cobra.ShellCompRequestCmd || cmd.Name() == cobra.ShellCompNoDescRequestCmd {
+		return
+	}
+
+	entry := history.Entry{
+		Time:       start.UTC(),
+		Command:    strings.TrimPrefix(strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()), " "),
+		Args:       history.Redact(os.Args[1:]),
+		DurationMS: time.Since(start).Milliseconds(),
+		Profile:    app.Config.Profile,
+		RequestIDs: app.API.RequestIDs(),
+	}
+	if cmdErr != nil {
+		entry.ExitCode = ExitCode(cmdErr)
+		entry.Error = cmdErr.Error()
+	}
+	if err := historyJournal(app).Append(entry); err != nil {
+		fmt.Fprintln(os.Stderr, style.Warning.Render(fmt.Sprintf("Could not record command history: %v", err)))
+	}
+}
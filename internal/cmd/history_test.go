@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseHistorySince(t *testing.T) {
+	tests := map[string]time.Duration{
+		"30m": 30 * time.Minute,
+		"2h":  2 * time.Hour,
+		"7d":  7 * 24 * time.Hour,
+	}
+	for in, want := range tests {
+		got, err := parseHistorySince(in)
+		if err != nil || got != want {
+			t.Errorf("parseHistorySince(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "abc", "-1h", "xd", "-2d"} {
+		if _, err := parseHistorySince(in); err == nil {
+			t.Errorf("parseHistorySince(%q) should fail", in)
+		}
+	}
+}
@@ -130,14 +130,18 @@ func runPasswordLogin(ctx context.Context, app *App, email, password string) err
 		return fmt.Errorf("login failed: %w", err)
 	}
 
-	orgID := int64(0)
-	orgName := ""
-	if loginResp.Organization.ID != 0 {
-		orgID = loginResp.Organization.ID
-		orgName = loginResp.Organization.Name
+	if err := app.Sessions.Save(sessionFromLogin(app, loginResp)); err != nil {
+		return err
 	}
 
-	sess := &session.Session{
+	printLoginWelcome(loginResp.User.Name, loginResp.User.Email)
+	return nil
+}
+
+// sessionFromLogin builds the cached session for a /auth/login style
+// response.
+func sessionFromLogin(app *App, loginResp *api.LoginResponse) *session.Session {
+	return &session.Session{
 		Token:         loginResp.Token,
 		RefreshToken:  loginResp.RefreshToken,
 		Email:         loginResp.User.Email,
@@ -150,20 +154,14 @@ func runPasswordLogin(ctx context.Context, app *App, email, password string) err
 			MFAEnabled: loginResp.User.MFAEnabled,
 		},
 		Organization: session.SessionOrg{
-			ID:   orgID,
-			Name: orgName,
+			ID:   loginResp.Organization.ID,
+			Name: loginResp.Organization.Name,
 		},
 		APIBaseURL:    app.Config.APIBaseURL,
 		ComplianceURL: app.Config.ComplianceURL,
 		DERPServerURL: app.Config.DERPServerURL,
 		OutputFormat:  app.OutputFormat,
 	}
-	if err := app.Sessions.Save(sess); err != nil {
-		return err
-	}
-
-	printLoginWelcome(loginResp.User.Name, loginResp.User.Email)
-	return nil
 }
 
 // runOAuthLogin performs OAuth login via browser and local callback server.
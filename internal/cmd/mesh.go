@@ -6,7 +6,6 @@ import (
 	"io"
 	"net"
 	"net/http"
-	neturl "net/url"
 	"os"
 	"os/exec"
 	"os/signal"
@@ -24,7 +23,9 @@ import (
 
 	"github.com/prysmsh/cli/internal/api"
 	"github.com/prysmsh/cli/internal/derp"
+	"github.com/prysmsh/cli/internal/mesh"
 	"github.com/prysmsh/cli/internal/meshd"
+	"github.com/prysmsh/cli/internal/meshdns"
 	"github.com/prysmsh/cli/internal/style"
 	"github.com/prysmsh/cli/internal/ui"
 	"github.com/prysmsh/cli/internal/util"
@@ -68,6 +69,19 @@ func removeDerpPidfile(home string) {
 	_ = os.Remove(filepath.Join(home, derpConnectPidFile))
 }
 
+// stopMeshBackground sends SIGTERM to a background `mesh connect` process and
+// removes its pidfile. It reports the PID and whether one was running.
+func stopMeshBackground(home string) (int, bool) {
+	pid, running := readDerpPidAndCheckRunning()
+	if running && pid > 0 {
+		if proc, err := os.FindProcess(pid); err == nil {
+			_ = proc.Signal(syscall.SIGTERM)
+		}
+	}
+	removeDerpPidfile(home)
+	return pid, running && pid > 0
+}
+
 func newMeshCommand() *cobra.Command {
 	meshCmd := &cobra.Command{
 		Use:   "mesh",
@@ -77,11 +91,20 @@ func newMeshCommand() *cobra.Command {
 	meshCmd.AddCommand(
 		newMeshConnectCommand(),
 		newMeshDisconnectCommand(),
+		newMeshDevicesCommand(),
+		newMeshDNSCommand(),
 		newMeshDoctorCommand(),
+		newMeshEnrollCommand(),
+		newMeshHealthCommand(),
 		newMeshPeersCommand(),
+		newMeshPingCommand(),
+		newMeshReceiveCommand(),
+		newMeshSendCommand(),
+		newMeshStatusCommand(),
 		newMeshRoutesCommand(),
 		newCrossClusterRoutesCommand(),
 		newMeshExitCommand(),
+		newMeshACLCommand(),
 	)
 
 	return meshCmd
@@ -149,11 +172,22 @@ func newMeshConnectCommand() *cobra.Command {
 	var foreground bool
 	var socks5Port int
 	var subnetEnabled bool
+	var userspace bool
 
 	c := &cobra.Command{
 		Use:   "connect",
 		Short: "Join the DERP mesh network and stream peer updates",
+		Long: `Join the DERP mesh network and stream peer updates.
+
+With --userspace the WireGuard tunnel runs inside this process instead of the
+mesh daemon. It uses a TUN device when the process may create one and
+otherwise falls back to a user-space network stack, which needs no root: mesh
+peers are then reached through the SOCKS5 proxy on --userspace-proxy. This is
+meant for containers and hosts where the daemon cannot be installed.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if userspace {
+				return runMeshConnect(cmd)
+			}
 			// Delegate to daemon if it's running (no sudo, no background fork).
 			if meshd.IsRunning() {
 				return runMeshConnectViaDaemon()
@@ -168,6 +202,8 @@ func newMeshConnectCommand() *cobra.Command {
 	c.Flags().IntVar(&socks5Port, "socks5-port", 0, "local port for SOCKS5 proxy to reach mesh routes (0 = disabled)")
 	c.Flags().BoolVar(&subnetEnabled, "subnet", true, "inject OS routes for cluster CIDRs (transparent routing; needs root/sudo)")
 	c.Flags().Bool("wireguard", true, "enable WireGuard tunnel for direct peer connectivity (requires sudo)")
+	c.Flags().BoolVar(&userspace, "userspace", false, "run WireGuard in this process without the daemon; falls back to user-space networking when TUN devices are not permitted")
+	c.Flags().String("userspace-proxy", "127.0.0.1:1055", "SOCKS5 listen address for reaching mesh peers in user-space networking mode")
 	return c
 }
 
@@ -193,15 +229,10 @@ func newMeshDisconnectCommand() *cobra.Command {
 			app := MustApp()
 			home := getPrysmHome()
 
-			pid, running := readDerpPidAndCheckRunning()
-			if running && pid > 0 {
-				proc, err := os.FindProcess(pid)
-				if err == nil {
-					_ = proc.Signal(syscall.SIGTERM)
-					fmt.Println(style.Success.Render(fmt.Sprintf("Sent SIGTERM to DERP process (PID %d)", pid)))
-				}
+			pid, running := stopMeshBackground(home)
+			if running {
+				fmt.Println(style.Success.Render(fmt.Sprintf("Sent SIGTERM to DERP process (PID %d)", pid)))
 			}
-			removeDerpPidfile(home)
 
 			// Best-effort stale subnet cleanup: if a previous process was killed
 			// without Stop(), stale REDIRECT rules can remain and break new sessions.
@@ -349,7 +380,7 @@ func runMeshConnectViaDaemon() error {
 		if st != nil && st.Status == "disconnected" {
 			return fmt.Errorf("daemon failed to connect — check `prysm daemon status` and re-login if your session expired")
 		}
-		return fmt.Errorf("daemon returned status %q with no overlay IP — check /var/log/prysm/meshd.log", resp.Status)
+		return fmt.Errorf("daemon returned status %q with no overlay IP — check `prysm daemon logs`", resp.Status)
 	}
 	fmt.Println(style.Success.Render(fmt.Sprintf("Mesh connected via daemon (%s on %s)", resp.OverlayIP, resp.Interface)))
 	fmt.Println(style.MutedStyle.Render("Daemon manages the tunnel — this CLI can exit safely."))
@@ -482,6 +513,7 @@ func runMeshConnect(cmd *cobra.Command) error {
 	}
 
 	wgEnabled, _ := cmd.Flags().GetBool("wireguard")
+	userspace, _ := cmd.Flags().GetBool("userspace")
 
 	headers := make(http.Header)
 	headers.Set("Authorization", "Bearer "+sess.Token)
@@ -554,7 +586,14 @@ func runMeshConnect(cmd *cobra.Command) error {
 	// Uses DERP as transport — WireGuard packets flow through the DERP WebSocket relay.
 	var wgTunnel *wg.Tunnel
 	if wgEnabled {
-		tun, bind, wgErr := wg.SetupMeshWireGuardDERP(ctx, app.API, app.Config.HomeDir, deviceID, derpClient)
+		setupWG := wg.SetupMeshWireGuardDERP
+		if userspace {
+			if err := wg.CheckTUNPrivileges(); err != nil {
+				fmt.Println(style.MutedStyle.Render(fmt.Sprintf("TUN device unavailable (%v); using user-space networking", err)))
+				setupWG = wg.SetupMeshWireGuardNetstack
+			}
+		}
+		tun, bind, wgErr := setupWG(ctx, app.API, app.Config.HomeDir, deviceID, derpClient)
 		if wgErr != nil {
 			fmt.Println(style.Warning.Render(fmt.Sprintf("WireGuard tunnel disabled: %v", wgErr)))
 		} else {
@@ -565,6 +604,16 @@ func runMeshConnect(cmd *cobra.Command) error {
 				bind.DeliverPacket(fromPeerID, packet)
 			}
 			fmt.Println(style.Success.Render(fmt.Sprintf("WireGuard tunnel active (%s on %s) via DERP", wgTunnel.OverlayIP(), wgTunnel.InterfaceName())))
+			if wgTunnel.Userspace() {
+				proxyAddr, _ := cmd.Flags().GetString("userspace-proxy")
+				socks := exit.NewSocks5Server(proxyAddr, wgTunnel.DialContext)
+				go func() {
+					if err := socks.ListenAndServe(ctx); err != nil && ctx.Err() == nil {
+						fmt.Fprintf(os.Stderr, "%s\n", style.Warning.Render(fmt.Sprintf("user-space mesh proxy stopped: %v", err)))
+					}
+				}()
+				fmt.Println(style.Success.Render(fmt.Sprintf("SOCKS5 proxy for mesh peers: %s", proxyAddr)))
+			}
 		}
 	}
 	// After DERP connects, re-trigger WG handshake for peers that were added
@@ -582,12 +631,17 @@ func runMeshConnect(cmd *cobra.Command) error {
 
 	socks5Port, _ := cmd.Flags().GetInt("socks5-port")
 	subnetEnabled, _ := cmd.Flags().GetBool("subnet")
+	if wgTunnel != nil && wgTunnel.Userspace() {
+		// Subnet routing rewrites host firewall rules, which user-space mode cannot do.
+		subnetEnabled = false
+	}
 	orgID := fmt.Sprintf("%d", sess.Organization.ID)
 
-	// List mesh nodes when SOCKS5 or subnet routing needs exit peers.
+	// List mesh nodes when SOCKS5 or subnet routing needs exit peers, or
+	// peer names need resolving over the WireGuard tunnel.
 	var meshNodes []api.MeshNode
 	var meshListErr error
-	if socks5Port > 0 || subnetEnabled {
+	if socks5Port > 0 || subnetEnabled || wgTunnel != nil {
 		meshNodes, meshListErr = app.API.ListMeshNodes(ctx)
 	}
 
@@ -674,6 +728,9 @@ func runMeshConnect(cmd *cobra.Command) error {
 		fmt.Fprintf(os.Stderr, "%s\n", style.Warning.Render(fmt.Sprintf("SOCKS5 proxy disabled: %v", meshListErr)))
 	}
 
+	// Names served by the local split DNS resolver.
+	hostToIP := map[string][]net.IP{}
+
 	// Subnet routing: iptables REDIRECT rules intercept TCP to cluster CIDRs
 	// and forward each connection transparently over DERP → agent.
 	if subnetEnabled {
@@ -702,15 +759,8 @@ func runMeshConnect(cmd *cobra.Command) error {
 			if runtime.GOOS != "darwin" {
 				cidrByCluster, _ = clusterCIDRMap(ctx, app, meshNodes)
 			}
-			hostToIP := buildMeshRouteHostIPs(meshBindings, cidrByCluster)
-			if len(hostToIP) > 0 {
-				stopDNS, err := startMeshSplitDNS(hostToIP)
-				if err != nil {
-					fmt.Fprintf(os.Stderr, "%s\n", style.Warning.Render(fmt.Sprintf("mesh split DNS disabled: %v", err)))
-				} else if stopDNS != nil {
-					defer stopDNS()
-					fmt.Println(style.Success.Render("mesh split DNS: *.mesh -> local resolver enabled"))
-				}
+			for host, ip := range buildMeshRouteHostIPs(meshBindings, cidrByCluster) {
+				hostToIP[host] = []net.IP{ip}
 			}
 
 			cidrMap := buildCIDRMap(meshNodes)
@@ -751,7 +801,7 @@ func runMeshConnect(cmd *cobra.Command) error {
 					// Translate back to the concrete mesh route target host:port before DERP dial.
 					return localProxy.DialViaDERP(dialCtx, peer, target)
 				}
-				bypassCIDRs := controlPlaneBypassCIDRs(ctx, relay, app.Config.APIBaseURL)
+				bypassCIDRs := mesh.ControlPlaneBypassCIDRs(ctx, relay, app.Config.APIBaseURL)
 				sr := subnet.NewWithBypass(cidrMap, dialFn, bypassCIDRs)
 				if err := sr.Start(ctx); err != nil {
 					fmt.Fprintf(os.Stderr, "%s\n", style.Warning.Render(fmt.Sprintf("subnet router disabled (need root): %v", err)))
@@ -768,6 +818,23 @@ func runMeshConnect(cmd *cobra.Command) error {
 		}
 	}
 
+	// Peer names (<device>.mesh.prysm) resolve to overlay IPs, which only
+	// route while the WireGuard interface is up.
+	if wgTunnel != nil && !wgTunnel.Userspace() && meshListErr == nil {
+		for host, ips := range meshdns.HostIPs(meshNodes, deviceID) {
+			hostToIP[host] = ips
+		}
+	}
+	if len(hostToIP) > 0 {
+		dnsServer, err := meshdns.Start(hostToIP)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s\n", style.Warning.Render(fmt.Sprintf("mesh split DNS disabled: %v", err)))
+		} else {
+			defer dnsServer.Close()
+			fmt.Println(style.Success.Render(fmt.Sprintf("mesh split DNS: *.mesh and *.%s -> local resolver enabled", meshdns.Suffix)))
+		}
+	}
+
 	fmt.Println(style.Success.Render(fmt.Sprintf("🔌 Joining DERP mesh as %s", deviceID)))
 	fmt.Println(style.MutedStyle.Render(fmt.Sprintf("Relay: %s", relay)))
 
@@ -819,49 +886,35 @@ func runMeshConnect(cmd *cobra.Command) error {
 }
 
 func newMeshPeersCommand() *cobra.Command {
-	return &cobra.Command{
+	var (
+		watch    bool
+		interval time.Duration
+	)
+
+	cmd := &cobra.Command{
 		Use:   "peers",
 		Short: "List mesh peers visible to your organization",
+		Long: `List mesh peers visible to your organization.
+
+With --watch the table stays on screen and refreshes when the DERP relay
+reports peers joining, leaving or updating stats, and at least every
+--interval. Changes since the previous refresh are listed under the table.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			app := MustApp()
+			if watch {
+				if interval <= 0 {
+					return fmt.Errorf("--interval must be positive")
+				}
+				return watchMeshPeers(cmd.Context(), app, interval)
+			}
+
 			ctx, cancel := context.WithTimeout(cmd.Context(), 20*time.Second)
 			defer cancel()
 
-			nodes, err := app.API.ListMeshNodes(ctx)
+			rows, err := loadMeshPeerRows(ctx, app)
 			if err != nil {
 				return err
 			}
-
-			// Include clusters as mesh peers (cluster agents may or may not be in mesh nodes)
-			clusters, _ := app.API.ListClusters(ctx)
-			rows := meshNodesToRows(nodes)
-			clusterIDsInMesh := make(map[int64]bool)
-			for _, n := range nodes {
-				if n.ClusterID != nil {
-					clusterIDsInMesh[*n.ClusterID] = true
-				}
-			}
-			for _, c := range clusters {
-				if clusterIDsInMesh[c.ID] {
-					continue
-				}
-				lastPing := "-"
-				if c.LastPing != nil {
-					lastPing = c.LastPing.Format(time.RFC3339)
-				}
-				exit := "-"
-				if c.IsExitRouter {
-					exit = "yes"
-				}
-				rows = append(rows, meshPeerRow{
-					DeviceID: c.Name,
-					PeerType: "cluster",
-					Status:   c.Status,
-					LastPing: lastPing,
-					Exit:     exit,
-				})
-			}
-
 			if len(rows) == 0 {
 				fmt.Println(style.Warning.Render("No mesh peers registered for your organization."))
 				return nil
@@ -871,54 +924,49 @@ func newMeshPeersCommand() *cobra.Command {
 			return nil
 		},
 	}
+	cmd.Flags().BoolVarP(&watch, "watch", "w", false, "keep the table on screen and update it live")
+	cmd.Flags().DurationVar(&interval, "interval", 10*time.Second, "maximum time between refreshes in watch mode")
+	return cmd
 }
 
-// controlPlaneBypassCIDRs resolves DERP/API hosts and returns /32 CIDRs that
-// must never be redirected through exit routing.
-func controlPlaneBypassCIDRs(ctx context.Context, relayURL, apiBaseURL string) []string {
-	hosts := []string{}
-	if h := hostFromURL(relayURL); h != "" {
-		hosts = append(hosts, h)
-	}
-	if h := hostFromURL(apiBaseURL); h != "" {
-		hosts = append(hosts, h)
+// loadMeshPeerRows returns the organization's mesh nodes plus clusters whose
+// agents have not joined the mesh.
+func loadMeshPeerRows(ctx context.Context, app *App) ([]meshPeerRow, error) {
+	nodes, err := app.API.ListMeshNodes(ctx)
+	if err != nil {
+		return nil, err
 	}
-	uniqHosts := make(map[string]struct{}, len(hosts))
-	for _, h := range hosts {
-		uniqHosts[h] = struct{}{}
+
+	// Include clusters as mesh peers (cluster agents may or may not be in mesh nodes)
+	clusters, _ := app.API.ListClusters(ctx)
+	rows := meshNodesToRows(nodes)
+	clusterIDsInMesh := make(map[int64]bool)
+	for _, n := range nodes {
+		if n.ClusterID != nil {
+			clusterIDsInMesh[*n.ClusterID] = true
+		}
 	}
-	out := []string{}
-	seen := map[string]struct{}{}
-	for h := range uniqHosts {
-		ips, err := net.DefaultResolver.LookupIP(ctx, "ip", h)
-		if err != nil {
+	for _, c := range clusters {
+		if clusterIDsInMesh[c.ID] {
 			continue
 		}
-		for _, ip := range ips {
-			v4 := ip.To4()
-			if v4 == nil {
-				continue
-			}
-			cidr := v4.String() + "/32"
-			if _, ok := seen[cidr]; ok {
-				continue
-			}
-			seen[cidr] = struct{}{}
-			out = append(out, cidr)
+		lastPing := "-"
+		if c.LastPing != nil {
+			lastPing = c.LastPing.Format(time.RFC3339)
 		}
+		exit := "-"
+		if c.IsExitRouter {
+			exit = "yes"
+		}
+		rows = append(rows, meshPeerRow{
+			DeviceID: c.Name,
+			PeerType: "cluster",
+			Status:   c.Status,
+			LastPing: lastPing,
+			Exit:     exit,
+		})
 	}
-	return out
-}
-
-func hostFromURL(raw string) string {
-	if strings.TrimSpace(raw) == "" {
-		return ""
-	}
-	u, err := neturl.Parse(raw)
-	if err != nil {
-		return ""
-	}
-	return u.Hostname()
+	return rows, nil
 }
 
 func clusterCIDRMap(ctx context.Context, app *App, meshNodes []api.MeshNode) (map[int64]string, error) {
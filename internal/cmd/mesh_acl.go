@@ -0,0 +1,367 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/prysmsh/cli/internal/api"
+	"github.com/prysmsh/cli/internal/derp"
+	"github.com/prysmsh/cli/internal/mesh"
+	"github.com/prysmsh/cli/internal/meshdns"
+	"github.com/prysmsh/cli/internal/style"
+	"github.com/prysmsh/cli/internal/ui"
+)
+
+// meshACLGrant is one policy rule that applies to traffic from this device
+// to a peer or tunnel target.
+type meshACLGrant struct {
+	Target   string `json:"target"`
+	Kind     string `json:"kind"` // peer or tunnel
+	Ports    string `json:"ports"`
+	Protocol string `json:"protocol"`
+	Action   string `json:"action"`
+	Rule     string `json:"rule"`
+}
+
+func newMeshACLCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "acl",
+		Short: "Inspect the organization's mesh access policy",
+		Long: `Inspect the organization's mesh access policy. Rules are evaluated in order;
+the first rule matching source, destination, protocol and port decides, and
+traffic no rule matches gets the policy's default action.`,
+	}
+	cmd.AddCommand(newMeshACLShowCommand(), newMeshACLTestCommand())
+	return cmd
+}
+
+func newMeshACLShowCommand() *cobra.Command {
+	var outputFormat string
+
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show what this device can reach and which rule allows it",
+		Long: `List, for every mesh peer and tunnel, the policy rules that apply to traffic
+from this device, in evaluation order. For a given port only the first listed
+rule covering it takes effect.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app := MustApp()
+			ctx, cancel := context.WithTimeout(cmd.Context(), 20*time.Second)
+			defer cancel()
+
+			policy, err := app.API.GetMeshACL(ctx)
+			if err != nil {
+				return fmt.Errorf("get mesh policy: %w", err)
+			}
+			nodes, err := app.API.ListMeshNodes(ctx)
+			if err != nil {
+				return err
+			}
+			clusters, _ := app.API.ListClusters(ctx)
+			tunnels, _ := app.API.ListTunnels(ctx, "")
+			deviceID, err := derp.EnsureDeviceID(app.Config.HomeDir)
+			if err != nil {
+				return fmt.Errorf("ensure device id: %w", err)
+			}
+
+			self := meshACLSelf(nodes, clusters, deviceID)
+			grants := meshACLGrants(policy, self, nodes, clusters, tunnels)
+
+			if wantsJSONOutput(outputFormat) {
+				return writeJSON(map[string]interface{}{
+					"device_id":      deviceID,
+					"default_action": meshACLDefault(policy),
+					"rules":          len(policy.Rules),
+					"grants":         grants,
+				})
+			}
+
+			fmt.Printf("Policy: %d rule(s), default %s\n", len(policy.Rules), meshACLDefault(policy))
+			fmt.Printf("Device: %s\n\n", deviceID)
+			if len(grants) == 0 {
+				fmt.Println(style.MutedStyle.Render("No peers or tunnels to evaluate."))
+				return nil
+			}
+			data := make([][]string, len(grants))
+			for i, g := range grants {
+				action := style.Success.Render(g.Action)
+				if g.Action != "accept" {
+					action = style.Error.Render(g.Action)
+				}
+				data[i] = []string{g.Target, g.Kind, g.Ports, g.Protocol, action, g.Rule}
+			}
+			ui.PrintTable([]string{"TARGET", "KIND", "PORTS", "PROTO", "ACTION", "RULE"}, data)
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "", "output format (table, json)")
+	return cmd
+}
+
+func newMeshACLTestCommand() *cobra.Command {
+	var (
+		src          string
+		dst          string
+		proto        string
+		outputFormat string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "test --dst <peer>[:port]",
+		Short: "Check whether the policy allows a connection (dry run)",
+		Long: `Ask the control plane to evaluate the mesh access policy for a single
+connection without sending any traffic. Peers can be given as device ID,
+<device>.` + meshdns.Suffix + ` name, cluster name or overlay IP; "me" is this device.
+Exits non-zero when the connection is denied.
+
+Control planes that cannot evaluate policies get a local approximation of the
+policy instead, marked as such in the output.`,
+		Example: `  prysm mesh acl test --dst prod-cluster:443
+  prysm mesh acl test --src laptop-2 --dst db.mesh.prysm:5432 --proto tcp`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dstRef, port, err := splitMeshACLTarget(dst)
+			if err != nil {
+				return err
+			}
+			proto = strings.ToLower(strings.TrimSpace(proto))
+			if proto != "" && proto != "tcp" && proto != "udp" {
+				return fmt.Errorf("protocol must be tcp or udp")
+			}
+
+			app := MustApp()
+			ctx, cancel := context.WithTimeout(cmd.Context(), 20*time.Second)
+			defer cancel()
+
+			nodes, err := app.API.ListMeshNodes(ctx)
+			if err != nil {
+				return err
+			}
+			clusters, _ := app.API.ListClusters(ctx)
+
+			resolve := func(ref string) (mesh.ACLEndpoint, error) {
+				if ref == "" || ref == "me" {
+					deviceID, err := derp.EnsureDeviceID(app.Config.HomeDir)
+					if err != nil {
+						return mesh.ACLEndpoint{}, fmt.Errorf("ensure device id: %w", err)
+					}
+					return meshACLSelf(nodes, clusters, deviceID), nil
+				}
+				t, err := resolveMeshPingTarget(nodes, clusters, ref)
+				if err != nil {
+					return mesh.ACLEndpoint{}, err
+				}
+				for _, n := range nodes {
+					if n.DeviceID == t.DeviceID {
+						return meshACLEndpoint(n, clusters), nil
+					}
+				}
+				return mesh.ACLEndpoint{DeviceID: t.DeviceID}, nil
+			}
+			srcEP, err := resolve(src)
+			if err != nil {
+				return err
+			}
+			dstEP, err := resolve(dstRef)
+			if err != nil {
+				return err
+			}
+
+			evaluatedBy := "control-plane"
+			verdict, err := app.API.EvaluateMeshACL(ctx, api.MeshACLCheck{
+				SrcDeviceID: srcEP.DeviceID,
+				DstDeviceID: dstEP.DeviceID,
+				Port:        port,
+				Protocol:    proto,
+			})
+			if errors.Is(err, api.ErrEndpointUnavailable) {
+				policy, policyErr := app.API.GetMeshACL(ctx)
+				if policyErr != nil {
+					return fmt.Errorf("get mesh policy: %w", policyErr)
+				}
+				d := mesh.EvaluateACL(policy, srcEP, dstEP, proto, port)
+				verdict, err = &api.MeshACLVerdict{Allowed: d.Allowed, Rule: d.Reason}, nil
+				evaluatedBy = "local"
+			}
+			if err != nil {
+				return fmt.Errorf("evaluate mesh policy: %w", err)
+			}
+
+			action := "deny"
+			if verdict.Allowed {
+				action = "accept"
+			}
+			target := dstEP.DeviceID
+			if port > 0 {
+				target += ":" + strconv.Itoa(port)
+			}
+
+			if wantsJSONOutput(outputFormat) {
+				if err := writeJSON(map[string]interface{}{
+					"src":          srcEP.DeviceID,
+					"dst":          dstEP.DeviceID,
+					"port":         port,
+					"protocol":     proto,
+					"action":       action,
+					"rule":         verdict.Rule,
+					"evaluated_by": evaluatedBy,
+				}); err != nil {
+					return err
+				}
+			} else {
+				if evaluatedBy == "local" {
+					fmt.Fprintln(os.Stderr, style.Warning.Render("The control plane cannot evaluate policies; this is a local approximation and may differ from enforcement."))
+				}
+				if verdict.Allowed {
+					fmt.Println(style.Success.Render(fmt.Sprintf("✓ %s → %s allowed by %s", srcEP.DeviceID, target, verdict.Rule)))
+				} else {
+					fmt.Println(style.Error.Render(fmt.Sprintf("✗ %s → %s denied by %s", srcEP.DeviceID, target, verdict.Rule)))
+				}
+			}
+			if !verdict.Allowed {
+				return withExitCode(1, fmt.Errorf("connection denied by %s", verdict.Rule))
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&src, "src", "me", "source peer, or \"me\" for this device")
+	cmd.Flags().StringVar(&dst, "dst", "", "destination peer with optional port, e.g. peer:443")
+	cmd.Flags().StringVar(&proto, "proto", "", "protocol to check (tcp, udp); any when omitted")
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "", "output format (table, json)")
+	_ = cmd.MarkFlagRequired("dst")
+	return cmd
+}
+
+// splitMeshACLTarget splits "peer:443" into the peer and port; the port is
+// optional.
+func splitMeshACLTarget(s string) (string, int, error) {
+	s = strings.TrimSpace(s)
+	i := strings.LastIndex(s, ":")
+	if i < 0 {
+		return s, 0, nil
+	}
+	port, err := strconv.Atoi(s[i+1:])
+	if err != nil || port < 1 || port > 65535 {
+		return "", 0, fmt.Errorf("invalid port in %q", s)
+	}
+	return s[:i], port, nil
+}
+
+func meshACLEndpoint(n api.MeshNode, clusters []api.Cluster) mesh.ACLEndpoint {
+	e := mesh.ACLEndpoint{DeviceID: n.DeviceID, PeerType: n.PeerType}
+	addr, _, _ := strings.Cut(n.WGAddress, "/")
+	e.IP = net.ParseIP(addr)
+	if n.ClusterID != nil {
+		for _, c := range clusters {
+			if c.ID == *n.ClusterID {
+				e.Cluster = c.Name
+				break
+			}
+		}
+	}
+	return e
+}
+
+// meshACLSelf returns this device's endpoint; a device that has not joined
+// the mesh yet is matched as a client by device ID only.
+func meshACLSelf(nodes []api.MeshNode, clusters []api.Cluster, deviceID string) mesh.ACLEndpoint {
+	for _, n := range nodes {
+		if n.DeviceID == deviceID {
+			return meshACLEndpoint(n, clusters)
+		}
+	}
+	return mesh.ACLEndpoint{DeviceID: deviceID, PeerType: "client"}
+}
+
+func meshACLDefault(policy *api.MeshACL) string {
+	if policy.DefaultAction == "" {
+		return "deny"
+	}
+	return strings.ToLower(policy.DefaultAction)
+}
+
+// meshACLGrants lists, per peer and tunnel, the rules that apply to traffic
+// from self in evaluation order, followed by the default action.
+func meshACLGrants(policy *api.MeshACL, self mesh.ACLEndpoint, nodes []api.MeshNode, clusters []api.Cluster, tunnels []api.Tunnel) []meshACLGrant {
+	grants := []meshACLGrant{}
+	byDevice := map[string]mesh.ACLEndpoint{}
+	for _, n := range nodes {
+		byDevice[n.DeviceID] = meshACLEndpoint(n, clusters)
+	}
+
+	for _, n := range nodes {
+		if n.DeviceID == self.DeviceID {
+			continue
+		}
+		dst := byDevice[n.DeviceID]
+		matched := false
+		for i, r := range policy.Rules {
+			if !mesh.ACLRuleMatches(r, self, dst) {
+				continue
+			}
+			grants = append(grants, meshACLGrant{
+				Target:   n.DeviceID,
+				Kind:     "peer",
+				Ports:    meshACLPorts(r.Ports),
+				Protocol: meshACLProto(r.Protocol),
+				Action:   strings.ToLower(r.Action),
+				Rule:     mesh.ACLRuleName(r, i),
+			})
+			if len(r.Ports) == 0 && r.Protocol == "" {
+				matched = true // later rules can never apply
+				break
+			}
+		}
+		if !matched {
+			grants = append(grants, meshACLGrant{Target: n.DeviceID, Kind: "peer", Ports: "*", Protocol: "any", Action: meshACLDefault(policy), Rule: "default"})
+		}
+	}
+
+	for _, t := range tunnels {
+		dst, ok := byDevice[t.TargetDeviceID]
+		if !ok {
+			dst = mesh.ACLEndpoint{DeviceID: t.TargetDeviceID}
+		}
+		d := mesh.EvaluateACL(policy, self, dst, strings.ToLower(t.Protocol), t.Port)
+		action := "deny"
+		if d.Allowed {
+			action = "accept"
+		}
+		name := t.Name
+		if name == "" {
+			name = fmt.Sprintf("tunnel %d", t.ID)
+		}
+		grants = append(grants, meshACLGrant{
+			Target:   fmt.Sprintf("%s (%s:%d)", name, t.TargetDeviceID, t.Port),
+			Kind:     "tunnel",
+			Ports:    strconv.Itoa(t.Port),
+			Protocol: meshACLProto(t.Protocol),
+			Action:   action,
+			Rule:     d.Reason,
+		})
+	}
+	return grants
+}
+
+func meshACLPorts(ports []string) string {
+	if len(ports) == 0 {
+		return "*"
+	}
+	return strings.Join(ports, ",")
+}
+
+func meshACLProto(proto string) string {
+	if proto == "" {
+		return "any"
+	}
+	return strings.ToLower(proto)
+}
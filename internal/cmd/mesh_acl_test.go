@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/prysmsh/cli/internal/api"
+)
+
+func TestSplitMeshACLTarget(t *testing.T) {
+	tests := []struct {
+		in   string
+		peer string
+		port int
+		err  bool
+	}{
+		{in: "prod:443", peer: "prod", port: 443},
+		{in: "laptop-1.mesh.prysm", peer: "laptop-1.mesh.prysm"},
+		{in: "100.96.0.7:22", peer: "100.96.0.7", port: 22},
+		{in: "prod:https", err: true},
+		{in: "prod:70000", err: true},
+	}
+	for _, tt := range tests {
+		peer, port, err := splitMeshACLTarget(tt.in)
+		if (err != nil) != tt.err || peer != tt.peer || port != tt.port {
+			t.Errorf("splitMeshACLTarget(%q) = %q, %d, %v", tt.in, peer, port, err)
+		}
+	}
+}
+
+func TestMeshACLGrants(t *testing.T) {
+	prodID := int64(1)
+	clusters := []api.Cluster{{ID: prodID, Name: "prod"}}
+	nodes := []api.MeshNode{
+		{DeviceID: "me", PeerType: "client", WGAddress: "100.96.0.5/32"},
+		{DeviceID: "agent-prod", PeerType: "cluster", ClusterID: &prodID, WGAddress: "100.96.1.2/32"},
+		{DeviceID: "laptop-2", PeerType: "client", WGAddress: "100.96.0.6/32"},
+	}
+	policy := &api.MeshACL{Rules: []api.MeshACLRule{
+		{Name: "web", Action: "accept", Sources: []string{"type:client"}, Destinations: []string{"cluster:prod"}, Ports: []string{"443"}, Protocol: "tcp"},
+		{Name: "no-prod", Action: "deny", Sources: []string{"*"}, Destinations: []string{"cluster:prod"}},
+	}}
+	tunnels := []api.Tunnel{{ID: 3, Name: "api", TargetDeviceID: "agent-prod", Port: 8080, Protocol: "TCP"}}
+
+	self := meshACLSelf(nodes, clusters, "me")
+	got := meshACLGrants(policy, self, nodes, clusters, tunnels)
+
+	want := []meshACLGrant{
+		{Target: "agent-prod", Kind: "peer", Ports: "443", Protocol: "tcp", Action: "accept", Rule: "web"},
+		{Target: "agent-prod", Kind: "peer", Ports: "*", Protocol: "any", Action: "deny", Rule: "no-prod"},
+		{Target: "laptop-2", Kind: "peer", Ports: "*", Protocol: "any", Action: "deny", Rule: "default"},
+		{Target: "api (agent-prod:8080)", Kind: "tunnel", Ports: "8080", Protocol: "tcp", Action: "deny", Rule: "no-prod"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d grants, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("grant %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
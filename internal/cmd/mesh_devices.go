@@ -0,0 +1,282 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/prysmsh/cli/internal/api"
+	"github.com/prysmsh/cli/internal/derp"
+	"github.com/prysmsh/cli/internal/meshd"
+	"github.com/prysmsh/cli/internal/meshdns"
+	"github.com/prysmsh/cli/internal/style"
+	"github.com/prysmsh/cli/internal/ui"
+	"github.com/prysmsh/cli/internal/util"
+	"github.com/prysmsh/cli/internal/wg"
+)
+
+func newMeshDevicesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "devices",
+		Short: "Manage devices enrolled in the mesh",
+		Long: `Manage the organization's device registry. Devices can be referred to by
+device ID, name or <device>.` + meshdns.Suffix + ` name.`,
+	}
+	cmd.AddCommand(
+		newMeshDevicesListCommand(),
+		newMeshDevicesRenameCommand(),
+		newMeshDevicesRemoveCommand(),
+		newMeshDevicesExpireCommand(),
+	)
+	return cmd
+}
+
+func newMeshDevicesListCommand() *cobra.Command {
+	var outputFormat string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List enrolled devices",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app := MustApp()
+			ctx, cancel := context.WithTimeout(cmd.Context(), 20*time.Second)
+			defer cancel()
+
+			devices, err := app.API.ListMeshDevices(ctx)
+			if err != nil {
+				return fmt.Errorf("list devices: %w", err)
+			}
+			if wantsJSONOutput(outputFormat) {
+				return writeJSON(devices)
+			}
+			if len(devices) == 0 {
+				fmt.Println(style.MutedStyle.Render("No devices enrolled. Join with `prysm mesh connect`."))
+				return nil
+			}
+
+			self, _ := derp.ReadDeviceID(app.Config.HomeDir)
+			data := make([][]string, len(devices))
+			for i, d := range devices {
+				id := d.DeviceID
+				if id == self {
+					id += " (this device)"
+				}
+				data[i] = []string{id, dashIfEmpty(d.Name), dashIfEmpty(d.Owner), dashIfEmpty(strings.Join(d.Tags, ",")), dashIfEmpty(d.Address), meshDeviceStatus(d), meshDeviceTime(d.LastSeen), meshDeviceTime(d.KeyExpiresAt)}
+			}
+			ui.PrintTable([]string{"DEVICE", "NAME", "OWNER", "TAGS", "ADDRESS", "STATUS", "LAST SEEN", "KEY EXPIRES"}, data)
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "", "output format (table, json)")
+	return cmd
+}
+
+func newMeshDevicesRenameCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rename <device> <name>",
+		Short: "Set a device's display name",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := strings.TrimSpace(args[1])
+			if name == "" {
+				return fmt.Errorf("name must not be empty")
+			}
+			app := MustApp()
+			ctx, cancel := context.WithTimeout(cmd.Context(), 20*time.Second)
+			defer cancel()
+
+			dev, err := lookupMeshDevice(ctx, app, args[0])
+			if err != nil {
+				return err
+			}
+			if _, err := app.API.RenameMeshDevice(ctx, dev.DeviceID, name); err != nil {
+				return fmt.Errorf("rename device: %w", err)
+			}
+			fmt.Println(style.Success.Render(fmt.Sprintf("✓ %s renamed to %q", dev.DeviceID, name)))
+			return nil
+		},
+	}
+}
+
+func newMeshDevicesRemoveCommand() *cobra.Command {
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "remove <device>",
+		Short: "Remove a device from the mesh and revoke its key",
+		Long: `Remove a device from the registry and revoke its WireGuard key. Peers drop it
+at their next sync, and it has to enroll again to rejoin.
+
+Removing this device also disconnects it (stopping the mesh daemon's session or
+a background connect) and deletes its local WireGuard keys, so the next ` + "`prysm mesh connect`" + ` enrolls it with new ones.`,
+		Example: `  prysm mesh devices remove old-laptop-cli
+  prysm mesh devices remove old-laptop.mesh.prysm --yes`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app := MustApp()
+			ctx, cancel := context.WithTimeout(cmd.Context(), 20*time.Second)
+			defer cancel()
+
+			dev, err := lookupMeshDevice(ctx, app, args[0])
+			if err != nil {
+				return err
+			}
+			self, _ := derp.ReadDeviceID(app.Config.HomeDir)
+			current := self != "" && dev.DeviceID == self
+
+			if !yes {
+				prompt := fmt.Sprintf("Remove %s from the mesh?", dev.DeviceID)
+				if current {
+					prompt = fmt.Sprintf("Remove this device (%s) from the mesh and delete its keys?", dev.DeviceID)
+				}
+				ok, err := util.PromptConfirm(prompt, false)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					fmt.Println(style.MutedStyle.Render("Removal cancelled."))
+					return nil
+				}
+			}
+
+			if err := app.API.RemoveMeshDevice(ctx, dev.DeviceID); err != nil {
+				return fmt.Errorf("remove device: %w", err)
+			}
+			fmt.Println(style.Success.Render(fmt.Sprintf("✓ %s removed from the mesh", dev.DeviceID)))
+			if current {
+				return forgetLocalMeshDevice(app.Config.HomeDir)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "do not ask for confirmation")
+	return cmd
+}
+
+func newMeshDevicesExpireCommand() *cobra.Command {
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "expire <device>",
+		Short: "Expire a device's key so it must re-authenticate",
+		Long: `Expire a device's key immediately. The device stays in the registry but is
+cut off from the mesh until its user logs in again and reconnects.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app := MustApp()
+			ctx, cancel := context.WithTimeout(cmd.Context(), 20*time.Second)
+			defer cancel()
+
+			dev, err := lookupMeshDevice(ctx, app, args[0])
+			if err != nil {
+				return err
+			}
+			if !yes {
+				ok, err := util.PromptConfirm(fmt.Sprintf("Expire the key of %s now?", dev.DeviceID), false)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					fmt.Println(style.MutedStyle.Render("Expiry cancelled."))
+					return nil
+				}
+			}
+			if _, err := app.API.ExpireMeshDevice(ctx, dev.DeviceID); err != nil {
+				return fmt.Errorf("expire device: %w", err)
+			}
+			fmt.Println(style.Success.Render(fmt.Sprintf("✓ Key of %s expired; it must re-authenticate to rejoin", dev.DeviceID)))
+			return nil
+		},
+	}
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "do not ask for confirmation")
+	return cmd
+}
+
+func lookupMeshDevice(ctx context.Context, app *App, ref string) (api.MeshDevice, error) {
+	devices, err := app.API.ListMeshDevices(ctx)
+	if err != nil {
+		return api.MeshDevice{}, fmt.Errorf("list devices: %w", err)
+	}
+	return findMeshDevice(devices, ref)
+}
+
+// findMeshDevice finds a device by ID, overlay address, display name or
+// <device>.mesh.prysm name. A name shared by several devices is ambiguous.
+func findMeshDevice(devices []api.MeshDevice, ref string) (api.MeshDevice, error) {
+	ref = strings.TrimSuffix(strings.TrimSpace(ref), ".")
+	for _, d := range devices {
+		addr, _, _ := strings.Cut(d.Address, "/")
+		if d.DeviceID == ref || (addr != "" && addr == ref) {
+			return d, nil
+		}
+	}
+
+	label := strings.TrimSuffix(strings.ToLower(ref), "."+meshdns.Suffix)
+	var matches []api.MeshDevice
+	for _, d := range devices {
+		if (d.Name != "" && strings.EqualFold(d.Name, ref)) || (label != "" && meshdns.Label(d.DeviceID) == label) {
+			matches = append(matches, d)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return api.MeshDevice{}, fmt.Errorf("no device named %q — see `prysm mesh devices list`", ref)
+	case 1:
+		return matches[0], nil
+	}
+	ids := make([]string, len(matches))
+	for i, d := range matches {
+		ids[i] = d.DeviceID
+	}
+	return api.MeshDevice{}, fmt.Errorf("%q matches several devices (%s); use the device ID", ref, strings.Join(ids, ", "))
+}
+
+// forgetLocalMeshDevice disconnects this device, through the daemon or a
+// background `mesh connect`, and deletes its keys after it was removed from
+// the registry.
+func forgetLocalMeshDevice(homeDir string) error {
+	if meshd.IsRunning() {
+		if resp, err := meshd.Disconnect(); err != nil {
+			fmt.Println(style.Warning.Render(fmt.Sprintf("Could not disconnect the mesh daemon: %v", err)))
+		} else if resp.Error != "" {
+			fmt.Println(style.Warning.Render(fmt.Sprintf("meshd: %s", resp.Error)))
+		}
+	}
+	if pid, running := stopMeshBackground(getPrysmHome()); running {
+		fmt.Println(style.MutedStyle.Render(fmt.Sprintf("Stopped the background mesh connection (PID %d).", pid)))
+	}
+	if err := wg.RemoveKeyMaterial(homeDir); err != nil {
+		return fmt.Errorf("remove local keys: %w", err)
+	}
+	fmt.Println(style.MutedStyle.Render("Local WireGuard keys deleted; `prysm mesh connect` enrolls this device again."))
+	return nil
+}
+
+func meshDeviceStatus(d api.MeshDevice) string {
+	status := dashIfEmpty(d.Status)
+	if d.KeyExpired() {
+		status = "expired"
+	}
+	if d.Ephemeral {
+		status += " (ephemeral)"
+	}
+	return status
+}
+
+func meshDeviceTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+func dashIfEmpty(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prysmsh/cli/internal/api"
+)
+
+func TestFindMeshDevice(t *testing.T) {
+	devices := []api.MeshDevice{
+		{DeviceID: "laptop-1-cli", Name: "Alice laptop", Address: "100.96.0.7/32"},
+		{DeviceID: "build-box-cli", Name: "builder"},
+		{DeviceID: "build-box-2-cli", Name: "builder"},
+	}
+	tests := []struct {
+		ref  string
+		want string
+	}{
+		{"laptop-1-cli", "laptop-1-cli"},
+		{"100.96.0.7", "laptop-1-cli"},
+		{"alice laptop", "laptop-1-cli"},
+		{"laptop-1-cli.mesh.prysm", "laptop-1-cli"},
+		{"laptop-1-cli.mesh.prysm.", "laptop-1-cli"},
+	}
+	for _, tt := range tests {
+		got, err := findMeshDevice(devices, tt.ref)
+		if err != nil || got.DeviceID != tt.want {
+			t.Errorf("findMeshDevice(%q) = %q, %v; want %q", tt.ref, got.DeviceID, err, tt.want)
+		}
+	}
+
+	if _, err := findMeshDevice(devices, "builder"); err == nil || !strings.Contains(err.Error(), "several devices") {
+		t.Errorf("ambiguous name: got %v", err)
+	}
+	if _, err := findMeshDevice(devices, "phone"); err == nil {
+		t.Error("unknown device: expected error")
+	}
+}
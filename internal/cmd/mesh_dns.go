@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/prysmsh/cli/internal/derp"
+	"github.com/prysmsh/cli/internal/meshdns"
+	"github.com/prysmsh/cli/internal/style"
+	"github.com/prysmsh/cli/internal/ui"
+)
+
+func newMeshDNSCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dns",
+		Short: "Inspect mesh peer name resolution",
+		Long: fmt.Sprintf(`While the mesh is connected with WireGuard, through the daemon or
+`+"`prysm mesh connect`"+`, a local resolver on %s answers <device>.%s with
+the peer's overlay IPs (A and AAAA records), and the system resolver forwards
+that domain to it. `+"`prysm mesh connect`"+` also answers *.mesh for routes.`, meshdns.Address, meshdns.Suffix),
+	}
+	cmd.AddCommand(newMeshDNSStatusCommand())
+	return cmd
+}
+
+func newMeshDNSStatusCommand() *cobra.Command {
+	var outputFormat string
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show mesh DNS names and whether the local resolver answers them",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app := MustApp()
+			ctx, cancel := context.WithTimeout(cmd.Context(), 20*time.Second)
+			defer cancel()
+
+			nodes, err := app.API.ListMeshNodes(ctx)
+			if err != nil {
+				return err
+			}
+			deviceID, _ := derp.EnsureDeviceID(app.Config.HomeDir)
+			records := meshdns.Records(nodes, deviceID)
+			active := len(records) > 0 && probeMeshDNS(ctx, records[0])
+
+			if wantsJSONOutput(outputFormat) {
+				return writeJSON(map[string]interface{}{
+					"suffix":   meshdns.Suffix,
+					"resolver": meshdns.Address,
+					"active":   active,
+					"records":  records,
+				})
+			}
+
+			if active {
+				fmt.Println(style.Success.Render(fmt.Sprintf("Resolver: active on %s", meshdns.Address)))
+			} else {
+				fmt.Println(style.Warning.Render(fmt.Sprintf("Resolver: not answering on %s — run `prysm mesh connect` with WireGuard enabled", meshdns.Address)))
+			}
+			if len(records) == 0 {
+				fmt.Println(style.MutedStyle.Render("No mesh peers with overlay addresses."))
+				return nil
+			}
+			data := make([][]string, len(records))
+			for i, r := range records {
+				name := r.Name
+				if r.Self {
+					name += " (this device)"
+				}
+				data[i] = []string{name, strings.Join(r.IPs, ", "), r.Status}
+			}
+			ui.PrintTable([]string{"NAME", "IP", "STATUS"}, data)
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "", "output format (table, json)")
+	return cmd
+}
+
+// probeMeshDNS reports whether the local resolver answers r with one of its
+// IPs.
+func probeMeshDNS(ctx context.Context, r meshdns.Record) bool {
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "udp", meshdns.Address)
+		},
+	}
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	ips, err := resolver.LookupIP(ctx, "ip", r.Name)
+	if err != nil {
+		return false
+	}
+	for _, ip := range ips {
+		for _, want := range r.IPs {
+			if ip.Equal(net.ParseIP(want)) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/prysmsh/cli/internal/api"
+	"github.com/prysmsh/cli/internal/derp"
+	"github.com/prysmsh/cli/internal/style"
+	"github.com/prysmsh/cli/internal/wg"
+)
+
+var meshTagPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+func newMeshEnrollCommand() *cobra.Command {
+	var (
+		authKey       string
+		tags          []string
+		ephemeral     bool
+		presharedKeys bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "enroll",
+		Short: "Register this device in the mesh, optionally with a pre-authorized key",
+		Long: `Register this device and its WireGuard key in the organization's device
+registry, then join with ` + "`prysm mesh connect`" + `.
+
+With --auth-key (or PRYSM_AUTH_KEY) the device authenticates with a
+pre-authorized key from the dashboard instead of an interactive login, so CI
+jobs and other unattended machines can join the mesh. Tags label the device in
+the registry (see ` + "`prysm mesh devices list`" + `). Ephemeral devices are expired by
+the backend as soon as they disconnect.
+
+With --preshared-keys every WireGuard peer of this device also gets a random
+preshared key, issued to both sides by the control plane and used in place of
+the ML-KEM derived one. Tunnels then stay protected by a symmetric secret even
+if Curve25519 is broken, and the keys are stored in the prysm home directory
+with the device's other keys.`,
+		Example: `  prysm mesh enroll --auth-key "$PRYSM_AUTH_KEY" --ephemeral --tag ci-runner
+  prysm mesh enroll --tag build --tag linux
+  prysm mesh enroll --preshared-keys`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if authKey == "" {
+				authKey = os.Getenv("PRYSM_AUTH_KEY")
+			}
+			tags, err := normalizeMeshTags(tags)
+			if err != nil {
+				return err
+			}
+
+			app := MustApp()
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			deviceID, err := derp.EnsureDeviceID(app.Config.HomeDir)
+			if err != nil {
+				return fmt.Errorf("ensure device id: %w", err)
+			}
+
+			if authKey != "" {
+				loginResp, err := app.API.ExchangeAuthKey(ctx, strings.TrimSpace(authKey), deviceID)
+				if err != nil {
+					return fmt.Errorf("exchange auth key: %w", err)
+				}
+				if err := app.Sessions.Save(sessionFromLogin(app, loginResp)); err != nil {
+					return err
+				}
+				fmt.Println(style.Success.Render(fmt.Sprintf("✓ Authenticated with auth key (organization %s)", loginResp.Organization.Name)))
+			} else if sess, err := app.Sessions.Load(); err != nil {
+				return err
+			} else if sess == nil {
+				return fmt.Errorf("no active session; run `prysm login` or pass --auth-key")
+			}
+
+			_, pubKey, err := wg.EnsureKeyPair(app.Config.HomeDir)
+			if err != nil {
+				return err
+			}
+			hostname, _ := os.Hostname()
+			dev, err := app.API.EnrollMeshDevice(ctx, api.MeshEnrollRequest{
+				DeviceID:      deviceID,
+				PublicKey:     pubKey,
+				Hostname:      hostname,
+				Tags:          tags,
+				Ephemeral:     ephemeral,
+				PresharedKeys: presharedKeys,
+			})
+			if err != nil {
+				return fmt.Errorf("enroll device: %w", err)
+			}
+
+			msg := fmt.Sprintf("✓ Enrolled %s", deviceID)
+			if len(dev.Tags) > 0 {
+				msg += fmt.Sprintf(" with tags %s", strings.Join(dev.Tags, ", "))
+			}
+			fmt.Println(style.Success.Render(msg))
+			if dev.Ephemeral {
+				fmt.Println(style.MutedStyle.Render("Ephemeral: the device is expired as soon as it disconnects."))
+			}
+			if presharedKeys {
+				n, err := enrollMeshPresharedKeys(ctx, app, deviceID)
+				if err != nil {
+					return err
+				}
+				fmt.Println(style.Success.Render(fmt.Sprintf("✓ Preshared keys set up with %d peer(s)", n)))
+			}
+			fmt.Println("Join the mesh with: prysm mesh connect")
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&authKey, "auth-key", "", "pre-authorized enrollment key (or set PRYSM_AUTH_KEY)")
+	cmd.Flags().StringSliceVar(&tags, "tag", nil, "tag to apply to the device (repeatable)")
+	cmd.Flags().BoolVar(&ephemeral, "ephemeral", false, "expire the device automatically when it disconnects")
+	cmd.Flags().BoolVar(&presharedKeys, "preshared-keys", false, "protect WireGuard sessions with per-peer preshared keys")
+	return cmd
+}
+
+// enrollMeshPresharedKeys generates preshared keys for this device's current
+// peers, registers them with the control plane and stores the keys in effect
+// (a pair that already had one keeps it). It returns the number of peers.
+func enrollMeshPresharedKeys(ctx context.Context, app *App, deviceID string) (int, error) {
+	cfg, err := wg.GetConfig(ctx, app.API, deviceID)
+	if err != nil {
+		return 0, fmt.Errorf("fetch peers: %w", err)
+	}
+	stored, err := wg.LoadPresharedKeys(app.Config.HomeDir)
+	if err != nil {
+		return 0, err
+	}
+
+	pubKeys := make(map[string]string, len(cfg.Peers))
+	keys := make([]wg.PresharedKeyAssignment, 0, len(cfg.Peers))
+	for _, p := range cfg.Peers {
+		pubKeys[p.Name] = p.PublicKey
+		psk := stored[p.PublicKey]
+		if psk == "" {
+			if psk, err = wg.GeneratePresharedKey(); err != nil {
+				return 0, err
+			}
+		}
+		keys = append(keys, wg.PresharedKeyAssignment{PeerDeviceID: p.Name, PresharedKey: psk})
+	}
+	if len(keys) == 0 {
+		return 0, nil
+	}
+
+	effective, err := wg.SubmitPresharedKeys(ctx, app.API, deviceID, keys)
+	if err != nil {
+		return 0, err
+	}
+	inEffect := make(map[string]string, len(effective))
+	for _, k := range effective {
+		if pub := pubKeys[k.PeerDeviceID]; pub != "" && k.PresharedKey != "" {
+			inEffect[pub] = k.PresharedKey
+		}
+	}
+	if err := wg.SavePresharedKeys(app.Config.HomeDir, inEffect); err != nil {
+		return 0, err
+	}
+	return len(effective), nil
+}
+
+// normalizeMeshTags lowercases and de-duplicates tags, rejecting ones that
+// are not DNS-label shaped.
+func normalizeMeshTags(tags []string) ([]string, error) {
+	out := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, t := range tags {
+		t = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(t, "tag:")))
+		if t == "" || seen[t] {
+			continue
+		}
+		if !meshTagPattern.MatchString(t) {
+			return nil, fmt.Errorf("invalid tag %q: use lowercase letters, digits and dashes", t)
+		}
+		seen[t] = true
+		out = append(out, t)
+	}
+	return out, nil
+}
@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeMeshTags(t *testing.T) {
+	got, err := normalizeMeshTags([]string{"CI-Runner", "tag:linux", " ", "ci-runner"})
+	if err != nil {
+		t.Fatalf("normalizeMeshTags: %v", err)
+	}
+	if want := []string{"ci-runner", "linux"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	for _, bad := range []string{"-lead", "trail-", "has space", "under_score"} {
+		if _, err := normalizeMeshTags([]string{bad}); err == nil {
+			t.Errorf("tag %q: expected error", bad)
+		}
+	}
+}
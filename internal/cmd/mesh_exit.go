@@ -4,13 +4,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"runtime"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/prysmsh/cli/internal/api"
+	"github.com/prysmsh/cli/internal/derp"
+	"github.com/prysmsh/cli/internal/meshd"
 	"github.com/prysmsh/cli/internal/style"
+	"github.com/prysmsh/cli/internal/ui"
 )
 
 func newMeshExitCommand() *cobra.Command {
@@ -22,24 +27,39 @@ func newMeshExitCommand() *cobra.Command {
 	exitCmd.AddCommand(
 		newMeshExitEnableCommand(),
 		newMeshExitDisableCommand(),
+		newMeshExitListCommand(),
+		newMeshExitUseCommand(),
+		newMeshExitOffCommand(),
 	)
 
 	return exitCmd
 }
 
 func newMeshExitEnableCommand() *cobra.Command {
-	var nodeRef string
+	var (
+		nodeRef    string
+		thisDevice bool
+	)
 
 	cmd := &cobra.Command{
 		Use:   "enable [node-id|device-id]",
 		Short: "Enable a mesh node as an exit node (route traffic through it)",
-		Long:  "Enable a mesh node as an exit node. Use node ID (numeric) or device ID. For devices, use the device_id from `prysm mesh peers`.",
-		Args:  cobra.MaximumNArgs(1),
+		Long: `Enable a mesh node as an exit node. Use node ID (numeric) or device ID. For devices, use the device_id from ` + "`prysm mesh peers`" + `.
+
+With --this-device, this workstation or server becomes the exit node: the mesh
+daemon enables IP forwarding and NATs peers' traffic to the host's network, so
+a team can route through a trusted home or office machine (Linux only).`,
+		Example: `  prysm mesh exit enable prod-cluster-node
+  sudo prysm mesh exit enable --this-device`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ref := nodeRef
 			if len(args) > 0 {
 				ref = args[0]
 			}
+			if thisDevice {
+				return setThisDeviceExit(cmd.Context(), ref, true)
+			}
 			if strings.TrimSpace(ref) == "" {
 				return errors.New("node-id or device-id is required")
 			}
@@ -67,11 +87,15 @@ func newMeshExitEnableCommand() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&nodeRef, "node", "", "mesh node ID or device ID")
+	cmd.Flags().BoolVar(&thisDevice, "this-device", false, "make this device an exit node for its mesh peers")
 	return cmd
 }
 
 func newMeshExitDisableCommand() *cobra.Command {
-	var nodeRef string
+	var (
+		nodeRef    string
+		thisDevice bool
+	)
 
 	cmd := &cobra.Command{
 		Use:   "disable [node-id|device-id]",
@@ -82,6 +106,9 @@ func newMeshExitDisableCommand() *cobra.Command {
 			if len(args) > 0 {
 				ref = args[0]
 			}
+			if thisDevice {
+				return setThisDeviceExit(cmd.Context(), ref, false)
+			}
 			if strings.TrimSpace(ref) == "" {
 				return errors.New("node-id or device-id is required")
 			}
@@ -107,5 +134,231 @@ func newMeshExitDisableCommand() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&nodeRef, "node", "", "mesh node ID or device ID")
+	cmd.Flags().BoolVar(&thisDevice, "this-device", false, "stop acting as an exit node for mesh peers")
 	return cmd
 }
+
+// setThisDeviceExit marks this device as an exit node (or not) and has the
+// local daemon set up forwarding now instead of at its next peer sync.
+func setThisDeviceExit(ctx context.Context, ref string, enable bool) error {
+	if strings.TrimSpace(ref) != "" {
+		return errors.New("--this-device does not take a node-id or device-id")
+	}
+	if enable && runtime.GOOS != "linux" {
+		return fmt.Errorf("acting as an exit node is only supported on Linux")
+	}
+
+	app := MustApp()
+	deviceID, err := derp.EnsureDeviceID(app.Config.HomeDir)
+	if err != nil {
+		return fmt.Errorf("ensure device id: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+	if err := app.API.SetMeshNodeExitByDeviceID(ctx, deviceID, enable); err != nil {
+		if enable {
+			return fmt.Errorf("enable exit node: %w", err)
+		}
+		return fmt.Errorf("disable exit node: %w", err)
+	}
+	if enable {
+		fmt.Println(style.Success.Render(fmt.Sprintf("✓ This device (%s) is now an exit node", deviceID)))
+	} else {
+		fmt.Println(style.Success.Render(fmt.Sprintf("✓ This device (%s) is no longer an exit node", deviceID)))
+	}
+
+	if !meshd.IsRunning() {
+		if enable {
+			fmt.Println(style.Warning.Render("Mesh daemon is not running; forwarding starts once it connects (`sudo prysm daemon install`)."))
+		}
+		return nil
+	}
+	resp, err := meshd.SyncExitGateway()
+	switch {
+	case err != nil:
+		fmt.Println(style.Warning.Render(fmt.Sprintf("Could not reach the mesh daemon: %v", err)))
+	case resp.Error != "":
+		fmt.Println(style.Warning.Render(fmt.Sprintf("Forwarding not configured: %s", resp.Error)))
+	case resp.ExitGateway:
+		fmt.Println(style.MutedStyle.Render("Forwarding peers' traffic; they can route through it with `prysm mesh exit use " + deviceID + "`."))
+	}
+	return nil
+}
+
+type meshExitRow struct {
+	Name     string   `json:"name"`
+	DeviceID string   `json:"device_id"`
+	Status   string   `json:"status"`
+	Regions  []string `json:"regions,omitempty"`
+	InUse    bool     `json:"in_use"`
+}
+
+func newMeshExitListCommand() *cobra.Command {
+	var outputFormat string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List exit nodes this device can route through",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app := MustApp()
+			ctx, cancel := context.WithTimeout(cmd.Context(), 20*time.Second)
+			defer cancel()
+
+			nodes, err := app.API.ListMeshNodes(ctx)
+			if err != nil {
+				return err
+			}
+			clusters, _ := app.API.ListClusters(ctx)
+			var inUse string
+			if meshd.IsRunning() {
+				if st, err := meshd.GetStatus(); err == nil {
+					inUse = st.ExitNode
+				}
+			}
+
+			rows := []meshExitRow{}
+			for _, n := range nodes {
+				if !n.ExitEnabled {
+					continue
+				}
+				rows = append(rows, meshExitRow{
+					Name:     exitNodeName(n, clusters),
+					DeviceID: n.DeviceID,
+					Status:   n.Status,
+					Regions:  n.ExitRegions,
+					InUse:    n.DeviceID == inUse,
+				})
+			}
+
+			if wantsJSONOutput(outputFormat) {
+				return writeJSON(rows)
+			}
+			if len(rows) == 0 {
+				fmt.Println(style.MutedStyle.Render("No exit nodes. Enable one with `prysm mesh exit enable`."))
+				return nil
+			}
+			data := make([][]string, len(rows))
+			for i, r := range rows {
+				mark := ""
+				if r.InUse {
+					mark = "*"
+				}
+				data[i] = []string{r.Name, r.DeviceID, r.Status, strings.Join(r.Regions, ","), mark}
+			}
+			ui.PrintTable([]string{"NAME", "DEVICE", "STATUS", "REGIONS", "IN USE"}, data)
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "", "output format (table, json)")
+	return cmd
+}
+
+func newMeshExitUseCommand() *cobra.Command {
+	var killSwitch bool
+
+	cmd := &cobra.Command{
+		Use:   "use <cluster|device-id>",
+		Short: "Route all of this device's traffic through an exit node",
+		Long: `Route all IPv4 traffic from this device through an exit node, given by cluster
+name or device ID. The mesh daemon adds the exit node's default route to the
+tunnel and keeps the relay and API reachable outside it. Exit nodes forward
+IPv4 only; IPv6 traffic is dropped while one is in use rather than bypassing it.
+
+With --kill-switch, traffic is dropped instead of leaving unprotected while the
+tunnel reconnects. Run ` + "`prysm mesh exit off`" + ` to go back to direct routing.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !meshd.IsRunning() {
+				return fmt.Errorf("exit nodes are managed by the mesh daemon — start it with `sudo prysm daemon install` and run `prysm mesh connect`")
+			}
+
+			app := MustApp()
+			ctx, cancel := context.WithTimeout(cmd.Context(), 20*time.Second)
+			defer cancel()
+			nodes, err := app.API.ListMeshNodes(ctx)
+			if err != nil {
+				return err
+			}
+			clusters, _ := app.API.ListClusters(ctx)
+			node, err := resolveExitNode(nodes, clusters, args[0])
+			if err != nil {
+				return err
+			}
+
+			resp, err := meshd.UseExitNode(node.DeviceID, killSwitch)
+			if err != nil {
+				return fmt.Errorf("query daemon: %w", err)
+			}
+			if resp.Error != "" {
+				return fmt.Errorf("meshd: %s", resp.Error)
+			}
+			msg := fmt.Sprintf("✓ Routing all traffic through %s", exitNodeName(node, clusters))
+			if killSwitch {
+				msg += " (kill switch on)"
+			}
+			fmt.Println(style.Success.Render(msg))
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&killSwitch, "kill-switch", false, "drop traffic while the tunnel is down instead of sending it directly")
+	return cmd
+}
+
+func newMeshExitOffCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "off",
+		Short: "Stop routing traffic through an exit node",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !meshd.IsRunning() {
+				fmt.Println(style.MutedStyle.Render("Mesh daemon is not running; no exit node in use."))
+				return nil
+			}
+			resp, err := meshd.ClearExitNode()
+			if err != nil {
+				return fmt.Errorf("query daemon: %w", err)
+			}
+			if resp.Error != "" {
+				return fmt.Errorf("meshd: %s", resp.Error)
+			}
+			fmt.Println(style.Success.Render("✓ Exit node off; traffic is routed directly"))
+			return nil
+		},
+	}
+}
+
+// resolveExitNode finds the exit-enabled mesh node for a cluster name or
+// device ID.
+func resolveExitNode(nodes []api.MeshNode, clusters []api.Cluster, ref string) (api.MeshNode, error) {
+	var clusterID int64
+	for _, c := range clusters {
+		if strings.EqualFold(c.Name, ref) {
+			clusterID = c.ID
+			break
+		}
+	}
+	for _, n := range nodes {
+		match := n.DeviceID == ref || (clusterID != 0 && n.ClusterID != nil && *n.ClusterID == clusterID)
+		if !match {
+			continue
+		}
+		if !n.ExitEnabled {
+			return api.MeshNode{}, fmt.Errorf("%s is not an exit node — enable it with `prysm mesh exit enable %s`", ref, n.DeviceID)
+		}
+		return n, nil
+	}
+	return api.MeshNode{}, fmt.Errorf("no mesh node or cluster named %q — see `prysm mesh exit list`", ref)
+}
+
+// exitNodeName returns the cluster name for cluster nodes, else the device ID.
+func exitNodeName(n api.MeshNode, clusters []api.Cluster) string {
+	if n.ClusterID != nil {
+		for _, c := range clusters {
+			if c.ID == *n.ClusterID {
+				return c.Name
+			}
+		}
+	}
+	return n.DeviceID
+}
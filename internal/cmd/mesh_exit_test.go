@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/prysmsh/cli/internal/api"
+)
+
+func TestResolveExitNode(t *testing.T) {
+	prodID, stagingID := int64(1), int64(2)
+	clusters := []api.Cluster{{ID: prodID, Name: "prod-eu"}, {ID: stagingID, Name: "staging"}}
+	nodes := []api.MeshNode{
+		{DeviceID: "agent-prod", ClusterID: &prodID, ExitEnabled: true},
+		{DeviceID: "agent-staging", ClusterID: &stagingID},
+		{DeviceID: "laptop-1", ExitEnabled: true},
+	}
+
+	tests := []struct {
+		ref, want, err string
+	}{
+		{ref: "prod-eu", want: "agent-prod"},
+		{ref: "PROD-EU", want: "agent-prod"},
+		{ref: "laptop-1", want: "laptop-1"},
+		{ref: "staging", err: "not an exit node"},
+		{ref: "nope", err: "no mesh node or cluster"},
+	}
+	for _, tt := range tests {
+		n, err := resolveExitNode(nodes, clusters, tt.ref)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("resolveExitNode(%q) err = %v, want %q", tt.ref, err, tt.err)
+			}
+			continue
+		}
+		if err != nil || n.DeviceID != tt.want {
+			t.Errorf("resolveExitNode(%q) = %q, %v; want %q", tt.ref, n.DeviceID, err, tt.want)
+		}
+	}
+}
+
+func TestSetThisDeviceExitRejectsRef(t *testing.T) {
+	err := setThisDeviceExit(context.Background(), "laptop-1", true)
+	if err == nil || !strings.Contains(err.Error(), "--this-device") {
+		t.Errorf("expected --this-device usage error, got %v", err)
+	}
+}
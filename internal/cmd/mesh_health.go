@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/prysmsh/cli/internal/meshd"
+	"github.com/prysmsh/cli/internal/style"
+)
+
+func newMeshHealthCommand() *cobra.Command {
+	var (
+		outputFormat string
+		jsonOutput   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "health",
+		Short: "Report mesh tunnel health from the daemon (exits non-zero when unhealthy)",
+		Long: `Report mesh tunnel health from the mesh daemon. The command exits non-zero
+unless the tunnel is connected, so it can back monitoring checks.
+
+For scraping, the daemon serves /healthz and Prometheus /metrics on its control
+socket (curl --unix-socket ` + meshd.SocketPath + ` http://meshd/metrics), and on
+a TCP address when run with --metrics-listen.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			health := &meshd.Health{State: "daemon not running"}
+			if meshd.IsRunning() {
+				h, err := meshd.GetHealth()
+				if err != nil {
+					return err
+				}
+				health = h
+			}
+
+			if jsonOutput {
+				outputFormat = "json"
+			}
+			if wantsJSONOutput(outputFormat) {
+				if err := writeJSON(health); err != nil {
+					return err
+				}
+			} else {
+				printMeshHealth(health)
+			}
+			if !health.Healthy {
+				return fmt.Errorf("mesh unhealthy: %s", health.State)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "", "output format (table, json)")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "shorthand for --output json")
+	return cmd
+}
+
+func printMeshHealth(h *meshd.Health) {
+	status := style.Success.Render("healthy")
+	if !h.Healthy {
+		status = style.Warning.Render("unhealthy")
+	}
+	fmt.Printf("Health:    %s\n", status)
+	fmt.Printf("State:     %s\n", h.State)
+	if h.OverlayIP != "" {
+		fmt.Printf("Overlay:   %s\n", h.OverlayIP)
+	}
+	if h.Interface != "" {
+		fmt.Printf("Interface: %s\n", h.Interface)
+	}
+	if h.Healthy {
+		fmt.Printf("Peers:     %d\n", h.PeerCount)
+		fmt.Printf("Uptime:    %s\n", (time.Duration(h.Uptime) * time.Second).String())
+		fmt.Printf("Traffic:   %d B sent, %d B received\n", h.TxBytes, h.RxBytes)
+	}
+}
@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"golang.org/x/term"
+
+	"github.com/prysmsh/cli/internal/meshd"
+	"github.com/prysmsh/cli/internal/style"
+)
+
+// meshPeersWatchHistory is how many recent changes watch mode keeps on screen.
+const meshPeersWatchHistory = 10
+
+// meshPeersMinRefresh debounces bursts of peer events into one API call.
+const meshPeersMinRefresh = time.Second
+
+// watchMeshPeers redraws the peers table until interrupted. Refreshes are
+// triggered by the mesh daemon's DERP peer events while it is connected and
+// by a fallback poll every interval.
+func watchMeshPeers(ctx context.Context, app *App, interval time.Duration) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+	go func() {
+		select {
+		case <-sigCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	trigger := make(chan struct{}, 1)
+	var live atomic.Bool
+	startMeshPeerEvents(ctx, &live, func() {
+		select {
+		case trigger <- struct{}{}:
+		default:
+		}
+	})
+
+	tty := term.IsTerminal(int(os.Stdout.Fd()))
+
+	var (
+		prev    []meshPeerRow
+		changes []string
+		loaded  bool
+	)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		fetchCtx, fetchCancel := context.WithTimeout(ctx, 20*time.Second)
+		rows, err := loadMeshPeerRows(fetchCtx, app)
+		fetchCancel()
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		now := time.Now().Format("15:04:05")
+		source := fmt.Sprintf("polling every %s", interval)
+		if live.Load() {
+			source = "live via mesh daemon"
+		}
+		var diff []string
+		if err == nil && loaded {
+			diff = diffMeshPeerRows(prev, rows)
+			for i := range diff {
+				diff[i] = now + "  " + diff[i]
+			}
+			changes = append(changes, diff...)
+			if len(changes) > meshPeersWatchHistory {
+				changes = changes[len(changes)-meshPeersWatchHistory:]
+			}
+		}
+
+		switch {
+		case tty:
+			fmt.Print("\033[H\033[2J")
+			fmt.Println(style.MutedStyle.Render(fmt.Sprintf("Mesh peers — updated %s (%s) · Ctrl+C to exit", now, source)))
+			fmt.Println()
+			if err != nil {
+				fmt.Println(style.Error.Render(fmt.Sprintf("refresh failed: %v", err)))
+			}
+			if err == nil || loaded {
+				printMeshPeersWatchTable(rows, prev, err)
+			}
+			if len(changes) > 0 {
+				fmt.Println()
+				fmt.Println("Recent changes:")
+				for _, c := range changes {
+					fmt.Println("  " + c)
+				}
+			}
+		case err != nil:
+			fmt.Fprintln(os.Stderr, style.Error.Render(fmt.Sprintf("%s  refresh failed: %v", now, err)))
+		case !loaded:
+			printMeshPeersWatchTable(rows, prev, nil)
+		default:
+			for _, c := range diff {
+				fmt.Println(c)
+			}
+		}
+		if err == nil {
+			prev, loaded = rows, true
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		case <-trigger:
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(meshPeersMinRefresh):
+			}
+		}
+	}
+}
+
+func printMeshPeersWatchTable(rows, prev []meshPeerRow, err error) {
+	if err != nil {
+		rows = prev
+	}
+	if len(rows) == 0 {
+		fmt.Println(style.Warning.Render("No mesh peers registered for your organization."))
+		return
+	}
+	renderMeshPeerRows(append([]meshPeerRow(nil), rows...))
+}
+
+// startMeshPeerEvents follows the DERP peer events seen by the mesh daemon
+// and calls onEvent for each batch. live is set once the daemon answered and
+// cleared when it stops; without it watch mode relies on polling alone.
+func startMeshPeerEvents(ctx context.Context, live *atomic.Bool, onEvent func()) {
+	if !meshd.IsRunning() {
+		return
+	}
+	seq, err := meshd.WaitPeerEvent(0)
+	if err != nil {
+		printDebug("mesh peers watch: no daemon events: %v", err)
+		return
+	}
+	live.Store(true)
+	go func() {
+		defer func() {
+			live.Store(false)
+			onEvent()
+		}()
+		for ctx.Err() == nil {
+			next, err := meshd.WaitPeerEvent(seq)
+			if err != nil {
+				if ctx.Err() == nil {
+					printDebug("mesh peers watch: daemon events ended: %v", err)
+				}
+				return
+			}
+			// A smaller count means the daemon reconnected and started over.
+			if next != seq {
+				seq = next
+				onEvent()
+			}
+		}
+	}()
+}
+
+// diffMeshPeerRows describes peers that joined, left or changed status or
+// exit role between two snapshots.
+func diffMeshPeerRows(prev, next []meshPeerRow) []string {
+	before := make(map[string]meshPeerRow, len(prev))
+	for _, r := range prev {
+		before[r.DeviceID] = r
+	}
+	after := make(map[string]meshPeerRow, len(next))
+	for _, r := range next {
+		after[r.DeviceID] = r
+	}
+
+	var out []string
+	for _, r := range sortedMeshPeerRows(next) {
+		old, ok := before[r.DeviceID]
+		switch {
+		case !ok:
+			out = append(out, fmt.Sprintf("+ %s joined (%s)", r.DeviceID, r.Status))
+		case old.Status != r.Status:
+			out = append(out, fmt.Sprintf("~ %s: %s → %s", r.DeviceID, old.Status, r.Status))
+		case old.Exit != r.Exit:
+			out = append(out, fmt.Sprintf("~ %s: exit %s → %s", r.DeviceID, old.Exit, r.Exit))
+		}
+	}
+	for _, r := range sortedMeshPeerRows(prev) {
+		if _, ok := after[r.DeviceID]; !ok {
+			out = append(out, fmt.Sprintf("- %s left", r.DeviceID))
+		}
+	}
+	return out
+}
+
+func sortedMeshPeerRows(rows []meshPeerRow) []meshPeerRow {
+	out := append([]meshPeerRow(nil), rows...)
+	sort.Slice(out, func(i, j int) bool { return out[i].DeviceID < out[j].DeviceID })
+	return out
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffMeshPeerRows(t *testing.T) {
+	prev := []meshPeerRow{
+		{DeviceID: "laptop-1", Status: "connected", Exit: "-"},
+		{DeviceID: "agent-prod", Status: "connected", Exit: "-"},
+		{DeviceID: "ci-runner", Status: "connected", Exit: "-"},
+	}
+	next := []meshPeerRow{
+		{DeviceID: "agent-prod", Status: "connected", Exit: "prio:10"},
+		{DeviceID: "laptop-1", Status: "disconnected", Exit: "-"},
+		{DeviceID: "laptop-2", Status: "connected", Exit: "-"},
+	}
+
+	want := []string{
+		"~ agent-prod: exit - → prio:10",
+		"~ laptop-1: connected → disconnected",
+		"+ laptop-2 joined (connected)",
+		"- ci-runner left",
+	}
+	if got := diffMeshPeerRows(prev, next); !reflect.DeepEqual(got, want) {
+		t.Errorf("diffMeshPeerRows() = %q, want %q", got, want)
+	}
+	if got := diffMeshPeerRows(next, next); len(got) != 0 {
+		t.Errorf("diffMeshPeerRows(same) = %q, want none", got)
+	}
+}
@@ -0,0 +1,432 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+
+	"github.com/prysmsh/cli/internal/api"
+	"github.com/prysmsh/cli/internal/meshd"
+	"github.com/prysmsh/cli/internal/meshdns"
+	"github.com/prysmsh/cli/internal/style"
+)
+
+// meshPingFallbackPort is probed over TCP when ICMP sockets are unavailable;
+// a refused connection still proves the peer answered over WireGuard.
+const meshPingFallbackPort = 22
+
+type meshPingTarget struct {
+	Name         string
+	DeviceID     string
+	OverlayIP    net.IP
+	TargetClient string // DERP client ID for relay pings
+}
+
+type meshPingPath struct {
+	Method   string         `json:"method,omitempty"` // icmp, tcp or relay
+	Sent     int            `json:"sent"`
+	Received int            `json:"received"`
+	Min      *time.Duration `json:"min_ns,omitempty"`
+	Avg      *time.Duration `json:"avg_ns,omitempty"`
+	Max      *time.Duration `json:"max_ns,omitempty"`
+	Error    string         `json:"error,omitempty"`
+	rtts     []time.Duration
+}
+
+type meshPingReport struct {
+	Peer          string       `json:"peer"`
+	DeviceID      string       `json:"device_id"`
+	OverlayIP     string       `json:"overlay_ip,omitempty"`
+	LastHandshake *time.Time   `json:"last_handshake,omitempty"`
+	WireGuard     meshPingPath `json:"wireguard"`
+	Relay         meshPingPath `json:"relay"`
+	ActivePath    string       `json:"active_path"` // wireguard, relay or none
+}
+
+func newMeshPingCommand() *cobra.Command {
+	var (
+		count        int
+		timeout      time.Duration
+		outputFormat string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "ping <peer>",
+		Short: "Measure reachability and latency to a mesh peer",
+		Long: `Ping a mesh peer over both the WireGuard overlay and the DERP relay and
+report which path is active.
+
+The peer can be a device ID, a <device>.` + meshdns.Suffix + ` name, a cluster name or
+an overlay IP. The WireGuard path is probed with ICMP echo to the peer's overlay
+IP (falling back to a TCP probe when ICMP sockets are not permitted, and for
+IPv6 overlay addresses) and needs a running mesh tunnel; the relay path sends
+ping requests through the mesh daemon's DERP connection.
+
+Exits non-zero when the peer is unreachable on both paths.`,
+		Example: `  prysm mesh ping laptop-1.mesh.prysm
+  prysm mesh ping prod-cluster -c 10
+  prysm mesh ping 100.96.0.7 -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if count < 1 {
+				return fmt.Errorf("--count must be at least 1")
+			}
+			app := MustApp()
+			ctx := cmd.Context()
+
+			listCtx, cancel := context.WithTimeout(ctx, 20*time.Second)
+			nodes, err := app.API.ListMeshNodes(listCtx)
+			if err != nil {
+				cancel()
+				return err
+			}
+			clusters, _ := app.API.ListClusters(listCtx)
+			cancel()
+
+			target, err := resolveMeshPingTarget(nodes, clusters, args[0])
+			if err != nil {
+				return err
+			}
+
+			report := &meshPingReport{Peer: target.Name, DeviceID: target.DeviceID}
+			if target.OverlayIP != nil {
+				report.OverlayIP = target.OverlayIP.String()
+			}
+			report.LastHandshake = meshPingHandshake(report.OverlayIP)
+
+			human := !wantsJSONOutput(outputFormat)
+			if human {
+				if report.OverlayIP != "" {
+					fmt.Printf("PING %s (%s)\n", target.Name, report.OverlayIP)
+				} else {
+					fmt.Printf("PING %s\n", target.Name)
+				}
+			}
+
+			var wg sync.WaitGroup
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				report.WireGuard = pingMeshWireGuard(ctx, target, count, timeout)
+			}()
+			go func() {
+				defer wg.Done()
+				report.Relay = pingMeshRelay(ctx, target, count, timeout)
+			}()
+			wg.Wait()
+			report.ActivePath = meshPingActivePath(report)
+
+			if human {
+				printMeshPing(report)
+			} else if err := writeJSON(report); err != nil {
+				return err
+			}
+			if report.ActivePath == "none" {
+				return fmt.Errorf("%s is unreachable", target.Name)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVarP(&count, "count", "c", 4, "number of probes per path")
+	cmd.Flags().DurationVar(&timeout, "timeout", 2*time.Second, "time to wait for each reply")
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "", "output format (table, json)")
+	return cmd
+}
+
+// resolveMeshPingTarget finds the mesh node ref names: a device ID, a
+// <device>.mesh.prysm name (or just its label), a cluster name or an overlay IP.
+func resolveMeshPingTarget(nodes []api.MeshNode, clusters []api.Cluster, ref string) (meshPingTarget, error) {
+	ref = strings.TrimSuffix(strings.TrimSpace(ref), ".")
+	label := strings.TrimSuffix(strings.ToLower(ref), "."+meshdns.Suffix)
+
+	var clusterID int64
+	var clusterName string
+	for _, c := range clusters {
+		if strings.EqualFold(c.Name, ref) {
+			clusterID, clusterName = c.ID, c.Name
+			break
+		}
+	}
+
+	for _, n := range nodes {
+		addr, _, _ := strings.Cut(n.WGAddress, "/")
+		ip := net.ParseIP(addr)
+		if v4 := ip.To4(); v4 != nil {
+			ip = v4
+		}
+		match := n.DeviceID == ref ||
+			(ip != nil && ip.Equal(net.ParseIP(ref))) ||
+			(label != "" && meshdns.Label(n.DeviceID) == label) ||
+			(clusterID != 0 && n.ClusterID != nil && *n.ClusterID == clusterID)
+		if !match {
+			continue
+		}
+
+		t := meshPingTarget{Name: n.DeviceID, DeviceID: n.DeviceID, OverlayIP: ip, TargetClient: n.DERPClientID}
+		if clusterName != "" {
+			t.Name = clusterName
+		} else if l := meshdns.Label(n.DeviceID); ip != nil && l != "" {
+			t.Name = l + "." + meshdns.Suffix
+		}
+		if t.TargetClient == "" {
+			t.TargetClient = "device_" + n.DeviceID
+			if strings.HasPrefix(n.DeviceID, "cluster_") {
+				t.TargetClient = n.DeviceID
+			}
+		}
+		return t, nil
+	}
+	return meshPingTarget{}, fmt.Errorf("no mesh peer named %q — see `prysm mesh peers`", ref)
+}
+
+// meshPingHandshake returns the daemon's last WireGuard handshake with the
+// peer at overlayIP, or nil when unknown.
+func meshPingHandshake(overlayIP string) *time.Time {
+	if overlayIP == "" || !meshd.IsRunning() {
+		return nil
+	}
+	st, err := meshd.GetStatus()
+	if err != nil {
+		printDebug("query daemon: %v", err)
+		return nil
+	}
+	for _, p := range st.Peers {
+		if p.OverlayIP == overlayIP && p.LastHandshake > 0 {
+			hs := time.Unix(p.LastHandshake, 0).UTC()
+			return &hs
+		}
+	}
+	return nil
+}
+
+func pingMeshWireGuard(ctx context.Context, t meshPingTarget, count int, timeout time.Duration) meshPingPath {
+	if t.OverlayIP == nil {
+		return meshPingPath{Error: "peer has no overlay address"}
+	}
+
+	path := meshPingPath{Method: "icmp"}
+	var (
+		conn       *icmp.PacketConn
+		privileged bool
+		err        error
+	)
+	if t.OverlayIP.To4() == nil {
+		// IPv6 overlay addresses are probed over TCP; echo is ICMPv4 only.
+		path.Method = "tcp"
+	} else if conn, privileged, err = listenMeshICMP(); err != nil {
+		printDebug("icmp unavailable, probing tcp/%d: %v", meshPingFallbackPort, err)
+		path.Method = "tcp"
+	} else {
+		defer conn.Close()
+	}
+
+	for seq := 1; seq <= count && ctx.Err() == nil; seq++ {
+		if seq > 1 {
+			sleepCtx(ctx, time.Second)
+		}
+		path.Sent++
+		var rtt time.Duration
+		if conn != nil {
+			rtt, err = probeMeshICMP(conn, privileged, t.OverlayIP, seq, timeout)
+		} else {
+			rtt, err = probeMeshTCP(ctx, t.OverlayIP, timeout)
+		}
+		if err != nil {
+			path.Error = err.Error()
+			continue
+		}
+		path.record(rtt)
+	}
+	return path
+}
+
+// listenMeshICMP opens an unprivileged ICMP socket, falling back to a raw one
+// (root, or CAP_NET_RAW) where the kernel does not allow ping sockets.
+func listenMeshICMP() (*icmp.PacketConn, bool, error) {
+	if conn, err := icmp.ListenPacket("udp4", "0.0.0.0"); err == nil {
+		return conn, false, nil
+	}
+	conn, err := icmp.ListenPacket("ip4:icmp", "0.0.0.0")
+	if err != nil {
+		return nil, false, err
+	}
+	return conn, true, nil
+}
+
+func probeMeshICMP(conn *icmp.PacketConn, privileged bool, ip net.IP, seq int, timeout time.Duration) (time.Duration, error) {
+	msg := icmp.Message{
+		Type: ipv4.ICMPTypeEcho,
+		Body: &icmp.Echo{ID: os.Getpid() & 0xffff, Seq: seq, Data: []byte("prysm-mesh-ping")},
+	}
+	b, err := msg.Marshal(nil)
+	if err != nil {
+		return 0, err
+	}
+	var dst net.Addr = &net.UDPAddr{IP: ip}
+	if privileged {
+		dst = &net.IPAddr{IP: ip}
+	}
+
+	start := time.Now()
+	if _, err := conn.WriteTo(b, dst); err != nil {
+		return 0, fmt.Errorf("send echo: %w", err)
+	}
+	if err := conn.SetReadDeadline(start.Add(timeout)); err != nil {
+		return 0, err
+	}
+	buf := make([]byte, 1500)
+	for {
+		n, from, err := conn.ReadFrom(buf)
+		if err != nil {
+			return 0, fmt.Errorf("no reply within %s", timeout)
+		}
+		reply, err := icmp.ParseMessage(ipv4.ICMPTypeEcho.Protocol(), buf[:n])
+		if err != nil || reply.Type != ipv4.ICMPTypeEchoReply {
+			continue
+		}
+		// Ping sockets rewrite the echo ID, so match on source and sequence.
+		echo, ok := reply.Body.(*icmp.Echo)
+		if !ok || echo.Seq != seq || !ip.Equal(icmpSource(from)) {
+			continue
+		}
+		return time.Since(start), nil
+	}
+}
+
+func icmpSource(addr net.Addr) net.IP {
+	switch a := addr.(type) {
+	case *net.UDPAddr:
+		return a.IP
+	case *net.IPAddr:
+		return a.IP
+	}
+	return nil
+}
+
+func probeMeshTCP(ctx context.Context, ip net.IP, timeout time.Duration) (time.Duration, error) {
+	d := net.Dialer{Timeout: timeout}
+	start := time.Now()
+	conn, err := d.DialContext(ctx, "tcp", net.JoinHostPort(ip.String(), fmt.Sprint(meshPingFallbackPort)))
+	rtt := time.Since(start)
+	if err == nil {
+		conn.Close()
+		return rtt, nil
+	}
+	if isConnRefused(err) {
+		return rtt, nil
+	}
+	return 0, fmt.Errorf("no reply within %s", timeout)
+}
+
+// pingMeshRelay probes the relay path through the mesh daemon's DERP
+// connection; a second relay session with this device's ID would displace it.
+func pingMeshRelay(ctx context.Context, t meshPingTarget, count int, timeout time.Duration) meshPingPath {
+	path := meshPingPath{Method: "relay"}
+	if st, err := meshd.GetStatus(); err != nil || st.Status == "disconnected" {
+		path.Error = "mesh daemon not connected (start it with `prysm mesh connect`)"
+		return path
+	}
+	for seq := 1; seq <= count && ctx.Err() == nil; seq++ {
+		if seq > 1 {
+			sleepCtx(ctx, time.Second)
+		}
+		path.Sent++
+		rtt, err := meshd.RelayPing(t.TargetClient, timeout)
+		if err != nil {
+			path.Error = err.Error()
+			continue
+		}
+		path.record(rtt)
+	}
+	return path
+}
+
+// meshPingActivePath reports the path traffic to the peer takes: WireGuard
+// when the overlay answers, else the relay.
+func meshPingActivePath(r *meshPingReport) string {
+	switch {
+	case r.WireGuard.Received > 0:
+		return "wireguard"
+	case r.Relay.Received > 0:
+		return "relay"
+	default:
+		return "none"
+	}
+}
+
+func (p *meshPingPath) record(rtt time.Duration) {
+	p.Received++
+	p.rtts = append(p.rtts, rtt)
+	var sum time.Duration
+	lo, hi := rtt, rtt
+	for _, d := range p.rtts {
+		sum += d
+		if d < lo {
+			lo = d
+		}
+		if d > hi {
+			hi = d
+		}
+	}
+	avg := sum / time.Duration(len(p.rtts))
+	p.Min, p.Avg, p.Max = &lo, &avg, &hi
+	p.Error = ""
+}
+
+func printMeshPing(r *meshPingReport) {
+	printPath := func(name string, p meshPingPath) {
+		loss := 100
+		if p.Sent > 0 {
+			loss = 100 * (p.Sent - p.Received) / p.Sent
+		}
+		line := fmt.Sprintf("%-10s %d/%d replies, %d%% loss", name, p.Received, p.Sent, loss)
+		if p.Method != "" && p.Method != "relay" {
+			line += fmt.Sprintf(" (%s)", p.Method)
+		}
+		if p.Avg != nil {
+			line += fmt.Sprintf(", rtt min/avg/max %s/%s/%s", roundRTT(*p.Min), roundRTT(*p.Avg), roundRTT(*p.Max))
+		}
+		if p.Received > 0 {
+			fmt.Println(style.Success.Render(line))
+		} else {
+			fmt.Println(style.Warning.Render(line))
+		}
+		if p.Received == 0 && p.Error != "" {
+			fmt.Println(style.MutedStyle.Render("           " + p.Error))
+		}
+	}
+	printPath("WireGuard:", r.WireGuard)
+	printPath("Relay:", r.Relay)
+	if r.LastHandshake != nil {
+		fmt.Printf("Handshake: %s ago\n", time.Since(*r.LastHandshake).Truncate(time.Second))
+	}
+
+	switch r.ActivePath {
+	case "wireguard":
+		fmt.Println(style.Success.Render("Active path: WireGuard"))
+	case "relay":
+		fmt.Println(style.Warning.Render("Active path: DERP relay (WireGuard overlay not answering)"))
+	default:
+		fmt.Println(style.Error.Render("Active path: none — peer unreachable"))
+	}
+}
+
+func roundRTT(d time.Duration) time.Duration {
+	return d.Round(10 * time.Microsecond)
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) {
+	select {
+	case <-ctx.Done():
+	case <-time.After(d):
+	}
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/prysmsh/cli/internal/api"
+)
+
+func TestResolveMeshPingTarget(t *testing.T) {
+	prodID := int64(1)
+	clusters := []api.Cluster{{ID: prodID, Name: "prod-eu"}}
+	nodes := []api.MeshNode{
+		{DeviceID: "cluster_prod", ClusterID: &prodID, WGAddress: "100.96.0.2/32"},
+		{DeviceID: "Laptop_1", WGAddress: "100.96.0.7/32", DERPClientID: "client-7"},
+		{DeviceID: "builder"},
+		{DeviceID: "v6-box", WGAddress: "fd7a:115c:a1e0::9/128"},
+	}
+
+	tests := []struct {
+		ref, name, ip, client string
+	}{
+		{ref: "prod-eu", name: "prod-eu", ip: "100.96.0.2", client: "cluster_prod"},
+		{ref: "laptop-1.mesh.prysm", name: "laptop-1.mesh.prysm", ip: "100.96.0.7", client: "client-7"},
+		{ref: "laptop-1.mesh.prysm.", name: "laptop-1.mesh.prysm", ip: "100.96.0.7", client: "client-7"},
+		{ref: "100.96.0.7", name: "laptop-1.mesh.prysm", ip: "100.96.0.7", client: "client-7"},
+		{ref: "Laptop_1", name: "laptop-1.mesh.prysm", ip: "100.96.0.7", client: "client-7"},
+		{ref: "builder", name: "builder", client: "device_builder"},
+		{ref: "fd7a:115c:a1e0:0::9", name: "v6-box.mesh.prysm", ip: "fd7a:115c:a1e0::9", client: "device_v6-box"},
+	}
+	for _, tt := range tests {
+		got, err := resolveMeshPingTarget(nodes, clusters, tt.ref)
+		if err != nil {
+			t.Errorf("resolveMeshPingTarget(%q): %v", tt.ref, err)
+			continue
+		}
+		ip := ""
+		if got.OverlayIP != nil {
+			ip = got.OverlayIP.String()
+		}
+		if got.Name != tt.name || ip != tt.ip || got.TargetClient != tt.client {
+			t.Errorf("resolveMeshPingTarget(%q) = %q %q %q; want %q %q %q", tt.ref, got.Name, ip, got.TargetClient, tt.name, tt.ip, tt.client)
+		}
+	}
+
+	if _, err := resolveMeshPingTarget(nodes, clusters, "nope"); err == nil {
+		t.Error("resolveMeshPingTarget(nope): expected error")
+	}
+}
+
+func TestMeshPingPathRecord(t *testing.T) {
+	var p meshPingPath
+	p.Sent = 3
+	p.Error = "no reply within 2s"
+	p.record(30 * time.Millisecond)
+	p.record(10 * time.Millisecond)
+	p.record(20 * time.Millisecond)
+
+	if p.Received != 3 || p.Error != "" {
+		t.Fatalf("received=%d error=%q", p.Received, p.Error)
+	}
+	if *p.Min != 10*time.Millisecond || *p.Avg != 20*time.Millisecond || *p.Max != 30*time.Millisecond {
+		t.Errorf("min/avg/max = %s/%s/%s", *p.Min, *p.Avg, *p.Max)
+	}
+}
+
+func TestMeshPingActivePath(t *testing.T) {
+	tests := []struct {
+		wg, relay int
+		want      string
+	}{
+		{wg: 2, relay: 2, want: "wireguard"},
+		{wg: 1, relay: 0, want: "wireguard"},
+		{wg: 0, relay: 3, want: "relay"},
+		{wg: 0, relay: 0, want: "none"},
+	}
+	for _, tt := range tests {
+		r := &meshPingReport{WireGuard: meshPingPath{Received: tt.wg}, Relay: meshPingPath{Received: tt.relay}}
+		if got := meshPingActivePath(r); got != tt.want {
+			t.Errorf("meshPingActivePath(wg=%d, relay=%d) = %q, want %q", tt.wg, tt.relay, got, tt.want)
+		}
+	}
+}
+
+func TestIsConnRefused(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().(*net.TCPAddr)
+	ln.Close()
+
+	_, err = net.DialTimeout("tcp", addr.String(), time.Second)
+	if err == nil || !isConnRefused(err) {
+		t.Fatalf("dial closed port: %v, isConnRefused = false", err)
+	}
+}
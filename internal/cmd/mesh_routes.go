@@ -18,13 +18,18 @@ import (
 func newMeshRoutesCommand() *cobra.Command {
 	routesCmd := &cobra.Command{
 		Use:   "routes",
-		Short: "Manage DERP mesh exit routes",
+		Short: "Manage DERP mesh exit routes and subnet routes",
 	}
 
 	routesCmd.AddCommand(
 		newMeshRoutesListCommand(),
 		newMeshRoutesCreateCommand(),
 		newMeshRoutesDeleteCommand(),
+		newMeshRoutesSubnetsCommand(),
+		newMeshRoutesAdvertiseCommand(),
+		newMeshRoutesWithdrawCommand(),
+		newMeshRoutesAcceptCommand(),
+		newMeshRoutesRejectCommand(),
 	)
 
 	return routesCmd
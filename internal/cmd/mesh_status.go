@@ -0,0 +1,236 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/prysmsh/cli/internal/meshd"
+	"github.com/prysmsh/cli/internal/style"
+)
+
+// Exit codes for `prysm mesh status`, for use as a monitoring check.
+const (
+	meshStatusHealthy  = 0
+	meshStatusDegraded = 1
+	meshStatusDown     = 2
+)
+
+// staleHandshakeAfter matches WireGuard's reject-after time: a peer without a
+// handshake for this long can no longer exchange data.
+const staleHandshakeAfter = 3 * time.Minute
+
+type meshStatusReport struct {
+	Health    string              `json:"health"` // healthy, degraded, down
+	State     string              `json:"state"`
+	OverlayIP string              `json:"overlay_ip,omitempty"`
+	Interface string              `json:"interface,omitempty"`
+	Uptime    int64               `json:"uptime"` // seconds
+	TxBytes   int64               `json:"tx_bytes"`
+	RxBytes   int64               `json:"rx_bytes"`
+	ExitNode  string              `json:"exit_node,omitempty"`
+	Peers     []meshStatusPeer    `json:"peers"`
+	Clusters  []meshStatusCluster `json:"clusters"`
+	Warnings  []string            `json:"warnings"`
+}
+
+type meshStatusPeer struct {
+	Name          string     `json:"name"`
+	OverlayIP     string     `json:"overlay_ip"`
+	PublicKey     string     `json:"public_key,omitempty"`
+	LastHandshake *time.Time `json:"last_handshake"`
+	TxBytes       int64      `json:"tx_bytes"`
+	RxBytes       int64      `json:"rx_bytes"`
+}
+
+type meshStatusCluster struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	MeshIP     string `json:"mesh_ip,omitempty"`
+	ExitRouter bool   `json:"exit_router"`
+}
+
+func newMeshStatusCommand() *cobra.Command {
+	var outputFormat string
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show mesh tunnel, peer and cluster status",
+		Long: `Show the mesh tunnel state reported by the mesh daemon, with per-peer
+handshakes and traffic, the organization's clusters and any warnings.
+
+The exit code reflects mesh health so the command can be used directly as a
+monitoring check:
+  0  healthy   tunnel connected and all peers have a recent handshake
+  1  degraded  tunnel reconnecting, or a peer has no recent handshake
+  2  down      tunnel disconnected or the daemon is not running`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var st *meshd.Response
+			if meshd.IsRunning() {
+				resp, err := meshd.GetStatus()
+				if err != nil {
+					return fmt.Errorf("query daemon: %w", err)
+				}
+				st = resp
+			}
+			report := buildMeshStatusReport(st, time.Now())
+			addMeshStatusClusters(cmd.Context(), report)
+
+			if wantsJSONOutput(outputFormat) {
+				if err := writeJSON(report); err != nil {
+					return err
+				}
+			} else {
+				printMeshStatus(report)
+			}
+
+			switch report.Health {
+			case "healthy":
+				return nil
+			case "degraded":
+				return withExitCode(meshStatusDegraded, fmt.Errorf("mesh degraded"))
+			default:
+				return withExitCode(meshStatusDown, fmt.Errorf("mesh down: %s", report.State))
+			}
+		},
+	}
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "", "output format (table, json)")
+	return cmd
+}
+
+// buildMeshStatusReport classifies the daemon's status. st is nil when the
+// daemon is not running.
+func buildMeshStatusReport(st *meshd.Response, now time.Time) *meshStatusReport {
+	r := &meshStatusReport{Peers: []meshStatusPeer{}, Clusters: []meshStatusCluster{}, Warnings: []string{}}
+	if st == nil {
+		r.Health, r.State = "down", "daemon not running"
+		r.Warnings = append(r.Warnings, "mesh daemon is not running — start it with `sudo prysm daemon install`")
+		return r
+	}
+
+	r.State = st.Status
+	r.OverlayIP = st.OverlayIP
+	r.Interface = st.Interface
+	r.Uptime = st.Uptime
+	r.TxBytes = st.TxBytes
+	r.RxBytes = st.RxBytes
+	r.ExitNode = st.ExitNode
+
+	switch st.Status {
+	case "connected":
+		r.Health = "healthy"
+	case "disconnected", "":
+		r.Health = "down"
+		return r
+	default:
+		r.Health = "degraded"
+		r.Warnings = append(r.Warnings, fmt.Sprintf("tunnel is %s", st.Status))
+	}
+
+	for _, p := range st.Peers {
+		peer := meshStatusPeer{
+			Name:      p.Name,
+			OverlayIP: p.OverlayIP,
+			PublicKey: p.PublicKey,
+			TxBytes:   p.TxBytes,
+			RxBytes:   p.RxBytes,
+		}
+		if p.LastHandshake > 0 {
+			hs := time.Unix(p.LastHandshake, 0).UTC()
+			peer.LastHandshake = &hs
+		}
+		r.Peers = append(r.Peers, peer)
+
+		switch {
+		case peer.LastHandshake == nil:
+			r.Warnings = append(r.Warnings, fmt.Sprintf("peer %s (%s): no handshake yet", p.Name, p.OverlayIP))
+		case now.Sub(*peer.LastHandshake) > staleHandshakeAfter:
+			r.Warnings = append(r.Warnings, fmt.Sprintf("peer %s (%s): last handshake %s ago", p.Name, p.OverlayIP, now.Sub(*peer.LastHandshake).Truncate(time.Second)))
+		default:
+			continue
+		}
+		r.Health = "degraded"
+	}
+	if r.Health == "healthy" && len(r.Peers) == 0 {
+		r.Warnings = append(r.Warnings, "no WireGuard peers configured")
+	}
+	return r
+}
+
+// addMeshStatusClusters adds the organization's clusters to r. Failures are
+// reported as warnings; they do not affect mesh health.
+func addMeshStatusClusters(ctx context.Context, r *meshStatusReport) {
+	app := MustApp()
+	if sess, err := app.Sessions.Load(); err != nil || sess == nil {
+		r.Warnings = append(r.Warnings, "not logged in; cluster status unavailable")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	clusters, err := app.API.ListClusters(ctx)
+	if err != nil {
+		r.Warnings = append(r.Warnings, fmt.Sprintf("list clusters: %v", err))
+		return
+	}
+	for _, c := range clusters {
+		r.Clusters = append(r.Clusters, meshStatusCluster{
+			Name:       c.Name,
+			Status:     c.Status,
+			MeshIP:     c.MeshIP,
+			ExitRouter: c.IsExitRouter,
+		})
+	}
+}
+
+func printMeshStatus(r *meshStatusReport) {
+	health := style.Success.Render(r.Health)
+	if r.Health != "healthy" {
+		health = style.Warning.Render(r.Health)
+	}
+	fmt.Printf("Health:    %s\n", health)
+	fmt.Printf("State:     %s\n", r.State)
+	if r.OverlayIP != "" {
+		fmt.Printf("Overlay:   %s\n", r.OverlayIP)
+	}
+	if r.Interface != "" {
+		fmt.Printf("Interface: %s\n", r.Interface)
+	}
+	if r.Uptime > 0 {
+		fmt.Printf("Uptime:    %s\n", (time.Duration(r.Uptime) * time.Second).String())
+		fmt.Printf("Traffic:   %d B sent, %d B received\n", r.TxBytes, r.RxBytes)
+	}
+	if r.ExitNode != "" {
+		fmt.Printf("Exit node: %s\n", r.ExitNode)
+	}
+
+	if len(r.Peers) > 0 {
+		fmt.Printf("\nPeers:\n")
+		for _, p := range r.Peers {
+			handshake := "never"
+			if p.LastHandshake != nil {
+				handshake = time.Since(*p.LastHandshake).Truncate(time.Second).String() + " ago"
+			}
+			fmt.Printf("  %-15s  %-24s  handshake %-10s  %d B sent, %d B received\n", p.OverlayIP, p.Name, handshake, p.TxBytes, p.RxBytes)
+		}
+	}
+	if len(r.Clusters) > 0 {
+		fmt.Printf("\nClusters:\n")
+		for _, c := range r.Clusters {
+			exit := ""
+			if c.ExitRouter {
+				exit = "  exit router"
+			}
+			fmt.Printf("  %-24s  %-12s%s\n", c.Name, c.Status, exit)
+		}
+	}
+	if len(r.Warnings) > 0 {
+		fmt.Println()
+		for _, w := range r.Warnings {
+			fmt.Println(style.Warning.Render("! " + w))
+		}
+	}
+}
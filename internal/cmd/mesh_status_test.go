@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prysmsh/cli/internal/meshd"
+)
+
+func TestBuildMeshStatusReport(t *testing.T) {
+	now := time.Unix(1760000000, 0)
+	fresh := now.Add(-time.Minute).Unix()
+	stale := now.Add(-10 * time.Minute).Unix()
+
+	tests := []struct {
+		name     string
+		st       *meshd.Response
+		health   string
+		warnings int
+	}{
+		{"daemon not running", nil, "down", 1},
+		{"disconnected", &meshd.Response{Status: "disconnected"}, "down", 0},
+		{"reconnecting", &meshd.Response{Status: "reconnecting"}, "degraded", 1},
+		{"connected without peers", &meshd.Response{Status: "connected"}, "healthy", 1},
+		{"connected", &meshd.Response{Status: "connected", Peers: []meshd.PeerInfo{
+			{Name: "a", OverlayIP: "100.96.0.2", LastHandshake: fresh},
+		}}, "healthy", 0},
+		{"stale peer", &meshd.Response{Status: "connected", Peers: []meshd.PeerInfo{
+			{Name: "a", OverlayIP: "100.96.0.2", LastHandshake: fresh},
+			{Name: "b", OverlayIP: "100.96.0.3", LastHandshake: stale},
+		}}, "degraded", 1},
+		{"peer without handshake", &meshd.Response{Status: "connected", Peers: []meshd.PeerInfo{
+			{Name: "a", OverlayIP: "100.96.0.2"},
+		}}, "degraded", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := buildMeshStatusReport(tt.st, now)
+			if r.Health != tt.health || len(r.Warnings) != tt.warnings {
+				t.Errorf("health = %q, warnings = %q; want %q with %d warnings", r.Health, r.Warnings, tt.health, tt.warnings)
+			}
+		})
+	}
+}
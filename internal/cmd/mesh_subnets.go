@@ -0,0 +1,267 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/prysmsh/cli/internal/api"
+	"github.com/prysmsh/cli/internal/derp"
+	"github.com/prysmsh/cli/internal/meshd"
+	"github.com/prysmsh/cli/internal/style"
+	"github.com/prysmsh/cli/internal/ui"
+)
+
+type meshSubnetRow struct {
+	ID         int64  `json:"id"`
+	CIDR       string `json:"cidr"`
+	Gateway    string `json:"gateway"`
+	Status     string `json:"status"`
+	Accepted   bool   `json:"accepted"`
+	ThisDevice bool   `json:"this_device"`
+}
+
+func newMeshRoutesSubnetsCommand() *cobra.Command {
+	var outputFormat string
+
+	cmd := &cobra.Command{
+		Use:   "subnets",
+		Short: "List subnet routes advertised by mesh gateways",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app := MustApp()
+			ctx, cancel := context.WithTimeout(cmd.Context(), 20*time.Second)
+			defer cancel()
+
+			routes, err := app.API.ListSubnetRoutes(ctx)
+			if err != nil {
+				return err
+			}
+			deviceID, _ := derp.EnsureDeviceID(app.Config.HomeDir)
+			var accepted []int64
+			if meshd.IsRunning() {
+				if st, err := meshd.GetStatus(); err == nil {
+					accepted = st.SubnetRoutes
+				}
+			}
+			rows := meshSubnetRows(routes, accepted, deviceID)
+
+			if wantsJSONOutput(outputFormat) {
+				return writeJSON(rows)
+			}
+			if len(rows) == 0 {
+				fmt.Println(style.MutedStyle.Render("No subnet routes. Advertise one from a gateway with `prysm mesh routes advertise <cidr>`."))
+				return nil
+			}
+			data := make([][]string, len(rows))
+			for i, r := range rows {
+				gateway := r.Gateway
+				if r.ThisDevice {
+					gateway += " (this device)"
+				}
+				mark := ""
+				if r.Accepted {
+					mark = "*"
+				}
+				data[i] = []string{strconv.FormatInt(r.ID, 10), r.CIDR, gateway, r.Status, mark}
+			}
+			ui.PrintTable([]string{"ID", "CIDR", "GATEWAY", "STATUS", "ACCEPTED"}, data)
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "", "output format (table, json)")
+	return cmd
+}
+
+func meshSubnetRows(routes []api.SubnetRoute, accepted []int64, deviceID string) []meshSubnetRow {
+	rows := make([]meshSubnetRow, 0, len(routes))
+	for _, r := range routes {
+		rows = append(rows, meshSubnetRow{
+			ID:         r.ID,
+			CIDR:       r.CIDR,
+			Gateway:    r.DeviceID,
+			Status:     r.Status,
+			Accepted:   slices.Contains(accepted, r.ID),
+			ThisDevice: r.DeviceID == deviceID,
+		})
+	}
+	return rows
+}
+
+func newMeshRoutesAdvertiseCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "advertise <cidr>",
+		Short: "Expose a LAN or VPC subnet to the mesh through this device",
+		Long: `Register a subnet as reachable through this device and forward mesh traffic to
+it. Clients opt in with ` + "`prysm mesh routes accept <route-id>`" + `.
+
+Forwarding is configured by the mesh daemon (Linux only): it enables IP
+forwarding and NATs mesh traffic to the subnet, so hosts on it need no return
+route.`,
+		Example: `  prysm mesh routes advertise 10.1.0.0/16`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cidr, err := parseSubnetCIDR(args[0])
+			if err != nil {
+				return err
+			}
+
+			app := MustApp()
+			ctx, cancel := context.WithTimeout(cmd.Context(), 20*time.Second)
+			defer cancel()
+			deviceID, err := derp.EnsureDeviceID(app.Config.HomeDir)
+			if err != nil {
+				return fmt.Errorf("ensure device id: %w", err)
+			}
+
+			route, err := app.API.AdvertiseSubnetRoute(ctx, deviceID, cidr)
+			if err != nil {
+				return fmt.Errorf("advertise subnet route: %w", err)
+			}
+			fmt.Println(style.Success.Render(fmt.Sprintf("✓ Subnet route %d: %s via %s", route.ID, route.CIDR, deviceID)))
+			syncSubnetRoutesWithDaemon()
+			fmt.Printf("Clients can use it with: prysm mesh routes accept %d\n", route.ID)
+			return nil
+		},
+	}
+}
+
+func newMeshRoutesWithdrawCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "withdraw <route-id|cidr>",
+		Short: "Stop advertising a subnet route",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app := MustApp()
+			ctx, cancel := context.WithTimeout(cmd.Context(), 20*time.Second)
+			defer cancel()
+
+			routes, err := app.API.ListSubnetRoutes(ctx)
+			if err != nil {
+				return err
+			}
+			route, err := findSubnetRoute(routes, args[0])
+			if err != nil {
+				return err
+			}
+			if err := app.API.DeleteSubnetRoute(ctx, route.ID); err != nil {
+				return fmt.Errorf("withdraw subnet route: %w", err)
+			}
+			fmt.Println(style.Success.Render(fmt.Sprintf("✓ Subnet route %d (%s) withdrawn", route.ID, route.CIDR)))
+			if deviceID, _ := derp.EnsureDeviceID(app.Config.HomeDir); route.DeviceID == deviceID {
+				syncSubnetRoutesWithDaemon()
+			}
+			return nil
+		},
+	}
+}
+
+func newMeshRoutesAcceptCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "accept <route-id>",
+		Short: "Route an advertised subnet through its gateway",
+		Long: `Route traffic for an advertised subnet through the gateway that advertises it.
+Acceptance is stored by the mesh daemon and survives restarts; undo it with
+` + "`prysm mesh routes reject <route-id>`" + `. See ` + "`prysm mesh routes subnets`" + ` for route IDs.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setSubnetRouteAccepted(args[0], true)
+		},
+	}
+}
+
+func newMeshRoutesRejectCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "reject <route-id>",
+		Short: "Stop routing an accepted subnet",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setSubnetRouteAccepted(args[0], false)
+		},
+	}
+}
+
+func setSubnetRouteAccepted(ref string, accept bool) error {
+	id, err := strconv.ParseInt(ref, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid route id: %w", err)
+	}
+	if !meshd.IsRunning() {
+		return fmt.Errorf("subnet routes are managed by the mesh daemon — start it with `sudo prysm daemon install` and run `prysm mesh connect`")
+	}
+
+	var resp *meshd.Response
+	if accept {
+		resp, err = meshd.AcceptSubnetRoute(id)
+	} else {
+		resp, err = meshd.RejectSubnetRoute(id)
+	}
+	if err != nil {
+		return fmt.Errorf("query daemon: %w", err)
+	}
+	if resp.Error != "" {
+		return fmt.Errorf("meshd: %s", resp.Error)
+	}
+	if accept {
+		fmt.Println(style.Success.Render(fmt.Sprintf("✓ Subnet route %d accepted", id)))
+	} else {
+		fmt.Println(style.Success.Render(fmt.Sprintf("✓ Subnet route %d rejected", id)))
+	}
+	return nil
+}
+
+// syncSubnetRoutesWithDaemon has the local daemon apply gateway forwarding
+// for this device's subnet routes now instead of at its next peer sync.
+func syncSubnetRoutesWithDaemon() {
+	if !meshd.IsRunning() {
+		fmt.Println(style.Warning.Render("Mesh daemon is not running; forwarding starts once it connects (`sudo prysm daemon install`)."))
+		return
+	}
+	resp, err := meshd.SyncSubnetRoutes()
+	switch {
+	case err != nil:
+		fmt.Println(style.Warning.Render(fmt.Sprintf("Could not reach the mesh daemon: %v", err)))
+	case resp.Error != "":
+		fmt.Println(style.Warning.Render(fmt.Sprintf("Forwarding not configured: %s", resp.Error)))
+	case len(resp.Advertised) > 0:
+		fmt.Println(style.MutedStyle.Render(fmt.Sprintf("Forwarding mesh traffic to %v", resp.Advertised)))
+	}
+}
+
+// parseSubnetCIDR validates an IPv4 subnet and returns it in canonical form.
+func parseSubnetCIDR(s string) (string, error) {
+	_, ipnet, err := net.ParseCIDR(s)
+	if err != nil {
+		return "", fmt.Errorf("invalid CIDR %q: %w", s, err)
+	}
+	if ipnet.IP.To4() == nil {
+		return "", fmt.Errorf("only IPv4 subnets are supported: %s", s)
+	}
+	if ones, _ := ipnet.Mask.Size(); ones == 0 {
+		return "", fmt.Errorf("%s covers all traffic — use `prysm mesh exit` for a default route", s)
+	}
+	return ipnet.String(), nil
+}
+
+// findSubnetRoute finds a subnet route by ID or CIDR.
+func findSubnetRoute(routes []api.SubnetRoute, ref string) (api.SubnetRoute, error) {
+	if id, err := strconv.ParseInt(ref, 10, 64); err == nil {
+		for _, r := range routes {
+			if r.ID == id {
+				return r, nil
+			}
+		}
+	} else if cidr, err := parseSubnetCIDR(ref); err == nil {
+		for _, r := range routes {
+			if r.CIDR == cidr {
+				return r, nil
+			}
+		}
+	}
+	return api.SubnetRoute{}, fmt.Errorf("no subnet route %q — see `prysm mesh routes subnets`", ref)
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prysmsh/cli/internal/api"
+)
+
+func TestParseSubnetCIDR(t *testing.T) {
+	tests := []struct {
+		in, want, err string
+	}{
+		{in: "10.1.0.0/16", want: "10.1.0.0/16"},
+		{in: "10.1.2.3/16", want: "10.1.0.0/16"},
+		{in: "192.168.1.0/24", want: "192.168.1.0/24"},
+		{in: "0.0.0.0/0", err: "prysm mesh exit"},
+		{in: "fd00::/64", err: "only IPv4"},
+		{in: "10.1.0.0", err: "invalid CIDR"},
+	}
+	for _, tt := range tests {
+		got, err := parseSubnetCIDR(tt.in)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("parseSubnetCIDR(%q) err = %v, want %q", tt.in, err, tt.err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("parseSubnetCIDR(%q) = %q, %v; want %q", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestFindSubnetRoute(t *testing.T) {
+	routes := []api.SubnetRoute{{ID: 4, CIDR: "10.1.0.0/16"}, {ID: 7, CIDR: "192.168.1.0/24"}}
+	for ref, want := range map[string]int64{"7": 7, "10.1.0.0/16": 4, "10.1.5.0/16": 4} {
+		r, err := findSubnetRoute(routes, ref)
+		if err != nil || r.ID != want {
+			t.Errorf("findSubnetRoute(%q) = %d, %v; want %d", ref, r.ID, err, want)
+		}
+	}
+	if _, err := findSubnetRoute(routes, "9"); err == nil {
+		t.Error("findSubnetRoute(9): expected error")
+	}
+}
+
+func TestMeshSubnetRows(t *testing.T) {
+	routes := []api.SubnetRoute{
+		{ID: 1, CIDR: "10.1.0.0/16", DeviceID: "gw-a"},
+		{ID: 2, CIDR: "10.2.0.0/16", DeviceID: "me"},
+	}
+	rows := meshSubnetRows(routes, []int64{1}, "me")
+	if !rows[0].Accepted || rows[0].ThisDevice {
+		t.Errorf("row 0 = %+v", rows[0])
+	}
+	if rows[1].Accepted || !rows[1].ThisDevice {
+		t.Errorf("row 1 = %+v", rows[1])
+	}
+}
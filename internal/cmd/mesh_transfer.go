@@ -0,0 +1,399 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/prysmsh/cli/internal/api"
+	"github.com/prysmsh/cli/internal/derp"
+	"github.com/prysmsh/cli/internal/mesh"
+	"github.com/prysmsh/cli/internal/meshd"
+	"github.com/prysmsh/cli/internal/meshdns"
+	"github.com/prysmsh/cli/internal/style"
+	"github.com/prysmsh/cli/internal/util"
+)
+
+// meshTransferIdle aborts a transfer whose peer stops sending or reading;
+// the receiver keeps the partial file so the next send resumes.
+const meshTransferIdle = 60 * time.Second
+
+func newMeshSendCommand() *cobra.Command {
+	var (
+		port    int
+		timeout time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "send <file> <peer>",
+		Short: "Send a file to a mesh peer running `prysm mesh receive`",
+		Long: `Send a file to a mesh peer over the WireGuard overlay (relayed through DERP).
+The peer must be running ` + "`prysm mesh receive`" + `, and this device needs a running
+mesh tunnel.
+
+The peer can be a device ID, a <device>.` + meshdns.Suffix + ` name, a cluster name or an
+overlay IP. The receiver verifies the file's SHA-256 before saving it; if a
+transfer is interrupted, sending the same file again resumes where it stopped.`,
+		Example: `  prysm mesh send ./build.tar.gz laptop-2.mesh.prysm
+  prysm mesh send dump.sql db-host --port 41700`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, ref := args[0], args[1]
+			st, err := os.Stat(path)
+			if err != nil {
+				return err
+			}
+			if !st.Mode().IsRegular() {
+				return fmt.Errorf("%s is not a regular file", path)
+			}
+
+			app := MustApp()
+			ctx, cancel := context.WithTimeout(cmd.Context(), 20*time.Second)
+			nodes, err := app.API.ListMeshNodes(ctx)
+			if err != nil {
+				cancel()
+				return err
+			}
+			clusters, _ := app.API.ListClusters(ctx)
+			cancel()
+
+			target, err := resolveMeshPingTarget(nodes, clusters, ref)
+			if err != nil {
+				return err
+			}
+			if target.OverlayIP == nil {
+				return fmt.Errorf("%s has no overlay address — is it connected to the mesh?", target.Name)
+			}
+
+			addr := net.JoinHostPort(target.OverlayIP.String(), strconv.Itoa(port))
+			dialer := net.Dialer{Timeout: timeout}
+			conn, err := dialer.DialContext(cmd.Context(), "tcp", addr)
+			if err != nil {
+				return fmt.Errorf("connect to %s (%s): %w — is `prysm mesh receive` running there and your mesh tunnel up?", target.Name, addr, err)
+			}
+			defer conn.Close()
+
+			fmt.Printf("Sending %s (%s) to %s\n", st.Name(), formatTransferSize(st.Size()), target.Name)
+			var progress func(int64)
+			tty := term.IsTerminal(int(os.Stderr.Fd()))
+			if tty {
+				progress = meshTransferProgress(st.Size())
+			}
+			res, err := mesh.SendFile(&idleConn{Conn: conn}, path, progress)
+			if tty {
+				fmt.Fprint(os.Stderr, "\r\033[K")
+			}
+			if err != nil {
+				return err
+			}
+			msg := fmt.Sprintf("✓ Sent %s to %s:%s", res.Name, target.Name, res.Path)
+			if res.Resumed > 0 {
+				msg += fmt.Sprintf(" (resumed at %s)", formatTransferSize(res.Resumed))
+			}
+			fmt.Println(style.Success.Render(msg))
+			fmt.Println(style.MutedStyle.Render("sha256 " + res.SHA256))
+			return nil
+		},
+	}
+	cmd.Flags().IntVar(&port, "port", mesh.DefaultTransferPort, "port the receiver listens on")
+	cmd.Flags().DurationVar(&timeout, "timeout", 10*time.Second, "time to wait for the receiver to accept the connection")
+	return cmd
+}
+
+func newMeshReceiveCommand() *cobra.Command {
+	var (
+		dir       string
+		port      int
+		from      []string
+		yes       bool
+		maxSize   string
+		once      bool
+		overwrite bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "receive",
+		Short: "Accept files sent with `prysm mesh send`",
+		Long: `Listen on this device's overlay address for files sent by mesh peers with
+` + "`prysm mesh send`" + ` and save them to a directory.
+
+Only registered mesh peers can connect; WireGuard authenticates their overlay
+addresses. Files from peers given with --from are accepted directly; others
+are confirmed one by one, or accepted from any peer with --yes. Files larger
+than --max-size or the free disk space are refused before any data is sent.
+
+Incomplete transfers are kept as hidden .part files and resume when the
+sender retries; ones untouched for a day are removed.`,
+		Example: `  prysm mesh receive --dir ~/Downloads
+  prysm mesh receive --from laptop-1.mesh.prysm --from ci-runner --once
+  prysm mesh receive --yes --max-size 2GiB`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if st, err := os.Stat(dir); err != nil {
+				return err
+			} else if !st.IsDir() {
+				return fmt.Errorf("%s is not a directory", dir)
+			}
+			limit, err := parseTransferSize(maxSize)
+			if err != nil {
+				return err
+			}
+			interactive := term.IsTerminal(int(os.Stdin.Fd()))
+			if len(from) == 0 && !yes && !interactive {
+				return errors.New("name the senders with --from, or pass --yes to accept files from any mesh peer")
+			}
+
+			app := MustApp()
+			ctx, cancel := context.WithCancel(cmd.Context())
+			defer cancel()
+
+			listCtx, listCancel := context.WithTimeout(ctx, 20*time.Second)
+			nodes, err := app.API.ListMeshNodes(listCtx)
+			if err != nil {
+				listCancel()
+				return err
+			}
+			clusters, _ := app.API.ListClusters(listCtx)
+			listCancel()
+
+			allowed := make(map[string]bool, len(from))
+			for _, ref := range from {
+				t, err := resolveMeshPingTarget(nodes, clusters, ref)
+				if err != nil {
+					return err
+				}
+				allowed[t.DeviceID] = true
+			}
+
+			overlayIP, err := meshTransferListenIP(app, nodes)
+			if err != nil {
+				return err
+			}
+			addr := net.JoinHostPort(overlayIP, strconv.Itoa(port))
+			ln, err := net.Listen("tcp", addr)
+			if err != nil {
+				return fmt.Errorf("listen on %s: %w — is the mesh tunnel up (`prysm mesh connect`)?", addr, err)
+			}
+			defer ln.Close()
+
+			sigCh := make(chan os.Signal, 1)
+			signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+			defer signal.Stop(sigCh)
+			done := make(chan struct{})
+			var doneOnce sync.Once
+			go func() {
+				select {
+				case <-sigCh:
+				case <-ctx.Done():
+				case <-done:
+				}
+				ln.Close()
+			}()
+
+			var (
+				nodesMu  sync.Mutex // guards nodes, refreshed for unknown peers
+				promptMu sync.Mutex // one confirmation prompt at a time
+				wg       sync.WaitGroup
+			)
+			handle := func(conn net.Conn) {
+				defer conn.Close()
+				nodesMu.Lock()
+				peer, ok := meshTransferPeer(ctx, app, &nodes, conn.RemoteAddr())
+				nodesMu.Unlock()
+				if !ok || (len(allowed) > 0 && !allowed[peer]) {
+					printDebug("mesh receive: rejected connection from %s", conn.RemoteAddr())
+					return
+				}
+				if n, err := mesh.CleanStaleParts(dir, mesh.PartFileMaxAge); err != nil {
+					printDebug("mesh receive: clean part files: %v", err)
+				} else if n > 0 {
+					printDebug("mesh receive: removed %d stale part file(s)", n)
+				}
+
+				opts := mesh.ReceiveOptions{Overwrite: overwrite, MaxSize: limit}
+				if !allowed[peer] && !yes {
+					opts.Accept = func(h mesh.TransferHeader) error {
+						promptMu.Lock()
+						defer promptMu.Unlock()
+						ok, err := util.PromptConfirm(fmt.Sprintf("Accept %s (%s) from %s?", h.Name, formatTransferSize(h.Size), peer), false)
+						if err != nil || !ok {
+							return errors.New("declined by the receiver")
+						}
+						return nil
+					}
+				}
+				res, err := mesh.ReceiveFile(&idleConn{Conn: conn}, dir, opts)
+				if err != nil {
+					fmt.Fprintln(os.Stderr, style.Error.Render(fmt.Sprintf("✗ from %s: %v", peer, err)))
+					return
+				}
+				msg := fmt.Sprintf("✓ Received %s (%s) from %s", res.Path, formatTransferSize(res.Size), peer)
+				if res.Resumed > 0 {
+					msg += fmt.Sprintf(", resumed at %s", formatTransferSize(res.Resumed))
+				}
+				fmt.Println(style.Success.Render(msg))
+				if once {
+					doneOnce.Do(func() { close(done) })
+				}
+			}
+
+			fmt.Printf("Receiving files into %s on %s · Ctrl+C to stop\n", dir, addr)
+			for {
+				conn, err := ln.Accept()
+				if err != nil {
+					wg.Wait()
+					if errors.Is(err, net.ErrClosed) {
+						return nil
+					}
+					return err
+				}
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					handle(conn)
+				}()
+			}
+		},
+	}
+	cmd.Flags().StringVar(&dir, "dir", ".", "directory to save received files in")
+	cmd.Flags().IntVar(&port, "port", mesh.DefaultTransferPort, "port to listen on")
+	cmd.Flags().StringArrayVar(&from, "from", nil, "accept files from this peer without asking (repeatable)")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "accept files from any mesh peer without asking")
+	cmd.Flags().StringVar(&maxSize, "max-size", "", "largest file to accept, e.g. 500MiB or 2GiB (default: free disk space)")
+	cmd.Flags().BoolVar(&once, "once", false, "exit after receiving one file")
+	cmd.Flags().BoolVar(&overwrite, "overwrite", false, "replace existing files with the same name")
+	return cmd
+}
+
+// parseTransferSize parses a byte count with an optional KiB/MiB/GiB/TiB
+// suffix (K, M, G and T, with or without "B", mean the same). An empty
+// string is no limit.
+func parseTransferSize(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	num := strings.TrimRight(strings.ToUpper(s), "IB")
+	shift := 0
+	if n := len(num); n > 0 {
+		if i := strings.IndexByte("KMGT", num[n-1]); i >= 0 {
+			shift = 10 * (i + 1)
+			num = num[:n-1]
+		}
+	}
+	n, err := strconv.ParseInt(strings.TrimSpace(num), 10, 64)
+	if err != nil || n <= 0 || n > math.MaxInt64>>shift {
+		return 0, fmt.Errorf("invalid size %q (use e.g. 500MiB or 2GiB)", s)
+	}
+	return n << shift, nil
+}
+
+// meshTransferListenIP returns this device's overlay address, preferring the
+// running daemon's view over the control plane's.
+func meshTransferListenIP(app *App, nodes []api.MeshNode) (string, error) {
+	if meshd.IsRunning() {
+		if st, err := meshd.GetStatus(); err == nil && st.OverlayIP != "" {
+			return st.OverlayIP, nil
+		}
+	}
+	deviceID, err := derp.EnsureDeviceID(app.Config.HomeDir)
+	if err != nil {
+		return "", fmt.Errorf("ensure device id: %w", err)
+	}
+	for _, n := range nodes {
+		if n.DeviceID == deviceID && n.WGAddress != "" {
+			addr, _, _ := strings.Cut(n.WGAddress, "/")
+			return addr, nil
+		}
+	}
+	return "", fmt.Errorf("this device has no overlay address — connect with `prysm mesh connect` first")
+}
+
+// meshTransferPeer maps a connection's source address to the mesh device
+// that owns it, refreshing the node list once for peers that joined after
+// the receiver started.
+func meshTransferPeer(ctx context.Context, app *App, nodes *[]api.MeshNode, remote net.Addr) (string, bool) {
+	tcp, ok := remote.(*net.TCPAddr)
+	if !ok {
+		return "", false
+	}
+	lookup := func() (string, bool) {
+		for _, n := range *nodes {
+			addr, _, _ := strings.Cut(n.WGAddress, "/")
+			if ip := net.ParseIP(addr); ip != nil && ip.Equal(tcp.IP) {
+				return n.DeviceID, true
+			}
+		}
+		return "", false
+	}
+	if id, ok := lookup(); ok {
+		return id, true
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	fresh, err := app.API.ListMeshNodes(ctx)
+	if err != nil {
+		printDebug("mesh receive: refresh peers: %v", err)
+		return "", false
+	}
+	*nodes = fresh
+	return lookup()
+}
+
+// idleConn extends the connection's deadline on every read and write, so
+// large transfers are not cut off while a stalled peer still is.
+type idleConn struct {
+	net.Conn
+}
+
+func (c *idleConn) Read(b []byte) (int, error) {
+	_ = c.Conn.SetDeadline(time.Now().Add(meshTransferIdle))
+	return c.Conn.Read(b)
+}
+
+func (c *idleConn) Write(b []byte) (int, error) {
+	_ = c.Conn.SetDeadline(time.Now().Add(meshTransferIdle))
+	return c.Conn.Write(b)
+}
+
+// meshTransferProgress returns a progress callback that redraws a single
+// status line on stderr, at most a few times per second.
+func meshTransferProgress(total int64) func(int64) {
+	var last time.Time
+	return func(n int64) {
+		if n < total && time.Since(last) < 200*time.Millisecond {
+			return
+		}
+		last = time.Now()
+		pct := 100.0
+		if total > 0 {
+			pct = float64(n) * 100 / float64(total)
+		}
+		fmt.Fprintf(os.Stderr, "\r  %s / %s (%.0f%%)", formatTransferSize(n), formatTransferSize(total), pct)
+	}
+}
+
+func formatTransferSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/prysmsh/cli/internal/api"
+)
+
+func TestFormatTransferSize(t *testing.T) {
+	tests := map[int64]string{
+		0:         "0 B",
+		1023:      "1023 B",
+		1024:      "1.0 KiB",
+		1536:      "1.5 KiB",
+		5 << 20:   "5.0 MiB",
+		3 << 30:   "3.0 GiB",
+		1<<40 + 1: "1.0 TiB",
+	}
+	for n, want := range tests {
+		if got := formatTransferSize(n); got != want {
+			t.Errorf("formatTransferSize(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestParseTransferSize(t *testing.T) {
+	tests := map[string]int64{
+		"":       0,
+		"1000":   1000,
+		"500MiB": 500 << 20,
+		"2G":     2 << 30,
+		"4kb":    4 << 10,
+		"1TiB":   1 << 40,
+	}
+	for in, want := range tests {
+		if got, err := parseTransferSize(in); err != nil || got != want {
+			t.Errorf("parseTransferSize(%q) = %d, %v; want %d", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"abc", "-5", "0", "10PiB", "99999999999T"} {
+		if _, err := parseTransferSize(in); err == nil {
+			t.Errorf("parseTransferSize(%q): expected error", in)
+		}
+	}
+}
+
+func TestMeshTransferPeer(t *testing.T) {
+	nodes := []api.MeshNode{
+		{DeviceID: "laptop-1", WGAddress: "100.96.0.7/32"},
+		{DeviceID: "laptop-2", WGAddress: "100.96.0.70/32"},
+	}
+	id, ok := meshTransferPeer(context.Background(), nil, &nodes, &net.TCPAddr{IP: net.ParseIP("100.96.0.70"), Port: 51000})
+	if !ok || id != "laptop-2" {
+		t.Errorf("got %q, %v; want laptop-2", id, ok)
+	}
+	if _, ok := meshTransferPeer(context.Background(), nil, &nodes, &net.UnixAddr{Name: "/tmp/x"}); ok {
+		t.Error("non-TCP address should be rejected")
+	}
+}
@@ -2,9 +2,17 @@
 
 package cmd
 
-import "syscall"
+import (
+	"errors"
+	"syscall"
+)
 
 // setSysProcAttrSetsid sets the Setsid field on Unix systems.
 func setSysProcAttrSetsid(attr *syscall.SysProcAttr) {
 	attr.Setsid = true
 }
+
+// isConnRefused reports whether err is a refused TCP connection.
+func isConnRefused(err error) bool {
+	return errors.Is(err, syscall.ECONNREFUSED)
+}
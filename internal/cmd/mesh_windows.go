@@ -2,9 +2,20 @@
 
 package cmd
 
-import "syscall"
+import (
+	"errors"
+	"syscall"
+
+	"golang.org/x/sys/windows"
+)
 
 // setSysProcAttrSetsid is a no-op on Windows (Setsid doesn't exist).
 func setSysProcAttrSetsid(attr *syscall.SysProcAttr) {
 	// Setsid is not available on Windows
 }
+
+// isConnRefused reports whether err is a refused TCP connection. Winsock
+// reports WSAECONNREFUSED, not syscall.ECONNREFUSED.
+func isConnRefused(err error) bool {
+	return errors.Is(err, windows.WSAECONNREFUSED) || errors.Is(err, syscall.ECONNREFUSED)
+}
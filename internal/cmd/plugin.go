@@ -0,0 +1,584 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/prysmsh/cli/internal/plugin"
+	"github.com/prysmsh/cli/internal/session"
+	"github.com/prysmsh/cli/internal/style"
+	"github.com/prysmsh/cli/internal/ui"
+)
+
+func newPluginCommand() *cobra.Command {
+	pluginCmd := &cobra.Command{
+		Use:     "plugin",
+		Aliases: []string{"plugins"},
+		Short:   "Manage CLI plugins",
+	}
+
+	pluginCmd.AddCommand(
+		newPluginListCommand(),
+		newPluginSearchCommand(),
+		newPluginInstallCommand(),
+		newPluginUpgradeCommand(),
+		newPluginRemoveCommand(),
+		newPluginLockCommand(),
+	)
+
+	return pluginCmd
+}
+
+func newPluginListCommand() *cobra.Command {
+	var outputFormat string
+	var outdated bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List builtin and external plugins",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app := MustApp()
+			records, err := plugin.LoadInstallRecords(app.Config.HomeDir)
+			if err != nil {
+				return err
+			}
+
+			if outdated {
+				ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+				defer cancel()
+				return printOutdatedPlugins(ctx, app, records, outputFormat)
+			}
+
+			plugins := pluginMgr.ListPlugins()
+			for i, p := range plugins {
+				if rec, ok := records[p.Name]; ok && p.Version == "" {
+					plugins[i].Version = rec.Version
+				}
+			}
+
+			if wantsJSONOutput(outputFormat) {
+				return writeJSON(plugins)
+			}
+
+			if len(plugins) == 0 {
+				fmt.Println(style.Warning.Render("No plugins installed. Use `prysm plugin install <name>` to add one."))
+				return nil
+			}
+
+			headers := []string{"NAME", "TYPE", "VERSION", "SOURCE"}
+			rows := make([][]string, 0, len(plugins))
+			for _, p := range plugins {
+				source := p.Path
+				if rec, ok := records[p.Name]; ok {
+					source = rec.Source
+				}
+				if source == "" {
+					source = "-"
+				}
+				ver := p.Version
+				if ver == "" {
+					ver = "-"
+				}
+				rows = append(rows, []string{p.Name, p.Type, ver, source})
+			}
+			ui.PrintTable(headers, rows)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "", "output format (table, json)")
+	cmd.Flags().BoolVar(&outdated, "outdated", false, "only show plugins with a newer version in the registry")
+	return cmd
+}
+
+func printOutdatedPlugins(ctx context.Context, app *App, records map[string]plugin.InstallRecord, outputFormat string) error {
+	installer := newPluginInstaller(app)
+	var idx *plugin.RegistryIndex
+	if err := ui.WithSpinner("Checking plugin registry...", func() error {
+		var fetchErr error
+		idx, fetchErr = installer.FetchIndex(ctx)
+		return fetchErr
+	}); err != nil {
+		return err
+	}
+
+	updates := findOutdatedPlugins(installedPluginVersions(records), idx)
+	if wantsJSONOutput(outputFormat) {
+		return writeJSON(updates)
+	}
+	if len(updates) == 0 {
+		fmt.Println(style.Success.Render("All plugins are up to date."))
+		return nil
+	}
+
+	headers := []string{"NAME", "INSTALLED", "LATEST"}
+	rows := make([][]string, 0, len(updates))
+	for _, u := range updates {
+		installed := u.Installed
+		if installed == "" {
+			installed = "unknown"
+		}
+		rows = append(rows, []string{u.Name, installed, style.Warning.Render(u.Latest)})
+	}
+	ui.PrintTable(headers, rows)
+	fmt.Println()
+	fmt.Println(style.Info.Render("Run 'prysm plugin upgrade --all' to install."))
+	return nil
+}
+
+func newPluginSearchCommand() *cobra.Command {
+	var outputFormat string
+
+	cmd := &cobra.Command{
+		Use:   "search [query]",
+		Short: "Search the plugin registry",
+		Long: `Search the plugin registry by name, description or publisher. With no query,
+every published plugin is listed.`,
+		Example: `  prysm plugin search terraform
+  prysm plugin search acme -o json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app := MustApp()
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			query := ""
+			if len(args) > 0 {
+				query = args[0]
+			}
+
+			installer := newPluginInstaller(app)
+			var idx *plugin.RegistryIndex
+			if err := ui.WithSpinner("Searching plugin registry...", func() error {
+				var fetchErr error
+				idx, fetchErr = installer.FetchIndex(ctx)
+				return fetchErr
+			}); err != nil {
+				return err
+			}
+
+			results := idx.Search(query)
+			if wantsJSONOutput(outputFormat) {
+				return writeJSON(results)
+			}
+			if len(results) == 0 {
+				fmt.Println(style.Warning.Render(fmt.Sprintf("No plugins match %q.", query)))
+				return nil
+			}
+
+			headers := []string{"NAME", "VERSION", "PUBLISHER", "DESCRIPTION", "INSTALL"}
+			rows := make([][]string, 0, len(results))
+			for _, e := range results {
+				install := "prysm plugin install " + e.Name
+				if !e.Supports(installer.GOOS, installer.GOARCH) {
+					install = style.MutedStyle.Render(fmt.Sprintf("not available for %s/%s", installer.GOOS, installer.GOARCH))
+				}
+				publisher := e.Publisher
+				if publisher == "" {
+					publisher = "-"
+				}
+				rows = append(rows, []string{e.Name, e.Version, publisher, truncate(e.Description, 50), install})
+			}
+			ui.PrintTable(headers, rows)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "", "output format (table, json)")
+	return cmd
+}
+
+func newPluginInstallCommand() *cobra.Command {
+	var opts plugin.InstallOptions
+
+	cmd := &cobra.Command{
+		Use:   "install <name|https://...|oci://...>",
+		Short: "Install an external plugin",
+		Long: `Install an external plugin into $PRYSM_HOME/plugins.
+
+The source can be a plugin name from the registry, a direct https:// download
+(raw binary, .tar.gz or .zip), or an oci:// artifact reference. Downloads are
+checksum-verified before they are installed: registry entries carry their own
+SHA-256, URL downloads use --sha256 or a "<url>.sha256" file, and OCI blobs are
+verified against their digest.
+
+The plugin binary must also carry a minisign signature from a key listed in
+plugin_trusted_keys (config.yaml); pass --allow-unsigned to install and run
+unsigned plugins.`,
+		Example: `  prysm plugin install terraform
+  prysm plugin install https://example.com/prysm-plugin-foo_linux_amd64.tar.gz --sha256 <hex>
+  prysm plugin install oci://ghcr.io/acme/prysm-plugin-bar:1.2.0`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app := MustApp()
+			ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Minute)
+			defer cancel()
+
+			installer := newPluginInstaller(app)
+
+			var rec *plugin.InstallRecord
+			if err := ui.WithSpinner(fmt.Sprintf("Installing %s...", args[0]), func() error {
+				var installErr error
+				rec, installErr = installer.Install(ctx, args[0], opts)
+				return installErr
+			}); err != nil {
+				return err
+			}
+
+			if isBuiltinCommand(cmd.Root(), rec.Name) {
+				fmt.Fprintln(os.Stderr, style.Warning.Render(fmt.Sprintf("Plugin %q is shadowed by the builtin `prysm %s` command and will not be reachable.", rec.Name, rec.Name)))
+			} else {
+				refreshPluginManifest(rec.Name)
+			}
+
+			label := rec.Name
+			if rec.Version != "" {
+				label += " v" + rec.Version
+			}
+			fmt.Println(style.Success.Render(fmt.Sprintf("Installed %s (sha256 %s).", label, rec.SHA256)))
+			fmt.Println(style.Info.Render(fmt.Sprintf("Run `prysm %s --help` to get started.", rec.Name)))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Name, "name", "", "install under this plugin name instead of the one derived from the source")
+	cmd.Flags().StringVar(&opts.SHA256, "sha256", "", "expected SHA-256 of the downloaded artifact (URL sources)")
+	cmd.Flags().BoolVar(&opts.Force, "force", false, "overwrite an existing plugin binary")
+	return cmd
+}
+
+func newPluginUpgradeCommand() *cobra.Command {
+	var all bool
+
+	cmd := &cobra.Command{
+		Use:   "upgrade [name]",
+		Short: "Upgrade installed plugins to the latest registry version",
+		Long: `Compare installed plugin versions (as reported by each plugin's manifest)
+against the plugin registry and install newer releases.
+
+Plugins installed from a URL or OCI reference are not tracked by the registry;
+reinstall them with "prysm plugin install --force <source>".`,
+		Example: `  prysm plugin upgrade terraform
+  prysm plugin upgrade --all`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 && !all {
+				return fmt.Errorf("specify a plugin name or --all")
+			}
+			if len(args) > 0 && all {
+				return fmt.Errorf("a plugin name cannot be combined with --all")
+			}
+
+			app := MustApp()
+			ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Minute)
+			defer cancel()
+
+			records, err := plugin.LoadInstallRecords(app.Config.HomeDir)
+			if err != nil {
+				return err
+			}
+			installed := installedPluginVersions(records)
+
+			for name := range installed {
+				rec := records[name]
+				if isRegistrySource(rec.Source) {
+					continue
+				}
+				if len(args) > 0 && args[0] == name {
+					return fmt.Errorf("plugin %q was installed from %s; reinstall with `prysm plugin install --force %s`", name, rec.Source, rec.Source)
+				}
+				fmt.Fprintln(os.Stderr, style.MutedStyle.Render(fmt.Sprintf("Skipping %s (installed from %s).", name, rec.Source)))
+				delete(installed, name)
+			}
+			if len(args) > 0 {
+				v, ok := installed[args[0]]
+				if !ok {
+					return fmt.Errorf("plugin %q is not installed with `prysm plugin install`", args[0])
+				}
+				installed = map[string]string{args[0]: v}
+			}
+
+			installer := newPluginInstaller(app)
+			var idx *plugin.RegistryIndex
+			if err := ui.WithSpinner("Checking plugin registry...", func() error {
+				var fetchErr error
+				idx, fetchErr = installer.FetchIndex(ctx)
+				return fetchErr
+			}); err != nil {
+				return err
+			}
+
+			lock, err := plugin.LoadLock(app.Config.HomeDir)
+			if err != nil {
+				return err
+			}
+			var updates []pluginUpdate
+			for _, u := range findOutdatedPlugins(installed, idx) {
+				if pin, ok := lock.Pinned(u.Name); ok {
+					msg := fmt.Sprintf("Skipping %s (pinned in %s; v%s available).", u.Name, plugin.LockFile, u.Latest)
+					if pin.Version != "" {
+						msg = fmt.Sprintf("Skipping %s (pinned to v%s in %s; v%s available).", u.Name, pin.Version, plugin.LockFile, u.Latest)
+					}
+					fmt.Fprintln(os.Stderr, style.MutedStyle.Render(msg))
+					continue
+				}
+				updates = append(updates, u)
+			}
+			if len(updates) == 0 {
+				if len(args) > 0 {
+					fmt.Println(style.Success.Render(fmt.Sprintf("%s is already up to date (%s).", args[0], pluginVersionLabel(installed[args[0]]))))
+				} else {
+					fmt.Println(style.Success.Render("All plugins are up to date."))
+				}
+				return nil
+			}
+
+			for _, u := range updates {
+				if err := ui.WithSpinner(fmt.Sprintf("Upgrading %s to v%s...", u.Name, u.Latest), func() error {
+					_, installErr := installer.InstallEntry(ctx, u.entry, plugin.InstallOptions{Name: u.Name, Force: true})
+					return installErr
+				}); err != nil {
+					return fmt.Errorf("upgrade %s: %w", u.Name, err)
+				}
+				refreshPluginManifest(u.Name)
+				fmt.Println(style.Success.Render(fmt.Sprintf("Upgraded %s: %s → v%s.", u.Name, pluginVersionLabel(u.Installed), u.Latest)))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "upgrade every outdated plugin")
+	return cmd
+}
+
+func newPluginRemoveCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:     "remove <name>",
+		Aliases: []string{"rm", "uninstall"},
+		Short:   "Remove an installed plugin",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app := MustApp()
+			name := args[0]
+
+			for _, p := range pluginMgr.ListPlugins() {
+				if p.Name != name {
+					continue
+				}
+				if p.Type == "builtin" {
+					return fmt.Errorf("%q is a builtin plugin and cannot be removed", name)
+				}
+				pluginsDir := filepath.Join(app.Config.HomeDir, "plugins")
+				if filepath.Dir(p.Path) != pluginsDir {
+					return fmt.Errorf("plugin %q at %s was not installed by prysm; remove it manually", name, p.Path)
+				}
+			}
+
+			path, err := plugin.Remove(app.Config.HomeDir, name)
+			if err != nil {
+				return err
+			}
+			secrets := session.NewSecretStore(filepath.Join(app.Config.HomeDir, session.SecretsFile))
+			if err := secrets.DeleteNamespace(plugin.SecretNamespace(name)); err != nil {
+				fmt.Fprintln(os.Stderr, style.Warning.Render(fmt.Sprintf("Could not clear secrets for %s: %v", name, err)))
+			}
+
+			if isBuiltinCommand(cmd.Root(), name) {
+				fmt.Fprintln(os.Stderr, style.Warning.Render(fmt.Sprintf("Plugin %q was shadowed by the builtin `prysm %s` command; that command is unaffected.", name, name)))
+			}
+			fmt.Println(style.Success.Render(fmt.Sprintf("Removed %s (%s).", name, path)))
+			return nil
+		},
+	}
+}
+
+func newPluginLockCommand() *cobra.Command {
+	var check bool
+	var outputFormat string
+
+	cmd := &cobra.Command{
+		Use:   "lock",
+		Short: "Pin installed plugins in plugins.lock",
+		Long: `Write $PRYSM_HOME/plugins.lock with the version and SHA-256 of every plugin
+installed in $PRYSM_HOME/plugins. Share the file to give a team the same plugin
+set: install and upgrade refuse other versions, and binaries that no longer match
+their pin are not loaded.
+
+With --check, compare the installed plugins against the lockfile instead and
+exit non-zero on drift.`,
+		Example: `  prysm plugin lock
+  prysm plugin lock --check`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app := MustApp()
+			home := app.Config.HomeDir
+
+			if check {
+				lock, err := plugin.LoadLock(home)
+				if err != nil {
+					return err
+				}
+				if lock == nil {
+					return fmt.Errorf("no %s found; run `prysm plugin lock` first", plugin.LockPath(home))
+				}
+				drift, err := lock.CheckInstalled(home)
+				if err != nil {
+					return err
+				}
+				if wantsJSONOutput(outputFormat) {
+					if err := writeJSON(drift); err != nil {
+						return err
+					}
+				} else if len(drift) == 0 {
+					fmt.Println(style.Success.Render(fmt.Sprintf("Installed plugins match %s.", plugin.LockFile)))
+				} else {
+					rows := make([][]string, 0, len(drift))
+					for _, d := range drift {
+						rows = append(rows, []string{d.Name, style.Warning.Render(d.Problem)})
+					}
+					ui.PrintTable([]string{"NAME", "PROBLEM"}, rows)
+				}
+				if len(drift) > 0 {
+					return fmt.Errorf("%d plugin(s) drift from %s", len(drift), plugin.LockFile)
+				}
+				return nil
+			}
+
+			records, err := plugin.LoadInstallRecords(home)
+			if err != nil {
+				return err
+			}
+			versions := installedPluginVersions(records)
+			lock := &plugin.Lock{Plugins: make(map[string]plugin.LockEntry)}
+			pluginsDir := filepath.Join(home, "plugins")
+			for _, p := range pluginMgr.ListPlugins() {
+				if p.Type != "external" || filepath.Dir(p.Path) != pluginsDir {
+					continue
+				}
+				sum, err := plugin.HashFile(p.Path)
+				if err != nil {
+					return fmt.Errorf("hash %s: %w", p.Path, err)
+				}
+				lock.Plugins[p.Name] = plugin.LockEntry{Version: versions[p.Name], SHA256: sum}
+			}
+			if err := plugin.SaveLock(home, lock); err != nil {
+				return err
+			}
+			fmt.Println(style.Success.Render(fmt.Sprintf("Pinned %d plugin(s) in %s.", len(lock.Plugins), plugin.LockPath(home))))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&check, "check", false, "report drift between installed plugins and plugins.lock")
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "", "output format for --check (table, json)")
+	return cmd
+}
+
+// newPluginInstaller returns an installer bound to the configured registry and
+// signature policy.
+func newPluginInstaller(app *App) *plugin.Installer {
+	installer := plugin.NewInstaller(app.Config.HomeDir, app.Config.PluginRegistry)
+	installer.Signatures = pluginSignaturePolicy()
+	return installer
+}
+
+// pluginUpdate is an installed plugin with a newer release in the registry.
+type pluginUpdate struct {
+	Name      string `json:"name"`
+	Installed string `json:"installed"`
+	Latest    string `json:"latest"`
+
+	entry *plugin.RegistryEntry
+}
+
+// installedPluginVersions returns name → version for external plugins that
+// prysm installed, i.e. that have an install record. Plugins found elsewhere
+// on $PATH are the user's own and are never upgraded. The version reported by
+// the plugin's own manifest wins; the install record is the fallback for
+// plugins that do not report one.
+func installedPluginVersions(records map[string]plugin.InstallRecord) map[string]string {
+	out := make(map[string]string)
+	if pluginMgr == nil {
+		return out
+	}
+	for _, p := range pluginMgr.ListPlugins() {
+		if p.Type != "external" {
+			continue
+		}
+		if _, ok := records[p.Name]; !ok {
+			continue
+		}
+		v, err := pluginMgr.ExternalVersion(p.Name)
+		if err != nil || v == "" {
+			v = records[p.Name].Version
+		}
+		out[p.Name] = strings.TrimPrefix(v, "v")
+	}
+	return out
+}
+
+// refreshPluginManifest caches a freshly installed plugin's manifest, so its
+// hooks take effect without starting it on every command.
+func refreshPluginManifest(name string) {
+	if pluginMgr == nil {
+		return
+	}
+	if err := pluginMgr.RefreshManifest(name); err != nil {
+		fmt.Fprintln(os.Stderr, style.Warning.Render(fmt.Sprintf("Could not read the manifest of plugin %q: %v. Its hooks stay inactive until it runs successfully.", name, err)))
+	}
+}
+
+// findOutdatedPlugins compares installed versions against the registry index.
+// Plugins with an unknown (empty) installed version are reported as outdated
+// so an upgrade pins them to a known release.
+func findOutdatedPlugins(installed map[string]string, idx *plugin.RegistryIndex) []pluginUpdate {
+	var updates []pluginUpdate
+	for name, current := range installed {
+		entry := idx.Find(name)
+		if entry == nil || entry.Version == "" {
+			continue
+		}
+		latest := strings.TrimPrefix(entry.Version, "v")
+		if current != "" {
+			cmp, err := compareSemver(current, latest)
+			if err != nil || cmp >= 0 {
+				continue
+			}
+		}
+		updates = append(updates, pluginUpdate{Name: name, Installed: current, Latest: latest, entry: entry})
+	}
+	sort.Slice(updates, func(i, j int) bool { return updates[i].Name < updates[j].Name })
+	return updates
+}
+
+// pluginVersionLabel formats an installed plugin version for messages.
+func pluginVersionLabel(version string) string {
+	if version == "" {
+		return "unknown version"
+	}
+	return "v" + version
+}
+
+// isRegistrySource reports whether an install record's source is a registry
+// name rather than a URL or OCI reference.
+func isRegistrySource(source string) bool {
+	return !strings.Contains(source, "://")
+}
+
+// isBuiltinCommand reports whether name resolves to a root command that is not
+// an external plugin placeholder (i.e. the plugin would be shadowed).
+func isBuiltinCommand(root *cobra.Command, name string) bool {
+	for _, c := range root.Commands() {
+		if c.Name() == name || c.HasAlias(name) {
+			return pluginMgr == nil || !pluginMgr.OwnsCommand(c)
+		}
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/prysmsh/cli/internal/plugin"
+)
+
+func TestFindOutdatedPlugins(t *testing.T) {
+	idx := &plugin.RegistryIndex{Plugins: []plugin.RegistryEntry{
+		{Name: "terraform", Version: "v1.3.0"},
+		{Name: "vault", Version: "2.0.0"},
+		{Name: "fresh", Version: "0.9.0"},
+		{Name: "unversioned", Version: "1.0.0"},
+	}}
+	installed := map[string]string{
+		"terraform":   "1.2.5",
+		"vault":       "2.0.0",
+		"fresh":       "1.0.0",
+		"unversioned": "",
+		"local-only":  "0.1.0",
+	}
+
+	got := findOutdatedPlugins(installed, idx)
+	if len(got) != 2 {
+		t.Fatalf("findOutdatedPlugins len = %d, want 2: %+v", len(got), got)
+	}
+	if got[0].Name != "terraform" || got[0].Installed != "1.2.5" || got[0].Latest != "1.3.0" {
+		t.Errorf("got[0] = %+v", got[0])
+	}
+	if got[1].Name != "unversioned" || got[1].Installed != "" {
+		t.Errorf("got[1] = %+v", got[1])
+	}
+}
+
+func TestIsRegistrySource(t *testing.T) {
+	tests := map[string]bool{
+		"terraform":                          true,
+		"https://example.com/prysm-plugin-x": false,
+		"oci://ghcr.io/acme/prysm-plugin-x":  false,
+	}
+	for in, want := range tests {
+		if got := isRegistrySource(in); got != want {
+			t.Errorf("isRegistrySource(%q) = %v, want %v", in, got, want)
+		}
+	}
+}
@@ -45,6 +45,8 @@ var (
 	overrideToken  string
 	debugEnabled   bool
 	insecureTLS    bool
+	allowUnsigned  bool
+	noColor        bool
 
 	appOnce       sync.Once
 	app           *App
@@ -62,11 +64,14 @@ var commandGroup = map[string]string{
 	"mesh":       "Networking",
 	"ping":       "Networking",
 	"edge":       "Networking",
+	"clusters":   "Networking",
 	"session":    "Account",
 	"logout":     "Account",
 	"diagnose":   "Tools",
+	"history":    "Tools",
 	"daemon":     "Tools",
 	"update":     "Tools",
+	"plugin":     "Tools",
 	"completion": "Tools",
 }
 
@@ -83,9 +88,9 @@ var menuGroupOrder = []string{
 // Lower values appear first. Commands not listed default to 50.
 var menuOrder = map[string]int{
 	"login": 1,
-	"tunnel": 1, "mesh": 2, "ping": 3, "edge": 4,
+	"tunnel": 1, "mesh": 2, "ping": 3, "edge": 4, "clusters": 5,
 	"session": 1, "logout": 2,
-	"diagnose": 1, "daemon": 2, "update": 3, "completion": 4,
+	"diagnose": 1, "history": 2, "daemon": 3, "update": 4, "plugin": 5, "completion": 6,
 }
 
 // menuShortDesc overrides command.Short for the default help menu to keep it tight.
@@ -94,12 +99,15 @@ var menuShortDesc = map[string]string{
 	"tunnel":     "Create secure TCP tunnels",
 	"mesh":       "Join the DERP mesh network",
 	"edge":       "Manage edge proxy domains and WAF rules",
+	"clusters":   "Manage clusters and agent tokens",
 	"ping":       "Ping a host over mesh",
 	"session":    "Show current session",
 	"logout":     "Sign out and purge credentials",
 	"diagnose":   "Run network diagnostics",
+	"history":    "Show recorded prysm commands",
 	"daemon":     "Manage mesh daemon",
 	"update":     "Update the CLI",
+	"plugin":     "Install and manage plugins",
 	"completion": "Generate shell completions",
 }
 
@@ -122,10 +130,14 @@ func Execute() error {
 			pluginMgr.Shutdown()
 		}
 	}()
-	err := rootCmd.Execute()
+	start := time.Now()
+	cmd, err := rootCmd.ExecuteC()
+	runAfterHooks(err)
+	recordHistory(cmd, start, err)
 	if err != nil {
 		return friendlyError(err)
 	}
+	printUpdateNotice()
 	return nil
 }
 
@@ -150,14 +162,24 @@ func init() {
 		return nil
 	}
 
-	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, _ []string) error {
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
 		// The daemon run command operates without user config or $HOME.
 		if cmd.Name() == "run" && cmd.Parent() != nil && cmd.Parent().Name() == "daemon" {
 			return nil
 		}
-		return initApp(cmd)
+		if err := initApp(cmd); err != nil {
+			return err
+		}
+		startUpdateCheck(cmd)
+		return runBeforeHooks(cmd, args)
 	}
 
+	cobra.OnInitialize(func() {
+		if noColor || os.Getenv("NO_COLOR") != "" {
+			style.DisableColor()
+		}
+	})
+
 	rootCmd.Version = version
 	rootCmd.SetVersionTemplate(style.RenderVersion(rootCmd.Name(), version) + "\n")
 
@@ -171,6 +193,8 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&overrideToken, "token", "", "authentication token (overrides session; can also use PRYSM_TOKEN env var)")
 	rootCmd.PersistentFlags().BoolVar(&debugEnabled, "debug", false, "enable debug logging")
 	rootCmd.PersistentFlags().BoolVar(&insecureTLS, "insecure", false, "skip TLS certificate verification when connecting to the API")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "disable colored output (also honors NO_COLOR)")
+	rootCmd.PersistentFlags().BoolVar(&allowUnsigned, "allow-unsigned", false, "allow external plugins without a trusted signature to run")
 
 	_ = viper.BindPFlag("debug", rootCmd.PersistentFlags().Lookup("debug"))
 
@@ -183,11 +207,15 @@ func init() {
 		newSessionCommand(),
 		meshCmd,
 		newTunnelCommand(),
+		newClustersCommand(),
 		newDiagnoseCommand(),
+		newHistoryCommand(),
 		newPingCommand(),
 		newUpdateCommand(),
+		newMigrateCommand(),
 		newDaemonCommand(),
 		newEdgeCommand(),
+		newPluginCommand(),
 	)
 
 	// Register exit plugin commands under "mesh exit" (use, off, status).
@@ -501,14 +529,10 @@ func initApp(cmd *cobra.Command) error {
 	}
 	var initErr error
 	appOnce.Do(func() {
-		cfgPath := cfgFile
-		if cfgPath == "" {
-			home, err := config.DefaultHomeDir()
-			if err != nil {
-				initErr = fmt.Errorf("determine config directory: %w", err)
-				return
-			}
-			cfgPath = filepath.Join(home, "config.yaml")
+		cfgPath, err := resolveConfigPath()
+		if err != nil {
+			initErr = err
+			return
 		}
 
 		cfg, err := config.Load(cfgPath, activeProfile)
@@ -516,6 +540,10 @@ func initApp(cmd *cobra.Command) error {
 			initErr = err
 			return
 		}
+		if cfg, err = migrateConfig(cfgPath, cfg); err != nil {
+			initErr = err
+			return
+		}
 
 		if overrideAPI != "" {
 			cfg.APIBaseURL = strings.TrimRight(overrideAPI, "/")
@@ -635,6 +663,18 @@ func initApp(cmd *cobra.Command) error {
 	return nil
 }
 
+// resolveConfigPath returns --config or $HOME/.prysm/config.yaml.
+func resolveConfigPath() (string, error) {
+	if cfgFile != "" {
+		return cfgFile, nil
+	}
+	home, err := config.DefaultHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("determine config directory: %w", err)
+	}
+	return filepath.Join(home, "config.yaml"), nil
+}
+
 func validateAPIBaseURLSecurity(raw string) error {
 	u, err := url.Parse(strings.TrimSpace(raw))
 	if err != nil {
@@ -697,6 +737,7 @@ func initPluginManager() {
 	appCtx := &plugin.AppContext{
 		Config:   app.Config,
 		Sessions: app.Sessions,
+		Secrets:  session.NewSecretStore(filepath.Join(app.Config.HomeDir, session.SecretsFile)),
 		API:      app.API,
 		Format:   app.OutputFormat,
 		Debug:    app.Debug,
@@ -704,15 +745,91 @@ func initPluginManager() {
 	hostSvc := plugin.NewBuiltinHostServices(appCtx)
 
 	// Wire host services into the eagerly-created builtin plugins.
-	exitPlugin.SetHost(hostSvc)
+	exitPlugin.SetHost(hostSvc.ForPlugin(exitPlugin.Manifest(), true))
 
 	pluginMgr = plugin.NewManager(hostSvc, app.Config.HomeDir, app.Debug)
+	pluginMgr.SetSignaturePolicy(pluginSignaturePolicy())
 
 	// Discover and register external plugins
 	pluginMgr.DiscoverExternalPlugins()
 	pluginMgr.RegisterCommands(rootCmd)
 }
 
+// pluginSignaturePolicy returns the external plugin verification policy from
+// config (plugin_trusted_keys) and the --allow-unsigned flag.
+func pluginSignaturePolicy() plugin.SignaturePolicy {
+	return plugin.SignaturePolicy{
+		TrustedKeys:   app.Config.PluginTrustedKeys,
+		AllowUnsigned: allowUnsigned,
+	}
+}
+
+// pendingHook is the invocation whose after hooks run once the command returns.
+// It is only set when the before hooks allowed the command to proceed.
+var pendingHook *plugin.HookRequest
+
+// runBeforeHooks runs plugin hooks registered for this command; a hook
+// failure aborts the command.
+func runBeforeHooks(cmd *cobra.Command, args []string) error {
+	if pluginMgr == nil || skipPluginHooks(cmd) {
+		return nil
+	}
+	req := plugin.HookRequest{
+		Phase:   plugin.HookBefore,
+		Command: strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" "),
+		Args:    args,
+		Flags:   make(map[string]string),
+	}
+	req.WorkingDir, _ = os.Getwd()
+	// Only the command's own flags: inherited globals include --token.
+	cmd.LocalFlags().Visit(func(f *pflag.Flag) {
+		req.Flags[f.Name] = f.Value.String()
+	})
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	defer cancel()
+	if err := pluginMgr.RunHooks(ctx, req); err != nil {
+		return err
+	}
+	pendingHook = &req
+	return nil
+}
+
+// runAfterHooks runs after hooks for the command that just finished. Failures
+// are reported but never change the command's result.
+func runAfterHooks(cmdErr error) {
+	if pluginMgr == nil || pendingHook == nil {
+		return
+	}
+	req := *pendingHook
+	pendingHook = nil
+	req.Phase = plugin.HookAfter
+	if cmdErr != nil {
+		req.Error = cmdErr.Error()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := pluginMgr.RunHooks(ctx, req); err != nil {
+		fmt.Fprintln(os.Stderr, style.Warning.Render(err.Error()))
+	}
+}
+
+// skipPluginHooks excludes plugin management, help and completion so a broken
+// hook can always be removed.
+func skipPluginHooks(cmd *cobra.Command) bool {
+	if isCompletionCommand() {
+		return true
+	}
+	for c := cmd; c != nil && c != c.Root(); c = c.Parent() {
+		switch c.Name() {
+		case "plugin", "help", "completion", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+			return true
+		}
+	}
+	return false
+}
+
 func printDebug(format string, args ...interface{}) {
 	debug := (app != nil && app.Debug) || os.Getenv("PRYSM_DEBUG") == "1" || os.Getenv("PRYSM_DEBUG") == "true"
 	if debug {
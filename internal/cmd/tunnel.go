@@ -40,6 +40,7 @@ func newTunnelCommand() *cobra.Command {
 		newTunnelDiagnoseCommand(),
 		newTunnelStatusCommand(),
 		newTunnelLogsCommand(),
+		newTunnelWebhookCommand(),
 	)
 
 	return tunnelCmd
@@ -60,6 +61,9 @@ func newTunnelExposeCommand() *cobra.Command {
 		scheme            string
 		insecureUpstream  bool
 		basicAuth         string
+		routeSpecs        []string
+		capture           bool
+		captureSecrets    bool
 	)
 
 	cmd := &cobra.Command{
@@ -68,11 +72,21 @@ func newTunnelExposeCommand() *cobra.Command {
 		Long: `Expose a local port so other authenticated peers can connect via the mesh.
 With --public, also generates a public URL (https://<id>.tunnel.prysm.sh).
 
+With --route, one tunnel serves several local services: each HTTP request is
+sent to the port of the route matching its host and path ([HOST=][PATH]:PORT).
+Host routes win over host-less ones, then the longest path prefix wins.
+Route hosts are registered with the public tunnel, so they need --public; a
+single label such as "api" becomes api.<tunnel domain>.
+
 This is a long-lived command (like ngrok). Use --background to run detached.
 Press Ctrl+C to stop when running in foreground.`,
 		Example: `  # Expose port 8080 with public URL
   prysm tunnel expose 8080 --public
 
+  # Share a whole local stack through one public URL
+  prysm tunnel expose --public --route api.example.com=/:8080 --route app.example.com=/:3000
+  prysm tunnel expose --public --route /api:8080 --route /:3000
+
   # Run in background
   prysm tunnel expose 3000 --public --background`,
 		Args: cobra.MaximumNArgs(1),
@@ -83,6 +97,17 @@ Press Ctrl+C to stop when running in foreground.`,
 					return errors.New("port must be between 1-65535")
 				}
 			}
+			routes, err := parseTunnelRoutes(routeSpecs)
+			if err != nil {
+				return err
+			}
+			if port == 0 && len(routes) > 0 {
+				port = routes[0].Port
+			}
+			routeHosts := tunnelRouteHosts(routes)
+			if len(routeHosts) > 0 && !public {
+				return errors.New("host routes need a public tunnel (add --public)")
+			}
 			if port <= 0 || port > 65535 {
 				return errors.New("port is required (e.g. prysm tunnel expose 8080 or -p 8080)")
 			}
@@ -115,6 +140,9 @@ Press Ctrl+C to stop when running in foreground.`,
 				if background {
 					return errors.New("--background is not supported for cluster tunnels")
 				}
+				if len(routes) > 0 || capture {
+					return errors.New("--route and webhook capture are not supported for cluster tunnels")
+				}
 				if strings.TrimSpace(service) == "" {
 					return errors.New("--service is required for cluster tunnels")
 				}
@@ -171,7 +199,7 @@ Press Ctrl+C to stop when running in foreground.`,
 			// credentials are passed through an env var so they don't appear
 			// in the child's argv (visible via `ps`).
 			if background && os.Getenv("PRYSM_TUNNEL_DAEMON") == "" {
-				return runTunnelExposeBackground(port, name, toPeer, externalPort, public, verbose, scheme, insecureUpstream, basicAuth)
+				return runTunnelExposeBackground(port, name, toPeer, externalPort, public, verbose, scheme, insecureUpstream, basicAuth, routeSpecs, capture, captureSecrets)
 			}
 
 			app := MustApp()
@@ -205,6 +233,28 @@ Press Ctrl+C to stop when running in foreground.`,
 				derpToken = tokResp.Token
 			}
 
+			// With --route or webhook capture, tunnel traffic goes to an
+			// in-process HTTP proxy that picks the local service per request
+			// (and records it).
+			var routerAddr string
+			if len(routes) > 0 || capture {
+				proxyRoutes := routes
+				if len(proxyRoutes) == 0 {
+					proxyRoutes = []tunnelRoute{{Path: "/", Port: port}}
+				}
+				var handler http.Handler = newTunnelRouter(proxyRoutes, scheme, insecureUpstream)
+				if capture {
+					quiet := os.Getenv("PRYSM_TUNNEL_DAEMON") != ""
+					handler = newWebhookRecorder(webhookDir(app.Config.HomeDir), port, scheme, quiet, captureSecrets, handler)
+				}
+				addr, stopRouter, err := startTunnelRouter(handler)
+				if err != nil {
+					return err
+				}
+				defer stopRouter()
+				routerAddr = addr
+			}
+
 			// Route tracking for bidirectional forwarding
 			routeConns := make(map[string]net.Conn)
 			routeConnsMu := sync.RWMutex{}
@@ -267,14 +317,17 @@ Press Ctrl+C to stop when running in foreground.`,
 					return
 				}
 				// route_setup: dial localhost:<targetPort> and start forwarding
-				addr := fmt.Sprintf("127.0.0.1:%d", targetPort)
-				logTunnel("[tunnel] route_setup route=%s dialing %s (scheme=%s)\n", routeID, addr, scheme)
-				conn, dialErr := dialUpstream(addr, scheme, insecureUpstream)
+				addr, dialScheme := fmt.Sprintf("127.0.0.1:%d", targetPort), scheme
+				if routerAddr != "" {
+					addr, dialScheme = routerAddr, "http"
+				}
+				logTunnel("[tunnel] route_setup route=%s dialing %s (scheme=%s)\n", routeID, addr, dialScheme)
+				conn, dialErr := dialUpstream(addr, dialScheme, insecureUpstream)
 				if dialErr != nil {
 					fmt.Fprintf(os.Stderr, "%s\n", style.Error.Render(fmt.Sprintf("tunnel dial %s: %v", addr, dialErr)))
 					return
 				}
-				logTunnel("[tunnel] connected to %s (scheme=%s)\n", addr, scheme)
+				logTunnel("[tunnel] connected to %s (scheme=%s)\n", addr, dialScheme)
 				routeConnsMu.Lock()
 				routeConns[routeID] = conn
 				routeConnsMu.Unlock()
@@ -366,12 +419,18 @@ Press Ctrl+C to stop when running in foreground.`,
 					IsPublic:          public,
 					BasicAuthUser:     basicAuthUser,
 					BasicAuthPassword: basicAuthPass,
+					Hostnames:         routeHosts,
 				})
 				return createErr
 			}); err != nil {
 				derpClient.Close()
 				return err
 			}
+			if missing := unregisteredRouteHosts(routeHosts, tunnel.Hostnames); len(missing) > 0 {
+				derpClient.Close()
+				cleanupTunnel(app, tunnel.ID)
+				return fmt.Errorf("the control plane did not register route host(s) %s for this tunnel", strings.Join(missing, ", "))
+			}
 
 			// Daemon-only: record the tunnel ID so `prysm tunnel status` can
 			// correlate this PID with the backend row. Best-effort — a failure
@@ -388,6 +447,9 @@ Press Ctrl+C to stop when running in foreground.`,
 			if tunnel.IsPublic && tunnel.ExternalURL != "" {
 				fmt.Println(style.Info.Render(fmt.Sprintf("  Public URL:  %s", tunnel.ExternalURL)))
 			}
+			for _, h := range tunnel.Hostnames {
+				fmt.Println(style.Info.Render(fmt.Sprintf("  Host URL:    https://%s", h)))
+			}
 			fmt.Println(style.MutedStyle.Render(fmt.Sprintf("  Mesh:        prysm tunnel connect --peer %s --port %d", deviceID, port)))
 			fmt.Printf("  Tunnel ID:   %d\n", tunnel.ID)
 			fmt.Printf("  Status:      %s\n", tunnel.Status)
@@ -397,6 +459,16 @@ Press Ctrl+C to stop when running in foreground.`,
 			if basicAuthUser != "" {
 				fmt.Printf("  Auth:        basic (user=%s)\n", basicAuthUser)
 			}
+			if capture {
+				fmt.Printf("  Capture:     %s (prysm tunnel webhook list)\n", webhookDir(app.Config.HomeDir))
+			}
+			for i, r := range routes {
+				label := "  Routes:      "
+				if i > 0 {
+					label = "               "
+				}
+				fmt.Printf("%s%s\n", label, r)
+			}
 			fmt.Println()
 			if os.Getenv("PRYSM_TUNNEL_DAEMON") != "" {
 				fmt.Println(style.MutedStyle.Render("Running in background. Use `prysm tunnel delete <id>` to stop."))
@@ -470,12 +542,17 @@ Press Ctrl+C to stop when running in foreground.`,
 	cmd.Flags().StringVar(&scheme, "scheme", "http", "upstream scheme: http or https")
 	cmd.Flags().BoolVar(&insecureUpstream, "insecure-upstream", true, "skip TLS verification for https upstream (default true for localhost dev)")
 	cmd.Flags().StringVar(&basicAuth, "basic-auth", "", "gate the public URL with HTTP basic auth in user:pass form (only meaningful with --public)")
+	cmd.Flags().StringArrayVar(&routeSpecs, "route", nil, "route HTTP requests by host and/or path to a local port, as [HOST=][PATH]:PORT (repeatable)")
+	cmd.Flags().BoolVar(&capture, "capture", false, "record inbound requests for replay (used by `prysm tunnel webhook`)")
+	_ = cmd.Flags().MarkHidden("capture")
+	cmd.Flags().BoolVar(&captureSecrets, "capture-secret-headers", false, "keep authorization and signature headers in captured requests")
+	_ = cmd.Flags().MarkHidden("capture-secret-headers")
 
 	return cmd
 }
 
 // runTunnelExposeBackground spawns a detached child process running tunnel expose.
-func runTunnelExposeBackground(port int, name, toPeer string, externalPort int, public, verbose bool, scheme string, insecureUpstream bool, basicAuth string) error {
+func runTunnelExposeBackground(port int, name, toPeer string, externalPort int, public, verbose bool, scheme string, insecureUpstream bool, basicAuth string, routeSpecs []string, capture, captureSecrets bool) error {
 	homeDir, err := config.DefaultHomeDir()
 	if err != nil {
 		return fmt.Errorf("config dir: %w", err)
@@ -513,6 +590,15 @@ func runTunnelExposeBackground(port int, name, toPeer string, externalPort int,
 	if !insecureUpstream {
 		args = append(args, "--insecure-upstream=false")
 	}
+	for _, r := range routeSpecs {
+		args = append(args, "--route", r)
+	}
+	if capture {
+		args = append(args, "--capture")
+	}
+	if captureSecrets {
+		args = append(args, "--capture-secret-headers")
+	}
 
 	child := exec.Command(os.Args[0], args...)
 	env := append(os.Environ(), "PRYSM_TUNNEL_DAEMON=1")
@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// tunnelRoute maps requests for a host and/or path prefix to a local port.
+// An empty Host matches any host.
+type tunnelRoute struct {
+	Host string
+	Path string
+	Port int
+}
+
+func (r tunnelRoute) String() string {
+	return fmt.Sprintf("%s%s → localhost:%d", r.Host, r.Path, r.Port)
+}
+
+// parseTunnelRoute parses a --route value: [HOST=][PATH]:PORT, for example
+// "api.example.com=/:8080", "/docs:4000" or "app.example.com=:3000".
+func parseTunnelRoute(s string) (tunnelRoute, error) {
+	s = strings.TrimSpace(s)
+	var r tunnelRoute
+	target := s
+	if host, rest, ok := strings.Cut(s, "="); ok {
+		r.Host = strings.ToLower(strings.TrimSpace(host))
+		if r.Host == "" || strings.ContainsAny(r.Host, "/: ") {
+			return tunnelRoute{}, fmt.Errorf("invalid route %q: bad host %q", s, host)
+		}
+		target = rest
+	}
+	idx := strings.LastIndex(target, ":")
+	if idx < 0 {
+		return tunnelRoute{}, fmt.Errorf("invalid route %q: expected [HOST=][PATH]:PORT", s)
+	}
+	port, err := strconv.Atoi(target[idx+1:])
+	if err != nil || port <= 0 || port > 65535 {
+		return tunnelRoute{}, fmt.Errorf("invalid route %q: port must be between 1-65535", s)
+	}
+	r.Port = port
+	r.Path = strings.TrimSpace(target[:idx])
+	if r.Path == "" {
+		r.Path = "/"
+	}
+	if !strings.HasPrefix(r.Path, "/") {
+		return tunnelRoute{}, fmt.Errorf("invalid route %q: path must start with /", s)
+	}
+	if r.Path != "/" {
+		r.Path = strings.TrimSuffix(r.Path, "/")
+	}
+	return r, nil
+}
+
+func parseTunnelRoutes(values []string) ([]tunnelRoute, error) {
+	routes := make([]tunnelRoute, 0, len(values))
+	seen := make(map[string]bool, len(values))
+	for _, v := range values {
+		r, err := parseTunnelRoute(v)
+		if err != nil {
+			return nil, err
+		}
+		key := r.Host + r.Path
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate route for %s%s", r.Host, r.Path)
+		}
+		seen[key] = true
+		routes = append(routes, r)
+	}
+	return routes, nil
+}
+
+// tunnelRouter demultiplexes HTTP requests arriving on a public tunnel to
+// local services. Host routes win over host-less ones, then the longest path
+// prefix wins. Routes are matched on whole path segments.
+type tunnelRouter struct {
+	routes  []tunnelRoute
+	proxies map[int]*httputil.ReverseProxy
+}
+
+func newTunnelRouter(routes []tunnelRoute, scheme string, insecureUpstream bool) *tunnelRouter {
+	sorted := append([]tunnelRoute(nil), routes...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if (sorted[i].Host != "") != (sorted[j].Host != "") {
+			return sorted[i].Host != ""
+		}
+		return len(sorted[i].Path) > len(sorted[j].Path)
+	})
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{ServerName: "localhost", InsecureSkipVerify: insecureUpstream}
+
+	rt := &tunnelRouter{routes: sorted, proxies: make(map[int]*httputil.ReverseProxy)}
+	for _, r := range sorted {
+		if rt.proxies[r.Port] != nil {
+			continue
+		}
+		target := &url.URL{Scheme: scheme, Host: fmt.Sprintf("127.0.0.1:%d", r.Port)}
+		rt.proxies[r.Port] = &httputil.ReverseProxy{
+			Rewrite: func(pr *httputil.ProxyRequest) {
+				pr.SetURL(target)
+				pr.Out.Host = pr.In.Host
+				pr.SetXForwarded()
+			},
+			Transport: transport,
+			ErrorHandler: func(w http.ResponseWriter, req *http.Request, err error) {
+				printDebug("tunnel route %s: %v", target.Host, err)
+				http.Error(w, fmt.Sprintf("upstream localhost:%d unavailable", r.Port), http.StatusBadGateway)
+			},
+		}
+	}
+	return rt
+}
+
+// match returns the route for a request host and path.
+func (rt *tunnelRouter) match(host, path string) (tunnelRoute, bool) {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.ToLower(host)
+	for _, r := range rt.routes {
+		if r.Host != "" && !tunnelRouteHostMatches(r.Host, host) {
+			continue
+		}
+		if r.Path == "/" || path == r.Path || strings.HasPrefix(path, r.Path+"/") {
+			return r, true
+		}
+	}
+	return tunnelRoute{}, false
+}
+
+// tunnelRouteHostMatches matches a route host against a request host. A
+// single-label route host ("api") also matches the first label of the
+// request host, so it works with api.<tunnel domain> style names.
+func tunnelRouteHostMatches(routeHost, host string) bool {
+	if routeHost == host {
+		return true
+	}
+	if !strings.Contains(routeHost, ".") {
+		first, _, _ := strings.Cut(host, ".")
+		return first == routeHost
+	}
+	return false
+}
+
+// tunnelRouteHosts returns the distinct route hosts, in route order. They are
+// registered with the tunnel so the public edge sends them here.
+func tunnelRouteHosts(routes []tunnelRoute) []string {
+	var hosts []string
+	for _, r := range routes {
+		if r.Host != "" && !slices.Contains(hosts, r.Host) {
+			hosts = append(hosts, r.Host)
+		}
+	}
+	return hosts
+}
+
+// unregisteredRouteHosts returns the route hosts the control plane did not
+// register for the tunnel; requests for them would never arrive.
+func unregisteredRouteHosts(requested, registered []string) []string {
+	var missing []string
+	for _, h := range requested {
+		if !slices.ContainsFunc(registered, func(r string) bool { return tunnelRouteHostMatches(h, strings.ToLower(r)) }) {
+			missing = append(missing, h)
+		}
+	}
+	return missing
+}
+
+func (rt *tunnelRouter) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r, ok := rt.match(req.Host, req.URL.Path)
+	if !ok {
+		http.Error(w, fmt.Sprintf("no tunnel route for %s%s", req.Host, req.URL.Path), http.StatusNotFound)
+		return
+	}
+	rt.proxies[r.Port].ServeHTTP(w, req)
+}
+
+// startTunnelRouter serves handler (a tunnelRouter, possibly wrapped) on a
+// loopback port; tunnel traffic is forwarded there instead of to a single
+// local port.
+func startTunnelRouter(handler http.Handler) (addr string, stop func(), err error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", nil, fmt.Errorf("start tunnel router: %w", err)
+	}
+	srv := &http.Server{Handler: handler, ReadHeaderTimeout: 30 * time.Second}
+	go func() {
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			printDebug("tunnel router: %v", err)
+		}
+	}()
+	return ln.Addr().String(), func() { _ = srv.Close() }, nil
+}
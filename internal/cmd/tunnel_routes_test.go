@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+)
+
+func TestParseTunnelRoute(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    tunnelRoute
+		wantErr bool
+	}{
+		{in: "api.example.com=/:8080", want: tunnelRoute{Host: "api.example.com", Path: "/", Port: 8080}},
+		{in: "App.Example.com=:3000", want: tunnelRoute{Host: "app.example.com", Path: "/", Port: 3000}},
+		{in: "/docs/:4000", want: tunnelRoute{Path: "/docs", Port: 4000}},
+		{in: "api=/v1:9000", want: tunnelRoute{Host: "api", Path: "/v1", Port: 9000}},
+		{in: "8080", wantErr: true},
+		{in: "/:0", wantErr: true},
+		{in: "docs:4000", wantErr: true},
+		{in: "=/:80", wantErr: true},
+		{in: "a/b=/:80", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseTunnelRoute(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseTunnelRoute(%q) = %+v, %v; want %+v (error %v)", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+	if _, err := parseTunnelRoutes([]string{"/:80", "/:81"}); err == nil {
+		t.Error("expected duplicate route error")
+	}
+}
+
+func TestTunnelRouteHosts(t *testing.T) {
+	routes, err := parseTunnelRoutes([]string{"api.example.com=/:8080", "api.example.com=/v2:8081", "app=/:3000", "/:4000"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hosts := tunnelRouteHosts(routes)
+	if fmt.Sprint(hosts) != "[api.example.com app]" {
+		t.Fatalf("tunnelRouteHosts = %v", hosts)
+	}
+	if missing := unregisteredRouteHosts(hosts, []string{"API.example.com", "app.t-123.tunnel.prysm.sh"}); len(missing) != 0 {
+		t.Errorf("all hosts registered, missing = %v", missing)
+	}
+	if missing := unregisteredRouteHosts(hosts, []string{"app.t-123.tunnel.prysm.sh"}); fmt.Sprint(missing) != "[api.example.com]" {
+		t.Errorf("missing = %v, want [api.example.com]", missing)
+	}
+}
+
+func TestTunnelRouterMatch(t *testing.T) {
+	rt := newTunnelRouter([]tunnelRoute{
+		{Path: "/", Port: 3000},
+		{Path: "/api", Port: 8080},
+		{Host: "docs.example.com", Path: "/", Port: 4000},
+		{Host: "admin", Path: "/", Port: 5000},
+	}, "http", false)
+
+	tests := []struct {
+		host, path string
+		want       int
+	}{
+		{"abc.tunnel.prysm.sh", "/", 3000},
+		{"abc.tunnel.prysm.sh", "/api", 8080},
+		{"abc.tunnel.prysm.sh", "/api/users", 8080},
+		{"abc.tunnel.prysm.sh", "/apix", 3000},
+		{"Docs.Example.com:443", "/api", 4000},
+		{"admin.abc.tunnel.prysm.sh", "/", 5000},
+	}
+	for _, tt := range tests {
+		r, ok := rt.match(tt.host, tt.path)
+		if !ok || r.Port != tt.want {
+			t.Errorf("match(%q, %q) = %d, %v; want %d", tt.host, tt.path, r.Port, ok, tt.want)
+		}
+	}
+
+	hostOnly := newTunnelRouter([]tunnelRoute{{Host: "api.example.com", Path: "/", Port: 8080}}, "http", false)
+	if _, ok := hostOnly.match("other.example.com", "/"); ok {
+		t.Error("unrouted host should not match")
+	}
+}
+
+func TestTunnelRouterProxies(t *testing.T) {
+	backend := func(name string) (*httptest.Server, int) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, "%s %s %s", name, r.Host, r.URL.Path)
+		}))
+		u, _ := url.Parse(s.URL)
+		port, _ := strconv.Atoi(u.Port())
+		return s, port
+	}
+	api, apiPort := backend("api")
+	defer api.Close()
+	web, webPort := backend("web")
+	defer web.Close()
+
+	addr, stop, err := startTunnelRouter(newTunnelRouter([]tunnelRoute{
+		{Host: "api.example.com", Path: "/", Port: apiPort},
+		{Path: "/", Port: webPort},
+	}, "http", false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stop()
+
+	get := func(host, path string) (int, string) {
+		req, _ := http.NewRequest("GET", "http://"+addr+path, nil)
+		req.Host = host
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(body)
+	}
+	if code, body := get("api.example.com", "/users"); code != 200 || body != "api api.example.com /users" {
+		t.Errorf("api route: %d %q", code, body)
+	}
+	if code, body := get("abc.tunnel.prysm.sh", "/"); code != 200 || body != "web abc.tunnel.prysm.sh /" {
+		t.Errorf("default route: %d %q", code, body)
+	}
+}
@@ -0,0 +1,530 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/prysmsh/cli/internal/style"
+	"github.com/prysmsh/cli/internal/ui"
+)
+
+const (
+	// webhookMaxBody caps the stored body; larger requests are forwarded in
+	// full but cannot be replayed.
+	webhookMaxBody = 10 << 20
+	// webhookKeep is the number of captured requests kept on disk.
+	webhookKeep = 500
+)
+
+// webhookRequest is a captured inbound request. Its metadata and headers are
+// stored as <home>/webhooks/<id>.json and its body as <id>.body, so listing
+// never reads bodies.
+type webhookRequest struct {
+	ID         string      `json:"id"`
+	ReceivedAt time.Time   `json:"received_at"`
+	Method     string      `json:"method"`
+	Host       string      `json:"host"`
+	URI        string      `json:"uri"`
+	Header     http.Header `json:"header"`
+	Redacted   []string    `json:"redacted,omitempty"`
+	Body       []byte      `json:"body,omitempty"`
+	BodySize   int         `json:"body_size"`
+	Truncated  bool        `json:"truncated,omitempty"`
+	Port       int         `json:"port"`
+	Scheme     string      `json:"scheme"`
+	Status     int         `json:"status,omitempty"`
+}
+
+func newTunnelWebhookCommand() *cobra.Command {
+	var (
+		port       int
+		name       string
+		background bool
+		verbose    bool
+		keepSecret bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "webhook",
+		Short: "Expose a public URL for webhooks and record every request",
+		Long: `Expose a local port as a public URL (like ` + "`tunnel expose --public`" + `) and save
+every inbound request, so webhook integrations can be developed without asking
+the provider to resend events.
+
+Authorization, cookie, token and signature headers are not stored unless
+--keep-secret-headers is given; replayed requests are sent without them.
+
+Captured requests are kept in the prysm home directory (the latest ` + strconv.Itoa(webhookKeep) + `). List
+them with ` + "`prysm tunnel webhook list`" + ` and send one to the local service again
+with ` + "`prysm tunnel webhook replay <id>`" + `.`,
+		Example: `  prysm tunnel webhook --port 3000
+  prysm tunnel webhook --port 3000 --keep-secret-headers
+  prysm tunnel webhook list
+  prysm tunnel webhook replay 3f9a1c`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if port <= 0 || port > 65535 {
+				return fmt.Errorf("--port is required (1-65535)")
+			}
+			expose := newTunnelExposeCommand()
+			expose.SetContext(cmd.Context())
+			flags := map[string]string{
+				"public":                 "true",
+				"capture":                "true",
+				"name":                   name,
+				"background":             strconv.FormatBool(background),
+				"verbose":                strconv.FormatBool(verbose),
+				"capture-secret-headers": strconv.FormatBool(keepSecret),
+			}
+			for k, v := range flags {
+				if err := expose.Flags().Set(k, v); err != nil {
+					return err
+				}
+			}
+			return expose.RunE(expose, []string{strconv.Itoa(port)})
+		},
+	}
+	cmd.Flags().IntVarP(&port, "port", "p", 0, "local port that receives the webhooks")
+	cmd.Flags().StringVar(&name, "name", "", "optional tunnel name")
+	cmd.Flags().BoolVarP(&background, "background", "b", false, "run in background (detached)")
+	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "verbose tunnel traffic logging")
+	cmd.Flags().BoolVar(&keepSecret, "keep-secret-headers", false, "store authorization, cookie, token and signature headers so replays carry them")
+
+	cmd.AddCommand(
+		newTunnelWebhookListCommand(),
+		newTunnelWebhookShowCommand(),
+		newTunnelWebhookReplayCommand(),
+	)
+	return cmd
+}
+
+func newTunnelWebhookListCommand() *cobra.Command {
+	var (
+		outputFormat string
+		limit        int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List captured webhook requests, newest first",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app := MustApp()
+			reqs, err := loadWebhookRequests(webhookDir(app.Config.HomeDir))
+			if err != nil {
+				return err
+			}
+			if limit > 0 && len(reqs) > limit {
+				reqs = reqs[:limit]
+			}
+			if wantsJSONOutput(outputFormat) {
+				return writeJSON(reqs)
+			}
+			if len(reqs) == 0 {
+				fmt.Println(style.MutedStyle.Render("No webhooks captured yet. Start with `prysm tunnel webhook --port <port>`."))
+				return nil
+			}
+			data := make([][]string, len(reqs))
+			for i, r := range reqs {
+				status := "-"
+				if r.Status > 0 {
+					status = strconv.Itoa(r.Status)
+				}
+				data[i] = []string{r.ID, r.ReceivedAt.Local().Format("2006-01-02 15:04:05"), r.Method, r.URI, status, formatTransferSize(int64(r.BodySize))}
+			}
+			ui.PrintTable([]string{"ID", "RECEIVED", "METHOD", "PATH", "STATUS", "BODY"}, data)
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "", "output format (table, json)")
+	cmd.Flags().IntVar(&limit, "limit", 50, "maximum number of requests to show (0 for all)")
+	return cmd
+}
+
+func newTunnelWebhookShowCommand() *cobra.Command {
+	var outputFormat string
+
+	cmd := &cobra.Command{
+		Use:   "show <id>",
+		Short: "Print a captured webhook request",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app := MustApp()
+			r, err := findWebhookRequest(webhookDir(app.Config.HomeDir), args[0])
+			if err != nil {
+				return err
+			}
+			if wantsJSONOutput(outputFormat) {
+				return writeJSON(r)
+			}
+			fmt.Printf("%s %s\n", r.Method, r.URI)
+			fmt.Printf("Host: %s\n", r.Host)
+			keys := make([]string, 0, len(r.Header))
+			for k := range r.Header {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				for _, v := range r.Header[k] {
+					fmt.Printf("%s: %s\n", k, v)
+				}
+			}
+			for _, k := range r.Redacted {
+				fmt.Println(style.MutedStyle.Render(k + ": [redacted]"))
+			}
+			fmt.Println()
+			os.Stdout.Write(r.Body)
+			if len(r.Body) > 0 && !bytes.HasSuffix(r.Body, []byte("\n")) {
+				fmt.Println()
+			}
+			if r.Truncated {
+				fmt.Println(style.Warning.Render(fmt.Sprintf("(body truncated at %s)", formatTransferSize(webhookMaxBody))))
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "", "output format (text, json)")
+	return cmd
+}
+
+func newTunnelWebhookReplayCommand() *cobra.Command {
+	var port int
+
+	cmd := &cobra.Command{
+		Use:   "replay <id>",
+		Short: "Send a captured webhook request to the local service again",
+		Long: `Send a captured request to the local service again, with its original
+method, path, headers and body. It goes to the port it was captured for unless
+--port is given.`,
+		Example: `  prysm tunnel webhook replay 3f9a1c
+  prysm tunnel webhook replay 3f9a1c --port 3001`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app := MustApp()
+			r, err := findWebhookRequest(webhookDir(app.Config.HomeDir), args[0])
+			if err != nil {
+				return err
+			}
+			if r.Truncated {
+				return fmt.Errorf("request %s was larger than %s and cannot be replayed", r.ID, formatTransferSize(webhookMaxBody))
+			}
+			if port == 0 {
+				port = r.Port
+			}
+			if len(r.Redacted) > 0 {
+				fmt.Fprintln(os.Stderr, style.Warning.Render(fmt.Sprintf("Replaying without %s (not captured; use `prysm tunnel webhook --keep-secret-headers` to keep them).", strings.Join(r.Redacted, ", "))))
+			}
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			start := time.Now()
+			status, err := replayWebhookRequest(ctx, r, port)
+			if err != nil {
+				return fmt.Errorf("replay %s: %w", r.ID, err)
+			}
+			printTunnelRequest(r.Method, r.URI, status, time.Since(start))
+			return nil
+		},
+	}
+	cmd.Flags().IntVarP(&port, "port", "p", 0, "local port to send the request to (default: the captured port)")
+	return cmd
+}
+
+func webhookDir(homeDir string) string {
+	return filepath.Join(homeDir, "webhooks")
+}
+
+// webhookRecorder saves every request passing through to the local service.
+// Secret headers are left out of the capture unless keepSecret is set.
+type webhookRecorder struct {
+	dir        string
+	port       int
+	scheme     string
+	quiet      bool
+	keepSecret bool
+	next       http.Handler
+}
+
+func newWebhookRecorder(dir string, port int, scheme string, quiet, keepSecret bool, next http.Handler) *webhookRecorder {
+	return &webhookRecorder{dir: dir, port: port, scheme: scheme, quiet: quiet, keepSecret: keepSecret, next: next}
+}
+
+func (wr *webhookRecorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(req.Body, webhookMaxBody+1))
+	if err != nil {
+		http.Error(w, "read request body", http.StatusBadRequest)
+		return
+	}
+	rec := &webhookRequest{
+		ID:         newWebhookID(),
+		ReceivedAt: time.Now().UTC(),
+		Method:     req.Method,
+		Host:       req.Host,
+		URI:        req.URL.RequestURI(),
+		Header:     req.Header.Clone(),
+		Body:       body,
+		Port:       wr.port,
+		Scheme:     wr.scheme,
+	}
+	if len(body) > webhookMaxBody {
+		rec.Body, rec.Truncated = body[:webhookMaxBody], true
+	}
+	rec.BodySize = len(rec.Body)
+	if !wr.keepSecret {
+		rec.Redacted = redactWebhookHeaders(rec.Header)
+	}
+	req.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), req.Body))
+
+	sw := &statusWriter{ResponseWriter: w}
+	wr.next.ServeHTTP(sw, req)
+	rec.Status = sw.status
+	if rec.Status == 0 {
+		rec.Status = http.StatusOK
+	}
+	if err := saveWebhookRequest(wr.dir, rec); err != nil {
+		fmt.Fprintln(os.Stderr, style.Warning.Render(fmt.Sprintf("webhook: could not save request: %v", err)))
+		return
+	}
+	if !wr.quiet {
+		fmt.Println(style.MutedStyle.Render(fmt.Sprintf("  ↳ captured %s · prysm tunnel webhook replay %s", rec.ID, rec.ID)))
+	}
+}
+
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *statusWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// redactWebhookHeaders removes credentials and webhook signatures from h and
+// returns the names it removed, sorted.
+func redactWebhookHeaders(h http.Header) []string {
+	var removed []string
+	for k := range h {
+		if isSecretWebhookHeader(k) {
+			removed = append(removed, k)
+			h.Del(k)
+		}
+	}
+	sort.Strings(removed)
+	return removed
+}
+
+// isSecretWebhookHeader reports whether a header carries credentials or a
+// signature that could be used to forge or replay requests to the real
+// endpoint (Authorization, Stripe-Signature, X-Hub-Signature-256,
+// X-Gitlab-Token, X-Shopify-Hmac-Sha256, ...).
+func isSecretWebhookHeader(name string) bool {
+	switch http.CanonicalHeaderKey(name) {
+	case "Authorization", "Proxy-Authorization", "Cookie":
+		return true
+	}
+	name = strings.ToLower(name)
+	for _, s := range []string{"signature", "hmac", "token", "secret", "api-key"} {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func newWebhookID() string {
+	b := make([]byte, 4)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// saveWebhookRequest stores a captured request and prunes the oldest ones
+// beyond webhookKeep. The body is written before the metadata, so a listed
+// capture always has its body.
+func saveWebhookRequest(dir string, r *webhookRequest) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	meta := *r
+	meta.Body = nil
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, r.ID+".body"), r.Body, 0o600); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, r.ID+".json"), data, 0o600); err != nil {
+		return err
+	}
+	return pruneWebhookRequests(dir, webhookKeep)
+}
+
+// pruneWebhookRequests removes all but the keep most recently written
+// captures, with their bodies. It runs on every captured request, so it goes
+// by file modification time and never reads the captures themselves.
+func pruneWebhookRequests(dir string, keep int) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	type capture struct {
+		name    string
+		modTime time.Time
+	}
+	var files []capture
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, capture{e.Name(), info.ModTime()})
+	}
+	if len(files) <= keep {
+		return nil
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].modTime.After(files[j].modTime) })
+	for _, f := range files[keep:] {
+		_ = os.Remove(filepath.Join(dir, f.name))
+		_ = os.Remove(filepath.Join(dir, strings.TrimSuffix(f.name, ".json")+".body"))
+	}
+	return nil
+}
+
+// loadWebhookRequests returns the captured requests without their bodies,
+// newest first.
+func loadWebhookRequests(dir string) ([]webhookRequest, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var reqs []webhookRequest
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			continue
+		}
+		var r webhookRequest
+		if err := json.Unmarshal(data, &r); err != nil || r.ID == "" {
+			printDebug("webhook: skipping %s: %v", e.Name(), err)
+			continue
+		}
+		reqs = append(reqs, r)
+	}
+	sort.Slice(reqs, func(i, j int) bool { return reqs[i].ReceivedAt.After(reqs[j].ReceivedAt) })
+	return reqs, nil
+}
+
+// findWebhookRequest looks a request up by ID or unique ID prefix and loads
+// it with its body. Only the matching capture is read.
+func findWebhookRequest(dir, ref string) (*webhookRequest, error) {
+	ref = strings.ToLower(strings.TrimSpace(ref))
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	var matches []string
+	for _, e := range entries {
+		id, ok := strings.CutSuffix(e.Name(), ".json")
+		if e.IsDir() || !ok {
+			continue
+		}
+		if id == ref {
+			matches = []string{id}
+			break
+		}
+		if ref != "" && strings.HasPrefix(id, ref) {
+			matches = append(matches, id)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no captured request %q — see `prysm tunnel webhook list`", ref)
+	case 1:
+		return readWebhookRequest(dir, matches[0])
+	}
+	return nil, fmt.Errorf("%q matches %d captured requests; use more of the ID", ref, len(matches))
+}
+
+// readWebhookRequest loads one capture and its body.
+func readWebhookRequest(dir, id string) (*webhookRequest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, id+".json"))
+	if err != nil {
+		return nil, err
+	}
+	var r webhookRequest
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("read captured request %s: %w", id, err)
+	}
+	body, err := os.ReadFile(filepath.Join(dir, id+".body"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("read captured request %s: %w", id, err)
+	}
+	r.Body = body
+	return &r, nil
+}
+
+// replayWebhookRequest sends a captured request to localhost:port and returns
+// the response status.
+func replayWebhookRequest(ctx context.Context, r *webhookRequest, port int) (int, error) {
+	scheme := r.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	target := fmt.Sprintf("%s://127.0.0.1:%d%s", scheme, port, r.URI)
+	req, err := http.NewRequestWithContext(ctx, r.Method, target, bytes.NewReader(r.Body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header = r.Header.Clone()
+	req.Header.Del("Content-Length")
+	req.Host = r.Host
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{ServerName: "localhost", InsecureSkipVerify: true}
+	client := &http.Client{
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode, nil
+}
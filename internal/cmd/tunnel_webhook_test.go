@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWebhookCaptureAndReplay(t *testing.T) {
+	var bodies []string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, r.Method+" "+r.Host+r.URL.RequestURI()+" "+r.Header.Get("X-Signature")+" "+string(b))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer upstream.Close()
+	u, _ := url.Parse(upstream.URL)
+	port, _ := strconv.Atoi(u.Port())
+
+	dir := t.TempDir()
+	rec := newWebhookRecorder(dir, port, "http", true, true, newTunnelRouter([]tunnelRoute{{Path: "/", Port: port}}, "http", false))
+	req := httptest.NewRequest("POST", "http://hooks.example.com/stripe?live=1", strings.NewReader(`{"type":"invoice.paid"}`))
+	req.Header.Set("X-Signature", "sig")
+	w := httptest.NewRecorder()
+	rec.ServeHTTP(w, req)
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("proxied status = %d", w.Code)
+	}
+
+	reqs, err := loadWebhookRequests(dir)
+	if err != nil || len(reqs) != 1 {
+		t.Fatalf("loadWebhookRequests = %v, %v", reqs, err)
+	}
+	got := reqs[0]
+	if got.Method != "POST" || got.URI != "/stripe?live=1" || got.Status != http.StatusAccepted || got.BodySize != 23 || got.Body != nil {
+		t.Errorf("listed %+v", got)
+	}
+
+	found, err := findWebhookRequest(dir, got.ID[:4])
+	if err != nil || found.ID != got.ID || string(found.Body) != `{"type":"invoice.paid"}` {
+		t.Fatalf("findWebhookRequest by prefix = %v, %v", found, err)
+	}
+	status, err := replayWebhookRequest(context.Background(), found, port)
+	if err != nil || status != http.StatusAccepted {
+		t.Fatalf("replay = %d, %v", status, err)
+	}
+	if len(bodies) != 2 || bodies[0] != bodies[1] {
+		t.Errorf("replayed request differs: %q", bodies)
+	}
+	if _, err := findWebhookRequest(dir, "zzzz"); err == nil {
+		t.Error("expected error for unknown id")
+	}
+}
+
+func TestWebhookCaptureRedactsSecretHeaders(t *testing.T) {
+	dir := t.TempDir()
+	rec := newWebhookRecorder(dir, 1, "http", true, false, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Stripe-Signature") == "" {
+			t.Error("secret headers must still reach the local service")
+		}
+	}))
+	req := httptest.NewRequest("POST", "http://hooks.example.com/stripe", strings.NewReader("{}"))
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("Stripe-Signature", "t=1,v1=abc")
+	req.Header.Set("X-Gitlab-Token", "token")
+	req.Header.Set("Content-Type", "application/json")
+	rec.ServeHTTP(httptest.NewRecorder(), req)
+
+	reqs, err := loadWebhookRequests(dir)
+	if err != nil || len(reqs) != 1 {
+		t.Fatalf("loadWebhookRequests = %v, %v", reqs, err)
+	}
+	got, err := readWebhookRequest(dir, reqs[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(got.Redacted, ",") != "Authorization,Stripe-Signature,X-Gitlab-Token" {
+		t.Errorf("redacted = %v", got.Redacted)
+	}
+	if len(got.Header) != 1 || got.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("stored headers = %v", got.Header)
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, got.ID+".json"))
+	if strings.Contains(string(data), "secret") || strings.Contains(string(data), "abc") {
+		t.Errorf("capture contains secrets: %s", data)
+	}
+}
+
+func TestPruneWebhookRequests(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	for i := 0; i < 5; i++ {
+		path := filepath.Join(dir, fmt.Sprintf("req%d.json", i))
+		// Contents are never parsed while pruning.
+		if err := os.WriteFile(path, []byte("not json"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		mtime := now.Add(time.Duration(i) * time.Minute)
+		if err := os.Chtimes(path, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := pruneWebhookRequests(dir, 3); err != nil {
+		t.Fatalf("pruneWebhookRequests: %v", err)
+	}
+	entries, _ := os.ReadDir(dir)
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	if strings.Join(names, ",") != "req2.json,req3.json,req4.json" {
+		t.Errorf("kept %v, want the three newest", names)
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"compress/gzip"
 	"crypto/sha256"
 	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -16,18 +18,28 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/prysmsh/pkg/tlsutil"
 	"github.com/spf13/cobra"
 
+	"github.com/prysmsh/cli/internal/config"
+	"github.com/prysmsh/cli/internal/minisign"
 	"github.com/prysmsh/cli/internal/style"
 	"github.com/prysmsh/cli/internal/ui"
+	"github.com/prysmsh/cli/internal/util"
 )
 
 // githubRelease is the subset of the GitHub releases API we care about.
 type githubRelease struct {
-	TagName string        `json:"tag_name"`
-	Assets  []githubAsset `json:"assets"`
+	TagName     string        `json:"tag_name"`
+	Body        string        `json:"body"`
+	PublishedAt time.Time     `json:"published_at"`
+	Prerelease  bool          `json:"prerelease"`
+	Draft       bool          `json:"draft"`
+	Assets      []githubAsset `json:"assets"`
 }
 
 type githubAsset struct {
@@ -35,29 +47,252 @@ type githubAsset struct {
 	BrowserDownloadURL string `json:"browser_download_url"`
 }
 
-const releasesURL = "https://api.github.com/repos/prysmsh/cli/releases/latest"
+const defaultReleasesURL = "https://api.github.com/repos/prysmsh/cli/releases"
+
+// releasesURL and updateHTTPClient are set from config by configureUpdateSource.
+var (
+	releasesURL      = defaultReleasesURL
+	updateHTTPClient = &http.Client{Timeout: 5 * time.Minute}
+)
+
+// Release channels. Stable follows GitHub's latest release, beta adds
+// pre-releases (beta, rc) and nightly follows every published build.
+const (
+	channelStable  = "stable"
+	channelBeta    = "beta"
+	channelNightly = "nightly"
+)
+
+var updateChannels = []string{channelStable, channelBeta, channelNightly}
+
+// updateOptions are the flags of `prysm update`.
+type updateOptions struct {
+	CheckOnly  bool
+	Channel    string
+	Version    string // install this release instead of the newest on Channel
+	SkipVerify bool
+	Yes        bool // skip the downgrade confirmation
+	Sudo       bool // install with sudo when the binary's directory is not writable
+}
 
 func newUpdateCommand() *cobra.Command {
-	var checkOnly bool
+	var (
+		opts         updateOptions
+		channel      string
+		list         bool
+		outputFormat string
+	)
 
 	cmd := &cobra.Command{
 		Use:   "update",
 		Short: "Update prysm to the latest release",
 		Long: `Check for and install the latest release of the Prysm CLI from GitHub.
 
-Use --check to see if an update is available without installing it.`,
+Use --check to see if an update is available without installing it.
+
+Releases are verified before installing: the archive must match the release's
+SHA256SUMS, and SHA256SUMS must carry a valid minisign signature from the Prysm
+release key.
+
+Use --channel to follow beta or nightly pre-releases instead of stable releases.
+The choice is saved as update.channel in config.yaml.
+
+Use --version to install a specific release, e.g. to roll back a bad release.
+Downgrades ask for confirmation unless --yes is passed. --list shows recent
+releases.
+
+Set update.releases_url in config.yaml (or PRYSM_UPDATE_URL) to a GitHub-compatible
+releases API, such as a GitHub Enterprise repository or an internal mirror, and
+update.ca_file to trust a private CA. HTTPS_PROXY and NO_PROXY are honored.
+
+Installs managed by Homebrew, npm, deb or rpm are not replaced in place; the
+matching upgrade command is printed instead. When the binary lives in a
+root-owned directory, pass --sudo to install the verified binary with sudo.`,
+		Example: `  prysm update
+  prysm update --check
+  prysm update --channel beta
+  prysm update --list
+  prysm update --version v1.3.2`,
 		// Skip app init — update works without Prysm config/auth.
 		PersistentPreRunE: func(*cobra.Command, []string) error { return nil },
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runUpdate(checkOnly)
+			cfgPath, cfg := loadUpdateConfig()
+			if err := configureUpdateSource(cfg); err != nil {
+				return err
+			}
+			if list {
+				return listReleases(outputFormat)
+			}
+
+			selected := strings.ToLower(strings.TrimSpace(cfg.Update.Channel))
+			if cmd.Flags().Changed("channel") {
+				selected = strings.ToLower(strings.TrimSpace(channel))
+			}
+			if selected == "" {
+				selected = channelStable
+			}
+			if !isUpdateChannel(selected) {
+				return fmt.Errorf("unknown update channel %q (valid: %s)", selected, strings.Join(updateChannels, ", "))
+			}
+			if cmd.Flags().Changed("channel") && !strings.EqualFold(cfg.Update.Channel, selected) {
+				if err := config.SetFileValue(cfgPath, "update.channel", selected); err != nil {
+					return fmt.Errorf("save update channel: %w", err)
+				}
+				fmt.Println(style.MutedStyle.Render(fmt.Sprintf("Update channel set to %s.", selected)))
+			}
+			opts.Channel = selected
+			return runUpdate(opts)
 		},
 	}
 
-	cmd.Flags().BoolVar(&checkOnly, "check", false, "check for updates without installing")
+	cmd.Flags().BoolVar(&opts.CheckOnly, "check", false, "check for updates without installing")
+	cmd.Flags().StringVar(&channel, "channel", "", "release channel to follow: stable, beta or nightly (saved in config)")
+	cmd.Flags().StringVar(&opts.Version, "version", "", "install a specific release (e.g. v1.3.2), including downgrades")
+	cmd.Flags().BoolVarP(&opts.Yes, "yes", "y", false, "do not ask for confirmation before a downgrade")
+	cmd.Flags().BoolVar(&opts.Sudo, "sudo", false, "install with sudo when the binary is in a root-owned directory")
+	cmd.Flags().BoolVar(&list, "list", false, "list recent releases")
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "", "output format for --list (table, json)")
+	cmd.Flags().BoolVar(&opts.SkipVerify, "insecure-skip-verify-release", false, "install releases without a signed checksum file (not recommended)")
+	_ = cmd.RegisterFlagCompletionFunc("channel", cobra.FixedCompletions(updateChannels, cobra.ShellCompDirectiveNoFileComp))
 	return cmd
 }
 
-func runUpdate(checkOnly bool) error {
+// listReleases prints recent releases with their channel and first line of notes.
+func listReleases(outputFormat string) error {
+	var rels []githubRelease
+	if err := ui.WithSpinner("Fetching releases...", func() error {
+		return fetchReleaseJSON(releasesURL+"?per_page=20", &rels)
+	}); err != nil {
+		return fmt.Errorf("list releases: %w", err)
+	}
+
+	type releaseRow struct {
+		Version   string    `json:"version"`
+		Channel   string    `json:"channel"`
+		Published time.Time `json:"published_at"`
+		Notes     string    `json:"notes"`
+		Current   bool      `json:"current"`
+	}
+	var out []releaseRow
+	for _, r := range rels {
+		if r.Draft {
+			continue
+		}
+		out = append(out, releaseRow{
+			Version:   strings.TrimPrefix(r.TagName, "v"),
+			Channel:   releaseChannel(r),
+			Published: r.PublishedAt,
+			Notes:     releaseSummary(r.Body),
+			Current:   strings.TrimPrefix(r.TagName, "v") == strings.TrimPrefix(version, "v"),
+		})
+	}
+
+	if wantsJSONOutput(outputFormat) {
+		if out == nil {
+			out = []releaseRow{}
+		}
+		return writeJSON(out)
+	}
+	if len(out) == 0 {
+		fmt.Println(style.MutedStyle.Render("No releases found."))
+		return nil
+	}
+	rows := make([][]string, 0, len(out))
+	for _, r := range out {
+		v := "v" + r.Version
+		if r.Current {
+			v = style.Success.Render(v + " (current)")
+		}
+		published := ""
+		if !r.Published.IsZero() {
+			published = r.Published.Local().Format("2006-01-02")
+		}
+		rows = append(rows, []string{v, r.Channel, published, util.TruncateString(r.Notes, 60)})
+	}
+	ui.PrintTable([]string{"VERSION", "CHANNEL", "PUBLISHED", "NOTES"}, rows)
+	return nil
+}
+
+// releaseChannel returns the narrowest channel that offers rel.
+func releaseChannel(rel githubRelease) string {
+	for _, c := range updateChannels {
+		if releaseInChannel(rel, c) {
+			return c
+		}
+	}
+	return channelNightly
+}
+
+// releaseSummary returns the first meaningful line of release notes, skipping
+// markdown headings and generated boilerplate.
+func releaseSummary(body string) string {
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "_Generated") || strings.HasPrefix(line, "|") {
+			continue
+		}
+		return strings.TrimLeft(line, "-* ")
+	}
+	return ""
+}
+
+// loadUpdateConfig reads config.yaml for update settings. Update must keep
+// working with a broken config, so load errors fall back to defaults.
+func loadUpdateConfig() (string, *config.Config) {
+	cfgPath, err := resolveConfigPath()
+	if err != nil {
+		return "", &config.Config{}
+	}
+	cfg, err := config.Load(cfgPath, activeProfile)
+	if err != nil {
+		printDebug("load config for update: %v", err)
+		return cfgPath, &config.Config{}
+	}
+	return cfgPath, cfg
+}
+
+// configureUpdateSource points the updater at the configured releases API
+// (update.releases_url) and trusts update.ca_file in addition to the system
+// roots. Proxies come from HTTPS_PROXY/HTTP_PROXY/NO_PROXY.
+func configureUpdateSource(cfg *config.Config) error {
+	releasesURL = defaultReleasesURL
+	if cfg.Update.ReleasesURL != "" {
+		releasesURL = strings.TrimRight(cfg.Update.ReleasesURL, "/")
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+	tlsConfig := &tls.Config{}
+	if cfg.Update.CAFile != "" {
+		pem, err := os.ReadFile(cfg.Update.CAFile)
+		if err != nil {
+			return fmt.Errorf("read update CA file: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificates found in update CA file %s", cfg.Update.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	tlsutil.ApplyPQCConfig(tlsConfig)
+	transport.TLSClientConfig = tlsConfig
+	updateHTTPClient = &http.Client{Timeout: 5 * time.Minute, Transport: transport}
+	return nil
+}
+
+func isUpdateChannel(channel string) bool {
+	for _, c := range updateChannels {
+		if c == channel {
+			return true
+		}
+	}
+	return false
+}
+
+func runUpdate(opts updateOptions) error {
 	currentVersion := version
 	if currentVersion == "dev" || currentVersion == "" {
 		fmt.Println(style.Warning.Render("Running a dev build — cannot determine current version."))
@@ -66,9 +301,21 @@ func runUpdate(checkOnly bool) error {
 	}
 
 	var rel *githubRelease
-	if err := ui.WithSpinner("Checking for updates...", func() error {
+	if opts.Version != "" {
+		tag := "v" + strings.TrimPrefix(strings.TrimSpace(opts.Version), "v")
+		if _, err := parseSemver(tag); err != nil {
+			return fmt.Errorf("invalid --version %q: %w", opts.Version, err)
+		}
+		if err := ui.WithSpinner(fmt.Sprintf("Fetching release %s...", tag), func() error {
+			var fetchErr error
+			rel, fetchErr = fetchReleaseByTag(tag)
+			return fetchErr
+		}); err != nil {
+			return fmt.Errorf("fetch release %s: %w", tag, err)
+		}
+	} else if err := ui.WithSpinner("Checking for updates...", func() error {
 		var fetchErr error
-		rel, fetchErr = fetchLatestRelease()
+		rel, fetchErr = fetchLatestRelease(opts.Channel)
 		return fetchErr
 	}); err != nil {
 		return fmt.Errorf("check for updates: %w", err)
@@ -81,17 +328,65 @@ func runUpdate(checkOnly bool) error {
 		return fmt.Errorf("compare versions: %w", err)
 	}
 
-	if cmp >= 0 {
+	// Only an explicit --version may move backwards.
+	if cmp == 0 || (cmp > 0 && opts.Version == "") {
 		fmt.Println(style.Success.Render(fmt.Sprintf("Already up to date (v%s).", currentVersion)))
 		return nil
 	}
 
-	if checkOnly {
+	selfPath, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("locate current binary: %w", err)
+	}
+	selfPath, err = filepath.EvalSymlinks(selfPath)
+	if err != nil {
+		return fmt.Errorf("resolve binary path: %w", err)
+	}
+
+	// Replacing a package-managed binary would leave the package database
+	// out of sync; hand off to the package manager instead.
+	if m := detectInstallMethod(selfPath, rel); m != nil {
+		fmt.Println(style.Warning.Render(fmt.Sprintf("Update available: v%s → v%s", currentVersion, latestVersion)))
+		fmt.Println(style.Info.Render(fmt.Sprintf("prysm was installed with %s; upgrade it with:", m.Name)))
+		fmt.Println("  " + m.Upgrade)
+		return nil
+	}
+
+	if opts.CheckOnly {
+		if cmp > 0 {
+			fmt.Println(style.Warning.Render(fmt.Sprintf("Downgrade available: v%s → v%s", currentVersion, latestVersion)))
+			fmt.Println(style.Info.Render(fmt.Sprintf("Run 'prysm update --version v%s' to install.", latestVersion)))
+			return nil
+		}
 		fmt.Println(style.Warning.Render(fmt.Sprintf("Update available: v%s → v%s", currentVersion, latestVersion)))
 		fmt.Println(style.Info.Render("Run 'prysm update' to install."))
 		return nil
 	}
 
+	// Everything up to the final copy runs as the invoking user, so the
+	// user's config (channel, mirror, CA) applies and migrations touch only
+	// files the user owns.
+	var sudo string
+	if !dirWritable(filepath.Dir(selfPath)) {
+		if !opts.Sudo {
+			return fmt.Errorf("cannot write to %s; rerun with --sudo (or as a user that owns the binary)", filepath.Dir(selfPath))
+		}
+		if sudo, err = sudoPath(); err != nil {
+			return err
+		}
+	}
+
+	if cmp > 0 && !opts.Yes {
+		ok, err := util.PromptConfirm(fmt.Sprintf("Downgrade from v%s to v%s?", currentVersion, latestVersion), false)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println(style.MutedStyle.Render("Downgrade cancelled."))
+			return nil
+		}
+	}
+
 	// Find the right asset for this OS/arch.
 	assetName := buildAssetName(latestVersion, runtime.GOOS, runtime.GOARCH)
 	var downloadURL string
@@ -107,45 +402,18 @@ func runUpdate(checkOnly bool) error {
 
 	var archiveData []byte
 	if err := ui.WithSpinner(fmt.Sprintf("Downloading v%s...", latestVersion), func() error {
-		resp, err := http.Get(downloadURL)
+		var err error
+		archiveData, err = fetchReleaseAsset(downloadURL)
 		if err != nil {
 			return fmt.Errorf("download release: %w", err)
 		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode != http.StatusOK {
-			return fmt.Errorf("download release: HTTP %d", resp.StatusCode)
-		}
-
-		archiveData, err = io.ReadAll(resp.Body)
-		if err != nil {
-			return fmt.Errorf("read release archive: %w", err)
-		}
 		return nil
 	}); err != nil {
 		return err
 	}
 
-	archiveHash := sha256.Sum256(archiveData)
-	archiveHashHex := hex.EncodeToString(archiveHash[:])
-
-	// Verify archive checksum if available.
-	checksumURL, checksumAssetName := findChecksumAsset(rel.Assets)
-	if checksumURL != "" {
-		checksums, err := fetchChecksums(checksumURL)
-		if err != nil {
-			return fmt.Errorf("fetch checksums: %w", err)
-		}
-		expectedHash, ok := checksums[assetName]
-		if !ok {
-			return fmt.Errorf("no checksum found for %s in %s", assetName, checksumAssetName)
-		}
-		if err := verifyChecksum(archiveData, expectedHash); err != nil {
-			return fmt.Errorf("integrity check failed for %s: %w", assetName, err)
-		}
-		fmt.Println(style.Success.Render(fmt.Sprintf("Checksum verified (%s): %s", checksumAssetName, archiveHashHex)))
-	} else {
-		fmt.Println(style.Warning.Render("No checksum file found in release — skipping integrity check."))
+	if err := verifyReleaseArchive(rel, assetName, archiveData, opts.SkipVerify); err != nil {
+		return err
 	}
 
 	binaryData, err := extractBinary(archiveData, assetName)
@@ -153,20 +421,21 @@ func runUpdate(checkOnly bool) error {
 		return fmt.Errorf("extract binary: %w", err)
 	}
 
-	selfPath, err := os.Executable()
-	if err != nil {
-		return fmt.Errorf("locate current binary: %w", err)
+	if sudo != "" {
+		err = sudoReplaceBinary(sudo, selfPath, binaryData)
+	} else {
+		err = atomicReplaceBinary(selfPath, binaryData)
 	}
-	selfPath, err = filepath.EvalSymlinks(selfPath)
 	if err != nil {
-		return fmt.Errorf("resolve binary path: %w", err)
-	}
-
-	if err := atomicReplaceBinary(selfPath, binaryData); err != nil {
 		return fmt.Errorf("replace binary: %w", err)
 	}
 
+	if cmp > 0 {
+		fmt.Println(style.Success.Render(fmt.Sprintf("Downgraded to v%s.", latestVersion)))
+		return nil
+	}
 	fmt.Println(style.Success.Render(fmt.Sprintf("Updated to v%s.", latestVersion)))
+	runPostUpdateMigrations(selfPath)
 	return nil
 }
 
@@ -189,24 +458,95 @@ func findChecksumAsset(assets []githubAsset) (string, string) {
 	return "", ""
 }
 
-// fetchChecksums downloads a SHA256SUMS file and parses it into a filename→hash map.
-func fetchChecksums(url string) (map[string]string, error) {
-	resp, err := http.Get(url)
+// releasePublicKey is the minisign public key (the base64 line of
+// minisign.pub) that signs release SHA256SUMS files.
+// scripts/release_artifacts.sh refuses to sign a release with any other key.
+const releasePublicKey = ""
+
+// releaseSigningKey is the key releases are verified against. Builds for a
+// mirror signed with its own key can override it with
+// -ldflags "-X github.com/prysmsh/cli/internal/cmd.releaseSigningKey=<key>".
+var releaseSigningKey = releasePublicKey
+
+// minisigSuffix names the detached signature of a release asset.
+const minisigSuffix = ".minisig"
+
+// verifyReleaseArchive checks archive against the release's checksum file and
+// the checksum file against its minisign signature (<checksums>.minisig). A
+// missing checksum file, signature or signing key is an error unless
+// skipVerify is set; a checksum or signature that is present but wrong is
+// always an error.
+func verifyReleaseArchive(rel *githubRelease, assetName string, archive []byte, skipVerify bool) error {
+	checksumURL, checksumAssetName := findChecksumAsset(rel.Assets)
+	if checksumURL == "" {
+		if !skipVerify {
+			return fmt.Errorf("release %s has no checksum file; refusing to install (use --insecure-skip-verify-release to override)", rel.TagName)
+		}
+		fmt.Println(style.Warning.Render("No checksum file found in release — skipping integrity check."))
+		return nil
+	}
+
+	sumsData, err := fetchReleaseAsset(checksumURL)
 	if err != nil {
-		return nil, fmt.Errorf("download checksums: %w", err)
+		return fmt.Errorf("fetch checksums: %w", err)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("download checksums: HTTP %d", resp.StatusCode)
+	var sigURL string
+	for _, a := range rel.Assets {
+		if a.Name == checksumAssetName+minisigSuffix {
+			sigURL = a.BrowserDownloadURL
+			break
+		}
+	}
+	switch {
+	case sigURL == "":
+		if !skipVerify {
+			return fmt.Errorf("release %s is not signed (no %s%s); refusing to install (use --insecure-skip-verify-release to override)", rel.TagName, checksumAssetName, minisigSuffix)
+		}
+		fmt.Println(style.Warning.Render("Release is not signed — skipping signature check."))
+	case releaseSigningKey == "":
+		if !skipVerify {
+			return fmt.Errorf("this build has no release signing key to verify %s; use --insecure-skip-verify-release to override", checksumAssetName)
+		}
+		fmt.Println(style.Warning.Render("No release signing key in this build — skipping signature check."))
+	default:
+		sig, err := fetchReleaseAsset(sigURL)
+		if err != nil {
+			return fmt.Errorf("fetch checksum signature: %w", err)
+		}
+		if err := minisign.Verify([]string{releaseSigningKey}, sumsData, sig); err != nil {
+			return fmt.Errorf("verify %s signature: %w", checksumAssetName, err)
+		}
+		fmt.Println(style.Success.Render(fmt.Sprintf("Signature verified (%s%s).", checksumAssetName, minisigSuffix)))
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	checksums, err := parseChecksums(string(sumsData))
 	if err != nil {
-		return nil, fmt.Errorf("read checksums: %w", err)
+		return fmt.Errorf("parse %s: %w", checksumAssetName, err)
 	}
+	expectedHash, ok := checksums[assetName]
+	if !ok {
+		return fmt.Errorf("no checksum found for %s in %s", assetName, checksumAssetName)
+	}
+	if err := verifyChecksum(archive, expectedHash); err != nil {
+		return fmt.Errorf("integrity check failed for %s: %w", assetName, err)
+	}
+	fmt.Println(style.Success.Render(fmt.Sprintf("Checksum verified (%s): %s", checksumAssetName, expectedHash)))
+	return nil
+}
+
+// fetchReleaseAsset downloads a release asset into memory.
+func fetchReleaseAsset(url string) ([]byte, error) {
+	resp, err := updateHTTPClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
 
-	return parseChecksums(string(body))
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
 }
 
 // parseChecksums parses checksum lines into a filename->hash map.
@@ -270,29 +610,97 @@ func verifyChecksum(data []byte, expectedHex string) error {
 	return nil
 }
 
-func fetchLatestRelease() (*githubRelease, error) {
-	req, err := http.NewRequest("GET", releasesURL, nil)
-	if err != nil {
+// fetchLatestRelease returns the newest release on channel. Stable uses
+// GitHub's latest release; other channels pick the highest version among
+// recent releases.
+func fetchLatestRelease(channel string) (*githubRelease, error) {
+	if channel == channelStable || channel == "" {
+		var rel githubRelease
+		if err := fetchReleaseJSON(releasesURL+"/latest", &rel); err != nil {
+			return nil, err
+		}
+		return &rel, nil
+	}
+
+	var rels []githubRelease
+	if err := fetchReleaseJSON(releasesURL+"?per_page=50", &rels); err != nil {
+		return nil, err
+	}
+	rel := selectChannelRelease(rels, channel)
+	if rel == nil {
+		return nil, fmt.Errorf("no releases found on the %s channel", channel)
+	}
+	return rel, nil
+}
+
+// fetchReleaseByTag returns the release tagged tag (e.g. "v1.3.2").
+func fetchReleaseByTag(tag string) (*githubRelease, error) {
+	var rel githubRelease
+	if err := fetchReleaseJSON(releasesURL+"/tags/"+tag, &rel); err != nil {
 		return nil, err
 	}
+	return &rel, nil
+}
+
+// releaseInChannel reports whether rel should be offered on channel.
+func releaseInChannel(rel githubRelease, channel string) bool {
+	if rel.Draft {
+		return false
+	}
+	switch channel {
+	case channelNightly:
+		return true
+	case channelBeta:
+		return !strings.Contains(strings.ToLower(rel.TagName), "nightly")
+	default:
+		return !rel.Prerelease
+	}
+}
+
+// selectChannelRelease returns the highest-versioned release on channel.
+// Releases with unparsable tags are ignored.
+func selectChannelRelease(rels []githubRelease, channel string) *githubRelease {
+	var best *githubRelease
+	for i := range rels {
+		if !releaseInChannel(rels[i], channel) {
+			continue
+		}
+		if _, err := parseSemver(rels[i].TagName); err != nil {
+			continue
+		}
+		if best == nil {
+			best = &rels[i]
+			continue
+		}
+		if cmp, _ := compareSemver(rels[i].TagName, best.TagName); cmp > 0 {
+			best = &rels[i]
+		}
+	}
+	return best
+}
+
+func fetchReleaseJSON(url string, v interface{}) error {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return err
+	}
 	req.Header.Set("Accept", "application/vnd.github+json")
 	req.Header.Set("User-Agent", "prysm-cli/updater")
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := updateHTTPClient.Do(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("GitHub API returned HTTP %d", resp.StatusCode)
+		return fmt.Errorf("GitHub API returned HTTP %d", resp.StatusCode)
 	}
 
-	var rel githubRelease
-	if err := json.NewDecoder(resp.Body).Decode(&rel); err != nil {
-		return nil, fmt.Errorf("parse release JSON: %w", err)
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("parse release JSON: %w", err)
 	}
-	return &rel, nil
+	return nil
 }
 
 // buildAssetName returns the expected archive filename for a given release version,
@@ -414,19 +822,24 @@ func atomicReplaceBinary(targetPath string, newBinary []byte) error {
 	return nil
 }
 
-// semver is a parsed MAJOR.MINOR.PATCH version.
+// semver is a parsed MAJOR.MINOR.PATCH[-PRERELEASE] version.
 type semver struct {
 	Major, Minor, Patch int
+	Pre                 string // pre-release identifiers, e.g. "beta.1"
 }
 
-// parseSemver parses a "MAJOR.MINOR.PATCH" string (with optional "v" prefix).
+// parseSemver parses a "MAJOR.MINOR.PATCH" string (with optional "v" prefix,
+// "-prerelease" suffix and ignored "+build" metadata).
 func parseSemver(s string) (semver, error) {
 	s = strings.TrimPrefix(s, "v")
+	core, _, _ := strings.Cut(s, "+")
+	core, pre, _ := strings.Cut(core, "-")
 	var v semver
-	n, err := fmt.Sscanf(s, "%d.%d.%d", &v.Major, &v.Minor, &v.Patch)
+	n, err := fmt.Sscanf(core, "%d.%d.%d", &v.Major, &v.Minor, &v.Patch)
 	if err != nil || n != 3 {
 		return semver{}, fmt.Errorf("invalid semver: %q", s)
 	}
+	v.Pre = pre
 	return v, nil
 }
 
@@ -446,9 +859,45 @@ func compareSemver(a, b string) (int, error) {
 		return cmpInt(va.Major, vb.Major), nil
 	case va.Minor != vb.Minor:
 		return cmpInt(va.Minor, vb.Minor), nil
-	default:
+	case va.Patch != vb.Patch:
 		return cmpInt(va.Patch, vb.Patch), nil
+	default:
+		return comparePrerelease(va.Pre, vb.Pre), nil
+	}
+}
+
+// comparePrerelease orders pre-release strings per semver: a release sorts
+// after its pre-releases, numeric identifiers compare numerically and sort
+// before alphanumeric ones, and a shorter identifier list sorts first.
+func comparePrerelease(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+	pa, pb := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(pa) && i < len(pb); i++ {
+		na, errA := strconv.Atoi(pa[i])
+		nb, errB := strconv.Atoi(pb[i])
+		switch {
+		case errA == nil && errB == nil:
+			if na != nb {
+				return cmpInt(na, nb)
+			}
+		case errA == nil:
+			return -1
+		case errB == nil:
+			return 1
+		default:
+			if c := strings.Compare(pa[i], pb[i]); c != 0 {
+				return c
+			}
+		}
 	}
+	return cmpInt(len(pa), len(pb))
 }
 
 func cmpInt(a, b int) int {
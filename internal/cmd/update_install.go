@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// installMethod describes a package manager that owns the running binary.
+type installMethod struct {
+	Name    string // homebrew, npm, deb, rpm
+	Upgrade string // command that upgrades this install
+}
+
+// dpkgInfoDir holds dpkg file lists; a var so tests can point it elsewhere.
+var dpkgInfoDir = "/var/lib/dpkg/info"
+
+// rpmOwns reports whether rpm manages path; a var so tests can stub it.
+var rpmOwns = func(path string) bool {
+	if _, err := exec.LookPath("rpm"); err != nil {
+		return false
+	}
+	return exec.Command("rpm", "-qf", path).Run() == nil
+}
+
+// detectInstallMethod returns the package manager that installed binPath, or
+// nil for a standalone install that self-update may replace. rel is the
+// release being installed, used to find deb/rpm download URLs.
+func detectInstallMethod(binPath string, rel *githubRelease) *installMethod {
+	ver := strings.TrimPrefix(rel.TagName, "v")
+	p := filepath.ToSlash(binPath)
+	switch {
+	case strings.Contains(p, "/Cellar/") || strings.HasPrefix(p, "/opt/homebrew/") || strings.HasPrefix(p, "/home/linuxbrew/.linuxbrew/"):
+		return &installMethod{Name: "homebrew", Upgrade: "brew upgrade prysm"}
+	case strings.Contains(p, "/node_modules/"):
+		return &installMethod{Name: "npm", Upgrade: "npm install -g @prysmsh/cli@" + npmVersion(ver)}
+	}
+	if runtime.GOOS != "linux" {
+		return nil
+	}
+	if dpkgOwns(binPath) {
+		asset := fmt.Sprintf("prysm-cli_%s_%s.deb", ver, runtime.GOARCH)
+		return &installMethod{Name: "deb", Upgrade: fmt.Sprintf("curl -fsSLO %s && sudo dpkg -i %s", releaseDownloadURL(rel, asset), asset)}
+	}
+	if rpmOwns(binPath) {
+		arch := runtime.GOARCH
+		switch arch {
+		case "amd64":
+			arch = "x86_64"
+		case "arm64":
+			arch = "aarch64"
+		}
+		asset := fmt.Sprintf("prysm-cli-%s-1.%s.rpm", ver, arch)
+		return &installMethod{Name: "rpm", Upgrade: "sudo rpm -U " + releaseDownloadURL(rel, asset)}
+	}
+	return nil
+}
+
+func npmVersion(ver string) string {
+	if ver == "" {
+		return "latest"
+	}
+	return ver
+}
+
+// releaseDownloadURL returns the download URL of a release asset, falling
+// back to github.com when the release does not list it.
+func releaseDownloadURL(rel *githubRelease, asset string) string {
+	for _, a := range rel.Assets {
+		if a.Name == asset {
+			return a.BrowserDownloadURL
+		}
+	}
+	return fmt.Sprintf("https://github.com/prysmsh/cli/releases/download/%s/%s", rel.TagName, asset)
+}
+
+// dpkgOwns reports whether any dpkg package lists path among its files.
+func dpkgOwns(path string) bool {
+	lists, _ := filepath.Glob(filepath.Join(dpkgInfoDir, "prysm*.list"))
+	for _, list := range lists {
+		f, err := os.Open(list)
+		if err != nil {
+			continue
+		}
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			if strings.TrimSpace(scanner.Text()) == path {
+				f.Close()
+				return true
+			}
+		}
+		f.Close()
+	}
+	return false
+}
+
+// dirWritable reports whether a file can be created next to the binary,
+// which atomicReplaceBinary needs.
+func dirWritable(dir string) bool {
+	f, err := os.CreateTemp(dir, ".prysm-update-check-*")
+	if err != nil {
+		return false
+	}
+	name := f.Name()
+	f.Close()
+	os.Remove(name)
+	return true
+}
+
+// sudoPath returns the sudo binary used to install into a root-owned
+// directory.
+func sudoPath() (string, error) {
+	if runtime.GOOS == "windows" {
+		return "", errors.New("--sudo is not supported on Windows; run the update from an elevated prompt")
+	}
+	sudo, err := exec.LookPath("sudo")
+	if err != nil {
+		return "", errors.New("sudo not found; rerun the update as a user that can write the binary")
+	}
+	return sudo, nil
+}
+
+// sudoReplaceBinary installs the already verified binary over targetPath
+// with sudo. Only the copy into place runs privileged: the new binary is
+// staged next to the target and renamed over it, so the swap is atomic.
+func sudoReplaceBinary(sudo, targetPath string, newBinary []byte) error {
+	tmp, err := os.CreateTemp("", "prysm-update-*")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(newBinary); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close temp file: %w", err)
+	}
+
+	staged := targetPath + ".update-new"
+	for i, args := range sudoReplaceCommands(tmp.Name(), staged, targetPath) {
+		cmd := exec.Command(sudo, args...)
+		cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+		if err := cmd.Run(); err != nil {
+			if i > 0 {
+				_ = exec.Command(sudo, "rm", "-f", staged).Run()
+			}
+			return fmt.Errorf("sudo %s: %w", args[0], err)
+		}
+	}
+	return nil
+}
+
+// sudoReplaceCommands returns the privileged commands that copy src to
+// staged, next to target, and move it into place.
+func sudoReplaceCommands(src, staged, target string) [][]string {
+	return [][]string{
+		{"install", "-m", "0755", src, staged},
+		{"mv", "-f", staged, target},
+	}
+}
+
+// runPostUpdateMigrations lets the new binary migrate config and state now
+// rather than on its first regular command. Failures are not fatal: the
+// migration is retried on the next run.
+func runPostUpdateMigrations(selfPath string) {
+	migrate := exec.Command(selfPath, "migrate")
+	migrate.Stdout, migrate.Stderr = os.Stdout, os.Stderr
+	if err := migrate.Run(); err != nil {
+		printDebug("post-update migrate: %v", err)
+	}
+}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/prysmsh/cli/internal/style"
+)
+
+// updateCheckInterval rate-limits the background release check.
+const updateCheckInterval = 24 * time.Hour
+
+// updateCheckWait bounds how long a finished command waits for an in-flight
+// check before falling back to the cached result.
+const updateCheckWait = 300 * time.Millisecond
+
+// updateCheckCache is stored in $PRYSM_HOME/cache/update-check.json.
+type updateCheckCache struct {
+	CheckedAt time.Time `json:"checked_at"`
+	Channel   string    `json:"channel"`
+	Latest    string    `json:"latest,omitempty"`
+}
+
+// pendingUpdateCheck holds the state of the check started for this invocation.
+var pendingUpdateCheck *updateCheck
+
+type updateCheck struct {
+	cached string      // latest version from the previous check
+	result chan string // fresh result, if a check is in flight
+}
+
+func updateCheckPath(homeDir string) string {
+	return filepath.Join(homeDir, "cache", "update-check.json")
+}
+
+// startUpdateCheck refreshes the latest-release cache in the background when it
+// is older than a day. The notice itself is printed by printUpdateNotice.
+func startUpdateCheck(cmd *cobra.Command) {
+	if !updateCheckEnabled(cmd) {
+		return
+	}
+	if err := configureUpdateSource(app.Config); err != nil {
+		printDebug("update check: %v", err)
+		return
+	}
+	path := updateCheckPath(app.Config.HomeDir)
+	channel := strings.ToLower(strings.TrimSpace(app.Config.Update.Channel))
+	if channel == "" || !isUpdateChannel(channel) {
+		channel = channelStable
+	}
+
+	var cache updateCheckCache
+	if data, err := os.ReadFile(path); err == nil {
+		_ = json.Unmarshal(data, &cache)
+	}
+	check := &updateCheck{}
+	if cache.Channel == channel {
+		check.cached = cache.Latest
+	}
+	pendingUpdateCheck = check
+	if cache.Channel == channel && time.Since(cache.CheckedAt) < updateCheckInterval {
+		return
+	}
+
+	// Record the attempt first so offline machines and short-lived commands
+	// don't retry on every invocation.
+	cache = updateCheckCache{CheckedAt: time.Now(), Channel: channel, Latest: check.cached}
+	_ = writeUpdateCheckCache(path, cache)
+
+	check.result = make(chan string, 1)
+	go func() {
+		rel, err := fetchLatestRelease(channel)
+		if err != nil {
+			printDebug("update check: %v", err)
+			check.result <- ""
+			return
+		}
+		cache.Latest = strings.TrimPrefix(rel.TagName, "v")
+		_ = writeUpdateCheckCache(path, cache)
+		check.result <- cache.Latest
+	}()
+}
+
+// updateCheckEnabled reports whether this invocation should check for
+// updates. Dev builds, machine-readable output, non-interactive stderr and
+// explicit opt-outs (update.check: false, PRYSM_NO_UPDATE_CHECK) skip it.
+func updateCheckEnabled(cmd *cobra.Command) bool {
+	if version == "dev" || version == "" || app == nil {
+		return false
+	}
+	if os.Getenv("PRYSM_NO_UPDATE_CHECK") != "" || !app.Config.UpdateCheckEnabled() {
+		return false
+	}
+	if isCompletionCommand() || wantsJSONOutput("") || !term.IsTerminal(int(os.Stderr.Fd())) {
+		return false
+	}
+	for c := cmd; c != nil; c = c.Parent() {
+		if c.Name() == "update" || c.Name() == cobra.ShellCompRequestCmd || c.Name() == cobra.ShellCompNoDescRequestCmd {
+			return false
+		}
+	}
+	return true
+}
+
+// printUpdateNotice prints a one-line notice on stderr when a newer release
+// is known.
+func printUpdateNotice() {
+	check := pendingUpdateCheck
+	if check == nil {
+		return
+	}
+	pendingUpdateCheck = nil
+
+	latest := check.cached
+	if check.result != nil {
+		select {
+		case fresh := <-check.result:
+			if fresh != "" {
+				latest = fresh
+			}
+		case <-time.After(updateCheckWait):
+		}
+	}
+	if latest == "" {
+		return
+	}
+	if cmp, err := compareSemver(version, latest); err != nil || cmp >= 0 {
+		return
+	}
+	fmt.Fprintln(os.Stderr, style.MutedStyle.Render(fmt.Sprintf("v%s available (current v%s), run 'prysm update'", latest, strings.TrimPrefix(version, "v"))))
+}
+
+func writeUpdateCheckCache(path string, cache updateCheckCache) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
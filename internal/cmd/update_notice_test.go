@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func captureUpdateNotice(t *testing.T, check *updateCheck) string {
+	t.Helper()
+	oldErr := os.Stderr
+	r, w, _ := os.Pipe()
+	os.Stderr = w
+	pendingUpdateCheck = check
+	printUpdateNotice()
+	w.Close()
+	os.Stderr = oldErr
+	out, _ := io.ReadAll(r)
+	return string(out)
+}
+
+func TestPrintUpdateNotice(t *testing.T) {
+	oldVersion := version
+	version = "1.3.0"
+	defer func() { version = oldVersion }()
+
+	if out := captureUpdateNotice(t, &updateCheck{cached: "1.4.0"}); !strings.Contains(out, "v1.4.0 available") {
+		t.Errorf("cached newer release: notice = %q", out)
+	}
+	if out := captureUpdateNotice(t, &updateCheck{cached: "1.3.0"}); out != "" {
+		t.Errorf("up to date: notice = %q", out)
+	}
+
+	fresh := make(chan string, 1)
+	fresh <- "1.5.0"
+	if out := captureUpdateNotice(t, &updateCheck{cached: "1.4.0", result: fresh}); !strings.Contains(out, "v1.5.0 available") {
+		t.Errorf("fresh result should win: notice = %q", out)
+	}
+
+	failed := make(chan string, 1)
+	failed <- ""
+	if out := captureUpdateNotice(t, &updateCheck{cached: "1.4.0", result: failed}); !strings.Contains(out, "v1.4.0 available") {
+		t.Errorf("failed check should fall back to cache: notice = %q", out)
+	}
+
+	if out := captureUpdateNotice(t, nil); out != "" {
+		t.Errorf("no check: notice = %q", out)
+	}
+}
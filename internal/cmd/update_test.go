@@ -5,10 +5,22 @@ import (
 	"archive/zip"
 	"bytes"
 	"compress/gzip"
+	"crypto/ed25519"
+	"crypto/rand"
 	"crypto/sha256"
+	"encoding/pem"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
 	"strings"
 	"testing"
+
+	"github.com/prysmsh/cli/internal/config"
+	"github.com/prysmsh/cli/internal/minisign"
 )
 
 func TestParseSemver(t *testing.T) {
@@ -64,6 +76,14 @@ func TestCompareSemver(t *testing.T) {
 		{"1.2.3", "v1.2.3", 0},
 		{"0.9.0", "1.0.0", -1},
 		{"2.0.0", "1.99.99", 1},
+		{"1.4.0-beta.1", "1.4.0", -1},
+		{"1.4.0", "1.4.0-rc.1", 1},
+		{"1.4.0-beta.2", "1.4.0-beta.10", -1},
+		{"1.4.0-beta.1", "1.4.0-rc.1", -1},
+		{"1.4.0-beta", "1.4.0-beta.1", -1},
+		{"1.4.0-1", "1.4.0-beta", -1},
+		{"1.3.9", "1.4.0-beta.1", -1},
+		{"1.4.0+build.5", "1.4.0", 0},
 	}
 
 	for _, tt := range tests {
@@ -79,6 +99,31 @@ func TestCompareSemver(t *testing.T) {
 	}
 }
 
+func TestSelectChannelRelease(t *testing.T) {
+	rels := []githubRelease{
+		{TagName: "v1.5.0-nightly.20261016", Prerelease: true},
+		{TagName: "v1.5.0-beta.2", Prerelease: true, Draft: true},
+		{TagName: "v1.5.0-beta.1", Prerelease: true},
+		{TagName: "v1.4.1"},
+		{TagName: "not-a-version"},
+		{TagName: "v1.4.0"},
+	}
+	tests := map[string]string{
+		"stable":  "v1.4.1",
+		"beta":    "v1.5.0-beta.1",
+		"nightly": "v1.5.0-nightly.20261016",
+	}
+	for channel, want := range tests {
+		got := selectChannelRelease(rels, channel)
+		if got == nil || got.TagName != want {
+			t.Errorf("selectChannelRelease(%s) = %+v, want %s", channel, got, want)
+		}
+	}
+	if got := selectChannelRelease(rels[:1], "stable"); got != nil {
+		t.Errorf("stable channel should ignore pre-releases, got %s", got.TagName)
+	}
+}
+
 func TestCompareSemverErrors(t *testing.T) {
 	_, err := compareSemver("bad", "1.0.0")
 	if err == nil {
@@ -370,3 +415,199 @@ func TestVerifyChecksumInvalidLength(t *testing.T) {
 		t.Fatalf("expected invalid length error, got: %v", err)
 	}
 }
+
+func TestVerifyReleaseArchive(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyID := [8]byte{7}
+	oldKey := releaseSigningKey
+	releaseSigningKey = minisign.EncodePublicKey(pub, keyID)
+	defer func() { releaseSigningKey = oldKey }()
+
+	archive := []byte("archive-bytes")
+	assetName := "prysm-cli-1.4.0-linux-amd64.tar.gz"
+	sums := []byte(fmt.Sprintf("%x  %s\n", sha256.Sum256(archive), assetName))
+	files := map[string][]byte{
+		"/SHA256SUMS":         sums,
+		"/SHA256SUMS.minisig": minisign.Sign(priv, keyID, sums, "file:SHA256SUMS"),
+		"/bad.minisig":        minisign.Sign(priv, keyID, []byte("other"), "file:SHA256SUMS"),
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(data)
+	}))
+	defer srv.Close()
+
+	sumsAsset := githubAsset{Name: "SHA256SUMS", BrowserDownloadURL: srv.URL + "/SHA256SUMS"}
+	signed := &githubRelease{TagName: "v1.4.0", Assets: []githubAsset{sumsAsset,
+		{Name: "SHA256SUMS.minisig", BrowserDownloadURL: srv.URL + "/SHA256SUMS.minisig"}}}
+	unsigned := &githubRelease{TagName: "v1.4.0", Assets: []githubAsset{sumsAsset}}
+	badSig := &githubRelease{TagName: "v1.4.0", Assets: []githubAsset{sumsAsset,
+		{Name: "SHA256SUMS.minisig", BrowserDownloadURL: srv.URL + "/bad.minisig"}}}
+	noSums := &githubRelease{TagName: "v1.4.0"}
+
+	if err := verifyReleaseArchive(signed, assetName, archive, false); err != nil {
+		t.Errorf("signed release: %v", err)
+	}
+	if err := verifyReleaseArchive(signed, assetName, []byte("tampered"), true); err == nil {
+		t.Error("tampered archive accepted")
+	}
+	if err := verifyReleaseArchive(badSig, assetName, archive, true); err == nil {
+		t.Error("bad signature accepted even with --insecure-skip-verify-release")
+	}
+	if err := verifyReleaseArchive(unsigned, assetName, archive, false); err == nil || !strings.Contains(err.Error(), "not signed") {
+		t.Errorf("unsigned release err = %v", err)
+	}
+	if err := verifyReleaseArchive(unsigned, assetName, archive, true); err != nil {
+		t.Errorf("unsigned release with skip: %v", err)
+	}
+	if err := verifyReleaseArchive(noSums, assetName, archive, false); err == nil {
+		t.Error("release without checksums accepted")
+	}
+
+	releaseSigningKey = ""
+	if err := verifyReleaseArchive(signed, assetName, archive, false); err == nil || !strings.Contains(err.Error(), "no release signing key") {
+		t.Errorf("missing build key err = %v", err)
+	}
+}
+
+func TestConfigureUpdateSourceMirror(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/repos/prysmsh/cli/releases/latest" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{"tag_name":"v1.4.0"}`)
+	}))
+	defer srv.Close()
+	oldClient := updateHTTPClient
+	defer func() { releasesURL, updateHTTPClient = defaultReleasesURL, oldClient }()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	if err := os.WriteFile(caFile, certPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.Config{Update: config.UpdateConfig{
+		ReleasesURL: srv.URL + "/api/v3/repos/prysmsh/cli/releases/",
+		CAFile:      caFile,
+	}}
+	if err := configureUpdateSource(cfg); err != nil {
+		t.Fatalf("configureUpdateSource: %v", err)
+	}
+	rel, err := fetchLatestRelease(channelStable)
+	if err != nil {
+		t.Fatalf("fetchLatestRelease via mirror: %v", err)
+	}
+	if rel.TagName != "v1.4.0" {
+		t.Errorf("tag = %s", rel.TagName)
+	}
+
+	cfg.Update.CAFile = ""
+	if err := configureUpdateSource(cfg); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fetchLatestRelease(channelStable); err == nil {
+		t.Error("mirror with untrusted certificate should fail without ca_file")
+	}
+
+	bad := filepath.Join(t.TempDir(), "bad.pem")
+	os.WriteFile(bad, []byte("not a cert"), 0o600)
+	cfg.Update.CAFile = bad
+	if err := configureUpdateSource(cfg); err == nil || !strings.Contains(err.Error(), "no certificates") {
+		t.Errorf("bad CA file err = %v", err)
+	}
+}
+
+func TestReleaseSummary(t *testing.T) {
+	tests := map[string]string{
+		"":                                  "",
+		"Fix tunnel reconnects\nMore text":  "Fix tunnel reconnects",
+		"## What's new\n\n- Faster login\n": "Faster login",
+		"# Prysm CLI Release v1.4.0\n\n_Generated 2026-01-01_\n\n## Artifacts\n\n| Filename | SHA256 |\n": "",
+	}
+	for body, want := range tests {
+		if got := releaseSummary(body); got != want {
+			t.Errorf("releaseSummary(%q) = %q, want %q", body, got, want)
+		}
+	}
+}
+
+func TestReleaseChannel(t *testing.T) {
+	tests := []struct {
+		rel  githubRelease
+		want string
+	}{
+		{githubRelease{TagName: "v1.4.0"}, "stable"},
+		{githubRelease{TagName: "v1.5.0-rc.1", Prerelease: true}, "beta"},
+		{githubRelease{TagName: "v1.5.0-nightly.20261016", Prerelease: true}, "nightly"},
+	}
+	for _, tt := range tests {
+		if got := releaseChannel(tt.rel); got != tt.want {
+			t.Errorf("releaseChannel(%s) = %s, want %s", tt.rel.TagName, got, tt.want)
+		}
+	}
+}
+
+func TestDetectInstallMethod(t *testing.T) {
+	oldDpkg, oldRPM := dpkgInfoDir, rpmOwns
+	defer func() { dpkgInfoDir, rpmOwns = oldDpkg, oldRPM }()
+	dpkgInfoDir = t.TempDir()
+	rpmOwns = func(string) bool { return false }
+	rel := &githubRelease{TagName: "v1.4.0", Assets: []githubAsset{
+		{Name: "prysm-cli-1.4.0-1.x86_64.rpm", BrowserDownloadURL: "https://mirror.example.com/prysm-cli-1.4.0-1.x86_64.rpm"},
+	}}
+
+	tests := map[string]string{
+		"/opt/homebrew/Cellar/prysm/1.3.0/bin/prysm":             "homebrew",
+		"/usr/local/Cellar/prysm/1.3.0/bin/prysm":                "homebrew",
+		"/usr/lib/node_modules/@prysmsh/cli-linux-x64/bin/prysm": "npm",
+		"/home/me/bin/prysm":                                     "",
+	}
+	for path, want := range tests {
+		got := ""
+		if m := detectInstallMethod(path, rel); m != nil {
+			got = m.Name
+		}
+		if got != want {
+			t.Errorf("detectInstallMethod(%s) = %q, want %q", path, got, want)
+		}
+	}
+
+	if runtime.GOOS != "linux" {
+		return
+	}
+	if err := os.WriteFile(filepath.Join(dpkgInfoDir, "prysm-cli.list"), []byte("/.\n/usr/local/bin\n/usr/local/bin/prysm\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m := detectInstallMethod("/usr/local/bin/prysm", rel)
+	if m == nil || m.Name != "deb" || !strings.Contains(m.Upgrade, "/v1.4.0/prysm-cli_1.4.0_") {
+		t.Errorf("deb install = %+v", m)
+	}
+
+	rpmOwns = func(string) bool { return true }
+	m = detectInstallMethod("/usr/bin/prysm", rel)
+	if m == nil || m.Name != "rpm" || !strings.HasPrefix(m.Upgrade, "sudo rpm -U https://") {
+		t.Errorf("rpm install = %+v", m)
+	}
+	if runtime.GOARCH == "amd64" && m.Upgrade != "sudo rpm -U https://mirror.example.com/prysm-cli-1.4.0-1.x86_64.rpm" {
+		t.Errorf("rpm install = %+v", m)
+	}
+}
+
+func TestSudoReplaceCommands(t *testing.T) {
+	got := sudoReplaceCommands("/tmp/prysm-update-1", "/usr/local/bin/prysm.update-new", "/usr/local/bin/prysm")
+	want := [][]string{
+		{"install", "-m", "0755", "/tmp/prysm-update-1", "/usr/local/bin/prysm.update-new"},
+		{"mv", "-f", "/usr/local/bin/prysm.update-new", "/usr/local/bin/prysm"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sudoReplaceCommands = %v, want %v", got, want)
+	}
+}
@@ -22,6 +22,32 @@ type Config struct {
 	OutputFormat   string `mapstructure:"format" yaml:"format"`
 	Organization   string `mapstructure:"organization" yaml:"organization"`
 	DefaultSession string `mapstructure:"session" yaml:"session"`
+	PluginRegistry string `mapstructure:"plugin_registry" yaml:"plugin_registry"`
+	// PluginTrustedKeys are minisign public keys allowed to sign external plugins.
+	PluginTrustedKeys []string `mapstructure:"plugin_trusted_keys" yaml:"plugin_trusted_keys"`
+	// Update holds settings for update notifications.
+	Update UpdateConfig `mapstructure:"update" yaml:"update"`
+	// History enables the local command journal in $PRYSM_HOME/history.jsonl.
+	History bool `mapstructure:"history" yaml:"history"`
+}
+
+// UpdateConfig configures self-update and the background check for new releases.
+type UpdateConfig struct {
+	// Channel selects which releases `prysm update` tracks (stable, beta, nightly).
+	Channel string `mapstructure:"channel" yaml:"channel"`
+	// Check enables the daily "new version available" notice. Unset means enabled.
+	Check *bool `mapstructure:"check" yaml:"check"`
+	// ReleasesURL is a GitHub-compatible releases API endpoint, e.g. a GitHub
+	// Enterprise repo or an internal mirror. Defaults to github.com.
+	ReleasesURL string `mapstructure:"releases_url" yaml:"releases_url"`
+	// CAFile is a PEM bundle trusted in addition to the system roots when
+	// downloading updates.
+	CAFile string `mapstructure:"ca_file" yaml:"ca_file"`
+}
+
+// UpdateCheckEnabled reports whether the background update check should run.
+func (c *Config) UpdateCheckEnabled() bool {
+	return c.Update.Check == nil || *c.Update.Check
 }
 
 type fileConfig struct {
@@ -148,6 +174,27 @@ func (c *Config) merge(other Config) {
 	if other.DefaultSession != "" {
 		c.DefaultSession = other.DefaultSession
 	}
+	if other.PluginRegistry != "" {
+		c.PluginRegistry = other.PluginRegistry
+	}
+	if len(other.PluginTrustedKeys) > 0 {
+		c.PluginTrustedKeys = other.PluginTrustedKeys
+	}
+	if other.Update.Channel != "" {
+		c.Update.Channel = other.Update.Channel
+	}
+	if other.Update.Check != nil {
+		c.Update.Check = other.Update.Check
+	}
+	if other.Update.ReleasesURL != "" {
+		c.Update.ReleasesURL = strings.TrimRight(other.Update.ReleasesURL, "/")
+	}
+	if other.Update.CAFile != "" {
+		c.Update.CAFile = other.Update.CAFile
+	}
+	if other.History {
+		c.History = true
+	}
 }
 
 func applyEnvOverrides(cfg *Config) {
@@ -169,4 +216,19 @@ func applyEnvOverrides(cfg *Config) {
 	if val := os.Getenv("PRYSM_ORG"); val != "" {
 		cfg.Organization = val
 	}
+	if val := os.Getenv("PRYSM_PLUGIN_REGISTRY"); val != "" {
+		cfg.PluginRegistry = val
+	}
+	if val := os.Getenv("PRYSM_UPDATE_CHANNEL"); val != "" {
+		cfg.Update.Channel = val
+	}
+	if val := os.Getenv("PRYSM_UPDATE_URL"); val != "" {
+		cfg.Update.ReleasesURL = strings.TrimRight(val, "/")
+	}
+	if val := os.Getenv("PRYSM_UPDATE_CA_FILE"); val != "" {
+		cfg.Update.CAFile = val
+	}
+	if val := os.Getenv("PRYSM_HISTORY"); val != "" {
+		cfg.History = val == "1" || strings.EqualFold(val, "true")
+	}
 }
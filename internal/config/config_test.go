@@ -152,6 +152,7 @@ func TestLoadAllEnvOverrides(t *testing.T) {
 	t.Setenv("PRYSM_HOME", "/env/home")
 	t.Setenv("PRYSM_FORMAT", "yaml")
 	t.Setenv("PRYSM_ORG", "env-org")
+	t.Setenv("PRYSM_HISTORY", "1")
 
 	cfg, err := Load("", "")
 	if err != nil {
@@ -176,6 +177,9 @@ func TestLoadAllEnvOverrides(t *testing.T) {
 	if cfg.Organization != "env-org" {
 		t.Errorf("Organization = %q, want env-org", cfg.Organization)
 	}
+	if !cfg.History {
+		t.Error("History = false, want true from PRYSM_HISTORY")
+	}
 }
 
 func TestLoadTrailingSlashStripped(t *testing.T) {
@@ -324,3 +328,47 @@ func TestLoadPathIsDirectory(t *testing.T) {
 		t.Errorf("error should mention read config file: %v", err)
 	}
 }
+
+func TestLoadUpdateCheck(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfgPath := filepath.Join(tmpDir, "config.yaml")
+
+	cfg, err := Load(cfgPath, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.UpdateCheckEnabled() {
+		t.Error("update check should default to enabled")
+	}
+
+	configYAML := `
+update:
+  check: false
+  releases_url: https://ghe.example.com/api/v3/repos/prysmsh/cli/releases/
+  ca_file: /etc/ssl/corp.pem
+profiles:
+  ci:
+    update:
+      check: true
+`
+	if err := os.WriteFile(cfgPath, []byte(configYAML), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = Load(cfgPath, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.UpdateCheckEnabled() {
+		t.Error("update.check: false should disable the update check")
+	}
+	if cfg.Update.ReleasesURL != "https://ghe.example.com/api/v3/repos/prysmsh/cli/releases" || cfg.Update.CAFile != "/etc/ssl/corp.pem" {
+		t.Errorf("Update = %+v", cfg.Update)
+	}
+	cfg, err = Load(cfgPath, "ci")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.UpdateCheckEnabled() {
+		t.Error("profile update.check: true should override the top-level setting")
+	}
+}
@@ -0,0 +1,130 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// VersionKey is the config.yaml key recording the last applied migration.
+const VersionKey = "config_version"
+
+// Migration upgrades config.yaml and the Prysm home directory from
+// Version-1 to Version. Apply must be safe to re-run if a previous attempt
+// failed before the new version was recorded.
+type Migration struct {
+	Version     int
+	Description string
+	Apply       func(env *MigrationEnv) error
+}
+
+// MigrationEnv is the state a migration may rewrite.
+type MigrationEnv struct {
+	// HomeDir is the Prysm home directory ($PRYSM_HOME).
+	HomeDir string
+	// Root is the top-level mapping of config.yaml. Changes are written back
+	// after Apply returns.
+	Root *yaml.Node
+}
+
+// migrations are applied in order; entry i has Version i+1. Never edit or
+// reorder a released entry.
+var migrations = []Migration{
+	{Version: 1, Description: "record config_version", Apply: func(*MigrationEnv) error { return nil }},
+}
+
+// SchemaVersion is the config version this build writes: the version of the
+// last migration, or 0 if there are none.
+func SchemaVersion() int {
+	return len(migrations)
+}
+
+// Migrate brings the config file at path and homeDir up to SchemaVersion(),
+// recording each applied level in the file so an interrupted run resumes
+// where it stopped. Files written by a newer CLI are left untouched. Before
+// the first change the original file is copied to <path>.v<N>.bak. It
+// returns the migrations that were applied and the backup path, which is
+// empty if no backup was made.
+func Migrate(path, homeDir string) (applied []Migration, backup string, err error) {
+	doc, exists, err := readConfigDoc(path)
+	if err != nil {
+		return nil, "", err
+	}
+	if !exists {
+		if _, err := os.Stat(homeDir); os.IsNotExist(err) {
+			// Fresh install: nothing to migrate yet.
+			return nil, "", nil
+		}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, "", fmt.Errorf("config file %s is not a YAML mapping", path)
+	}
+
+	current, err := configVersion(root)
+	if err != nil {
+		return nil, "", fmt.Errorf("config file %s: %w", path, err)
+	}
+	if current >= SchemaVersion() {
+		return nil, "", nil
+	}
+
+	if exists {
+		backup = BackupPath(path, current)
+		if err := backupConfig(path, backup); err != nil {
+			return nil, "", err
+		}
+	}
+
+	env := &MigrationEnv{HomeDir: homeDir, Root: root}
+	for _, m := range migrations {
+		if m.Version <= current {
+			continue
+		}
+		if err := m.Apply(env); err != nil {
+			return applied, backup, fmt.Errorf("config migration %d (%s): %w", m.Version, m.Description, err)
+		}
+		setMappingValue(root, VersionKey, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(m.Version)})
+		if err := writeConfigDoc(path, doc); err != nil {
+			return applied, backup, fmt.Errorf("config migration %d: %w", m.Version, err)
+		}
+		applied = append(applied, m)
+	}
+	return applied, backup, nil
+}
+
+func configVersion(root *yaml.Node) (int, error) {
+	node := mappingValue(root, VersionKey)
+	if node == nil {
+		return 0, nil
+	}
+	v, err := strconv.Atoi(node.Value)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("invalid %s %q", VersionKey, node.Value)
+	}
+	return v, nil
+}
+
+// BackupPath is where Migrate copies the config file at path before
+// upgrading it from version.
+func BackupPath(path string, version int) string {
+	return fmt.Sprintf("%s.v%d.bak", path, version)
+}
+
+// backupConfig copies path to backup unless an earlier, interrupted run
+// already did.
+func backupConfig(path, backup string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read config file: %w", err)
+	}
+	if _, err := os.Stat(backup); err == nil {
+		return nil
+	}
+	if err := os.WriteFile(backup, data, 0o600); err != nil {
+		return fmt.Errorf("back up config file: %w", err)
+	}
+	return nil
+}
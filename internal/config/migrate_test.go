@@ -0,0 +1,176 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestMigrationsMatchSchemaVersion(t *testing.T) {
+	for i, m := range migrations {
+		if m.Version != i+1 {
+			t.Errorf("migrations[%d].Version = %d, want %d", i, m.Version, i+1)
+		}
+	}
+	if len(migrations) > 0 && migrations[len(migrations)-1].Version != SchemaVersion() {
+		t.Errorf("last migration = %d, SchemaVersion = %d", migrations[len(migrations)-1].Version, SchemaVersion())
+	}
+}
+
+// withMigrations replaces the migration list for the duration of a test.
+func withMigrations(t *testing.T, ms []Migration) {
+	t.Helper()
+	saved := migrations
+	migrations = ms
+	t.Cleanup(func() { migrations = saved })
+}
+
+func TestMigrate(t *testing.T) {
+	withMigrations(t, []Migration{
+		{Version: 1, Description: "set format", Apply: func(env *MigrationEnv) error {
+			setMappingValue(env.Root, "format", &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "json"})
+			return nil
+		}},
+		{Version: 2, Description: "write marker", Apply: func(env *MigrationEnv) error {
+			return os.WriteFile(filepath.Join(env.HomeDir, "marker"), nil, 0o600)
+		}},
+	})
+
+	home := t.TempDir()
+	cfgPath := filepath.Join(home, "config.yaml")
+	configYAML := `# team defaults
+api_url: https://api.prod.prysm.sh/v1
+update:
+  check: false
+`
+	if err := os.WriteFile(cfgPath, []byte(configYAML), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	applied, backup, err := Migrate(cfgPath, home)
+	if err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if len(applied) != 2 {
+		t.Fatalf("applied %d migrations, want 2", len(applied))
+	}
+
+	data, _ := os.ReadFile(cfgPath)
+	if !strings.Contains(string(data), "# team defaults") || !strings.Contains(string(data), "config_version: 2") {
+		t.Errorf("migrated config:\n%s", data)
+	}
+	if backup != cfgPath+".v0.bak" {
+		t.Errorf("backup path = %q", backup)
+	}
+	if data, err := os.ReadFile(backup); err != nil || string(data) != configYAML {
+		t.Errorf("backup = %q, %v", data, err)
+	}
+	if _, err := os.Stat(filepath.Join(home, "marker")); err != nil {
+		t.Errorf("home migration not applied: %v", err)
+	}
+
+	cfg, err := Load(cfgPath, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.OutputFormat != "json" || cfg.UpdateCheckEnabled() {
+		t.Errorf("migrated config = %+v", cfg)
+	}
+
+	if applied, _, err := Migrate(cfgPath, home); err != nil || len(applied) != 0 {
+		t.Errorf("second Migrate = %d applied, %v", len(applied), err)
+	}
+}
+
+func TestMigrateSkipsFreshAndNewerConfigs(t *testing.T) {
+	withMigrations(t, []Migration{
+		{Version: 1, Description: "noop", Apply: func(*MigrationEnv) error { return nil }},
+	})
+
+	home := filepath.Join(t.TempDir(), ".prysm")
+	cfgPath := filepath.Join(home, "config.yaml")
+	if applied, _, err := Migrate(cfgPath, home); err != nil || applied != nil {
+		t.Errorf("fresh install: %v, %v", applied, err)
+	}
+	if _, err := os.Stat(cfgPath); !os.IsNotExist(err) {
+		t.Errorf("fresh install should not create a config file")
+	}
+
+	if err := os.MkdirAll(home, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	newer := "config_version: 99\nformat: json\n"
+	if err := os.WriteFile(cfgPath, []byte(newer), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if applied, _, err := Migrate(cfgPath, home); err != nil || applied != nil {
+		t.Errorf("newer config: %v, %v", applied, err)
+	}
+	if data, _ := os.ReadFile(cfgPath); string(data) != newer {
+		t.Errorf("newer config rewritten:\n%s", data)
+	}
+
+	if err := os.WriteFile(cfgPath, []byte("config_version: two\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := Migrate(cfgPath, home); err == nil || !strings.Contains(err.Error(), "invalid config_version") {
+		t.Errorf("invalid version err = %v", err)
+	}
+}
+
+func TestMigrateWithoutMigrationsLeavesConfigAlone(t *testing.T) {
+	withMigrations(t, nil)
+
+	home := t.TempDir()
+	cfgPath := filepath.Join(home, "config.yaml")
+	if err := os.WriteFile(cfgPath, []byte("format: json\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if applied, _, err := Migrate(cfgPath, home); err != nil || applied != nil {
+		t.Errorf("Migrate = %v, %v", applied, err)
+	}
+	if _, err := os.Stat(cfgPath + ".v0.bak"); !os.IsNotExist(err) {
+		t.Errorf("no backup expected without migrations")
+	}
+}
+
+func TestMigrateStampsSchemaVersion(t *testing.T) {
+	home := t.TempDir()
+	cfgPath := filepath.Join(home, "config.yaml")
+	if err := os.WriteFile(cfgPath, []byte("format: json\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	applied, backup, err := Migrate(cfgPath, home)
+	if err != nil || len(applied) != SchemaVersion() {
+		t.Fatalf("Migrate = %d applied, %v", len(applied), err)
+	}
+	if _, err := os.Stat(backup); err != nil {
+		t.Errorf("backup %q: %v", backup, err)
+	}
+	doc, _, err := readConfigDoc(cfgPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, err := configVersion(doc.Content[0]); err != nil || v != SchemaVersion() {
+		t.Errorf("config_version = %d, %v; want %d", v, err, SchemaVersion())
+	}
+
+	// The stamped file still loads and survives later edits.
+	if err := SetFileValue(cfgPath, "update.check", "false"); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(cfgPath, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.OutputFormat != "json" || cfg.UpdateCheckEnabled() {
+		t.Errorf("loaded config = %+v", cfg)
+	}
+	if applied, backup, err := Migrate(cfgPath, home); err != nil || applied != nil || backup != "" {
+		t.Errorf("second Migrate = %v, %q, %v", applied, backup, err)
+	}
+}
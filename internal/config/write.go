@@ -0,0 +1,96 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SetFileValue sets a key in the YAML config file at path, creating the file
+// if needed. Dotted keys (update.channel) address nested mappings. Other keys,
+// profiles and comments are preserved.
+func SetFileValue(path, key, value string) error {
+	doc, _, err := readConfigDoc(path)
+	if err != nil {
+		return err
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("config file %s is not a YAML mapping", path)
+	}
+
+	parts := strings.Split(key, ".")
+	for _, part := range parts[:len(parts)-1] {
+		child := mappingValue(root, part)
+		if child == nil || child.Kind != yaml.MappingNode {
+			child = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			setMappingValue(root, part, child)
+		}
+		root = child
+	}
+	setMappingValue(root, parts[len(parts)-1], &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value})
+	return writeConfigDoc(path, doc)
+}
+
+// readConfigDoc parses the config file at path into a YAML document whose
+// first child is the top-level node. A missing or empty file yields an empty
+// mapping; exists reports whether the file was present.
+func readConfigDoc(path string) (doc *yaml.Node, exists bool, err error) {
+	doc = &yaml.Node{}
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, false, fmt.Errorf("read config file: %w", err)
+	}
+	exists = err == nil
+	if len(bytes.TrimSpace(data)) > 0 {
+		if err := yaml.Unmarshal(data, doc); err != nil {
+			return nil, exists, fmt.Errorf("parse config file: %w", err)
+		}
+	}
+	if doc.Kind == 0 {
+		doc = &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	return doc, exists, nil
+}
+
+func writeConfigDoc(path string, doc *yaml.Node) error {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("encode config file: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("encode config file: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("create config directory: %w", err)
+	}
+	return os.WriteFile(path, buf.Bytes(), 0o600)
+}
+
+// mappingValue returns the value stored under key in a YAML mapping, or nil.
+func mappingValue(m *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setMappingValue replaces or appends key in a YAML mapping.
+func setMappingValue(m *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			m.Content[i+1] = value
+			return
+		}
+	}
+	m.Content = append(m.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSetFileValue(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.yaml")
+
+	if err := SetFileValue(cfgPath, "update.channel", "beta"); err != nil {
+		t.Fatalf("SetFileValue on missing file: %v", err)
+	}
+	cfg, err := Load(cfgPath, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Update.Channel != "beta" {
+		t.Fatalf("Update.Channel = %q, want beta", cfg.Update.Channel)
+	}
+
+	configYAML := `# team defaults
+api_url: https://api.prod.prysm.sh/v1
+update:
+  channel: beta
+profiles:
+  staging:
+    api_url: https://api.staging.prysm.sh/v1
+`
+	if err := os.WriteFile(cfgPath, []byte(configYAML), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetFileValue(cfgPath, "update.channel", "nightly"); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(cfgPath)
+	if !strings.Contains(string(data), "# team defaults") || strings.Count(string(data), "channel:") != 1 {
+		t.Errorf("config not updated in place:\n%s", data)
+	}
+	cfg, err = Load(cfgPath, "staging")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Update.Channel != "nightly" || cfg.APIBaseURL != "https://api.staging.prysm.sh/v1" {
+		t.Errorf("cfg = %+v", cfg)
+	}
+}
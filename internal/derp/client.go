@@ -54,6 +54,10 @@ type WGPacketHandler func(fromPeerID string, packet []byte)
 // PingResponseHandler is called when a ping_response from a remote agent arrives.
 type PingResponseHandler func(data map[string]interface{})
 
+// PeerEventHandler is called for peer_list, peer_joined, peer_left and
+// stats_update events.
+type PeerEventHandler func(event EventType)
+
 // Client manages a DERP websocket connection.
 type Client struct {
 	url             string
@@ -86,6 +90,9 @@ type Client struct {
 	// PingResponseHandler is optional; when set, ping_response events are forwarded.
 	PingResponseHandler PingResponseHandler
 
+	// PeerEventHandler is optional; when set, peer membership and stats events are forwarded.
+	PeerEventHandler PeerEventHandler
+
 	// OnConnected is called after the DERP WebSocket connection is established.
 	OnConnected func()
 }
@@ -124,6 +131,14 @@ func WithLogLevel(level LogLevel) Option {
 	}
 }
 
+// WithLogger sends client events to l instead of stdout. A nil logger
+// silences them.
+func WithLogger(l *log.Logger) Option {
+	return func(c *Client) {
+		c.logger = l
+	}
+}
+
 // WithInsecure disables TLS certificate verification.
 func WithInsecure(insecure bool) Option {
 	return func(c *Client) {
@@ -169,6 +184,13 @@ func WithPingResponseHandler(h PingResponseHandler) Option {
 	}
 }
 
+// WithPeerEventHandler sets the callback for peer membership and stats events.
+func WithPeerEventHandler(h PeerEventHandler) Option {
+	return func(c *Client) {
+		c.PeerEventHandler = h
+	}
+}
+
 // WithWGPacketHandler sets the callback for incoming WireGuard packets relayed via DERP.
 func WithWGPacketHandler(h WGPacketHandler) Option {
 	return func(c *Client) {
@@ -458,18 +480,22 @@ func (c *Client) handleMessage(msg map[string]interface{}) {
 
 	switch eventType {
 	case EventPeerList:
+		c.notifyPeerEvent(eventType)
 		count := len(getSlice(msg["peers"]))
 		c.log(style.Info.Render(fmt.Sprintf("Mesh peers online: %d", count)))
 	case EventPeerJoined:
+		c.notifyPeerEvent(eventType)
 		peer := msg["peer"]
 		c.log(style.Success.Render(fmt.Sprintf("Peer joined: %s", summarizePeer(peer))))
 	case EventPeerLeft:
+		c.notifyPeerEvent(eventType)
 		c.log(style.Warning.Render(fmt.Sprintf("Peer left: %s", getString(msg["peer_id"]))))
 	case EventServiceDiscovery:
 		c.log(style.BlueStyle.Render("Service discovery update received"))
 	case EventRelayMessage:
 		c.log(style.Bold.Render(fmt.Sprintf("Relay message: %s", summarizeMessage(msg["message"]))))
 	case EventStatsUpdate:
+		c.notifyPeerEvent(eventType)
 		c.log(style.MagentaStyle.Render("Mesh stats updated"))
 	case EventPong:
 		if c.logLevel == LogDebug {
@@ -502,6 +528,12 @@ func (c *Client) handleMessage(msg map[string]interface{}) {
 	}
 }
 
+func (c *Client) notifyPeerEvent(event EventType) {
+	if c.PeerEventHandler != nil {
+		c.PeerEventHandler(event)
+	}
+}
+
 func (c *Client) log(message string) {
 	if c.logger != nil {
 		c.logger.Println(message)
@@ -30,6 +30,25 @@ func TestHandleMessage_PeerLeft(t *testing.T) {
 	})
 }
 
+func TestHandleMessage_PeerEventHandler(t *testing.T) {
+	var got []EventType
+	c := NewClient("wss://derp.example.com", "dev-1", WithLogger(nil), WithPeerEventHandler(func(e EventType) {
+		got = append(got, e)
+	}))
+	for _, typ := range []string{"peer_list", "peer_joined", "service_discovery", "peer_left", "stats_update"} {
+		c.handleMessage(map[string]interface{}{"type": typ})
+	}
+	want := []EventType{EventPeerList, EventPeerJoined, EventPeerLeft, EventStatsUpdate}
+	if len(got) != len(want) {
+		t.Fatalf("events = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("events[%d] = %s, want %s", i, got[i], want[i])
+		}
+	}
+}
+
 func TestHandleMessage_ServiceDiscovery(t *testing.T) {
 	c := NewClient("wss://derp.example.com", "dev-1")
 	c.handleMessage(map[string]interface{}{"type": "service_discovery"})
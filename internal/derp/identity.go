@@ -10,6 +10,24 @@ import (
 	"strings"
 )
 
+const deviceIDFile = "mesh-device-id"
+
+// ReadDeviceID returns the device identifier stored by EnsureDeviceID, or ""
+// if this device has none yet. Unlike EnsureDeviceID it never creates one.
+func ReadDeviceID(homeDir string) (string, error) {
+	if homeDir == "" {
+		return "", fmt.Errorf("home directory is required")
+	}
+	data, err := os.ReadFile(filepath.Join(homeDir, deviceIDFile))
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
 // EnsureDeviceID returns a stable device identifier stored within the given directory.
 // New IDs use hostname-cli to avoid duplicates when CLI and desktop run on the same machine.
 func EnsureDeviceID(homeDir string) (string, error) {
@@ -17,14 +35,11 @@ func EnsureDeviceID(homeDir string) (string, error) {
 		return "", fmt.Errorf("home directory is required")
 	}
 
-	path := filepath.Join(homeDir, "mesh-device-id")
-	if data, err := os.ReadFile(path); err == nil {
-		id := strings.TrimSpace(string(data))
-		if id != "" {
-			return id, nil
-		}
+	if id, err := ReadDeviceID(homeDir); err == nil && id != "" {
+		return id, nil
 	}
 
+	path := filepath.Join(homeDir, deviceIDFile)
 	id, err := generateID()
 	if err != nil {
 		return "", err
@@ -91,3 +91,23 @@ func TestEnsureDeviceIDExistingFileEmptyTrimmed(t *testing.T) {
 		t.Error("expected new id when file is whitespace-only")
 	}
 }
+
+func TestReadDeviceIDDoesNotCreate(t *testing.T) {
+	dir := t.TempDir()
+
+	id, err := ReadDeviceID(dir)
+	if err != nil || id != "" {
+		t.Fatalf("ReadDeviceID on empty home = %q, %v", id, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "mesh-device-id")); !os.IsNotExist(err) {
+		t.Fatalf("ReadDeviceID created the device ID file: %v", err)
+	}
+
+	want, err := EnsureDeviceID(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id, err := ReadDeviceID(dir); err != nil || id != want {
+		t.Errorf("ReadDeviceID = %q, %v; want %q", id, err, want)
+	}
+}
@@ -0,0 +1,158 @@
+// Package history implements the opt-in local journal of prysm invocations.
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// File is the journal file name under $PRYSM_HOME.
+const File = "history.jsonl"
+
+// Redacted replaces secret values in recorded arguments.
+const Redacted = "[REDACTED]"
+
+// Entry is one recorded invocation.
+type Entry struct {
+	Time       time.Time `json:"time"`
+	Command    string    `json:"command"` // command path without "prysm", e.g. "tunnel expose"
+	Args       []string  `json:"args"`    // arguments after "prysm", secrets redacted
+	ExitCode   int       `json:"exit_code"`
+	Error      string    `json:"error,omitempty"`
+	DurationMS int64     `json:"duration_ms"`
+	Profile    string    `json:"profile,omitempty"`
+	RequestIDs []string  `json:"request_ids,omitempty"`
+}
+
+// Filter selects entries returned by Journal.Read.
+type Filter struct {
+	Since   time.Time // only entries at or after Since
+	Command string    // command path prefix, e.g. "tunnel"
+	Failed  bool      // only non-zero exit codes
+	Limit   int       // keep the most recent Limit entries; 0 keeps all
+}
+
+// Journal appends entries to a JSON-lines file.
+type Journal struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewJournal creates a journal writing to path.
+func NewJournal(path string) *Journal {
+	return &Journal{path: path}
+}
+
+// Append writes e as a single line.
+func (j *Journal) Append(e Entry) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(j.path), 0o700); err != nil {
+		return fmt.Errorf("ensure history directory: %w", err)
+	}
+	f, err := os.OpenFile(j.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("open history: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("write history: %w", err)
+	}
+	return nil
+}
+
+// Read returns the entries matching f, oldest first. Malformed lines (e.g. a
+// partial write) are skipped.
+func (j *Journal) Read(f Filter) ([]Entry, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	file, err := os.Open(j.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("open history: %w", err)
+	}
+	defer file.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e Entry
+		if json.Unmarshal(scanner.Bytes(), &e) != nil {
+			continue
+		}
+		if !f.Since.IsZero() && e.Time.Before(f.Since) {
+			continue
+		}
+		if f.Failed && e.ExitCode == 0 {
+			continue
+		}
+		if f.Command != "" && e.Command != f.Command && !strings.HasPrefix(e.Command, f.Command+" ") {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read history: %w", err)
+	}
+	if f.Limit > 0 && len(entries) > f.Limit {
+		entries = entries[len(entries)-f.Limit:]
+	}
+	return entries, nil
+}
+
+// sensitiveFlagWords mark flags whose values must never be written to disk.
+var sensitiveFlagWords = []string{"token", "password", "secret", "key", "credential"}
+
+// Redact returns a copy of args with the values of secret-looking flags
+// (--token, --password, --api-key, ...) replaced by Redacted. Both
+// "--flag value" and "--flag=value" forms are handled.
+func Redact(args []string) []string {
+	out := make([]string, len(args))
+	copy(out, args)
+	for i := 0; i < len(out); i++ {
+		arg := out[i]
+		if arg == "--" {
+			break
+		}
+		if !strings.HasPrefix(arg, "-") {
+			continue
+		}
+		name, _, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !isSensitiveFlag(name) {
+			continue
+		}
+		if hasValue {
+			out[i] = arg[:strings.Index(arg, "=")+1] + Redacted
+		} else if i+1 < len(out) && !strings.HasPrefix(out[i+1], "-") {
+			out[i+1] = Redacted
+			i++
+		}
+	}
+	return out
+}
+
+func isSensitiveFlag(name string) bool {
+	name = strings.ToLower(name)
+	for _, w := range sensitiveFlagWords {
+		if strings.Contains(name, w) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRedact(t *testing.T) {
+	args := []string{"--token", "abc", "tunnel", "expose", "--api-key=xyz", "8080", "--debug", "--password", "--", "--token", "literal"}
+	got := Redact(args)
+	want := []string{"--token", Redacted, "tunnel", "expose", "--api-key=" + Redacted, "8080", "--debug", "--password", "--", "--token", "literal"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Redact = %q\nwant %q", got, want)
+	}
+	if args[1] != "abc" {
+		t.Error("Redact modified its input")
+	}
+}
+
+func TestJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), File)
+	j := NewJournal(path)
+
+	if entries, err := j.Read(Filter{}); err != nil || entries != nil {
+		t.Fatalf("Read on missing journal = %v, %v", entries, err)
+	}
+
+	now := time.Now()
+	for _, e := range []Entry{
+		{Time: now.Add(-48 * time.Hour), Command: "tunnel expose", ExitCode: 0},
+		{Time: now.Add(-time.Hour), Command: "tunnel list", ExitCode: 1, Error: "boom"},
+		{Time: now, Command: "mesh connect", RequestIDs: []string{"req-1"}},
+		{Time: now, Command: "tunnels"},
+	} {
+		if err := j.Append(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// A torn write must not hide the rest of the journal.
+	f, _ := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	f.WriteString("{\"time\":")
+	f.Close()
+
+	all, err := j.Read(Filter{})
+	if err != nil || len(all) != 4 {
+		t.Fatalf("Read = %d entries, %v", len(all), err)
+	}
+	if all[2].RequestIDs[0] != "req-1" {
+		t.Errorf("request ids not round-tripped: %+v", all[2])
+	}
+
+	tunnel, _ := j.Read(Filter{Command: "tunnel"})
+	if len(tunnel) != 2 {
+		t.Errorf("Command filter = %+v", tunnel)
+	}
+	failed, _ := j.Read(Filter{Failed: true})
+	if len(failed) != 1 || failed[0].Error != "boom" {
+		t.Errorf("Failed filter = %+v", failed)
+	}
+	recent, _ := j.Read(Filter{Since: now.Add(-2 * time.Hour)})
+	if len(recent) != 3 {
+		t.Errorf("Since filter = %+v", recent)
+	}
+	last, _ := j.Read(Filter{Limit: 1})
+	if len(last) != 1 || last[0].Command != "tunnels" {
+		t.Errorf("Limit = %+v", last)
+	}
+}
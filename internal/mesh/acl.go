@@ -0,0 +1,123 @@
+package mesh
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/prysmsh/cli/internal/api"
+)
+
+// ACLEndpoint is a mesh node as seen by access policy selectors.
+type ACLEndpoint struct {
+	DeviceID string
+	Cluster  string // cluster name for cluster agents
+	PeerType string // client or cluster
+	IP       net.IP // overlay IP, if known
+}
+
+// ACLDecision is the outcome of evaluating a policy for one connection.
+type ACLDecision struct {
+	Allowed bool
+	Rule    int    // index of the deciding rule; -1 when the default applied
+	Reason  string // rule name, or "default <action>"
+}
+
+// EvaluateACL decides whether src may open a proto connection to dst:port,
+// following the rule semantics documented on api.MeshACL. It is a local
+// approximation of the control plane's evaluation, used where the control
+// plane cannot evaluate a connection itself. An empty proto or a zero port
+// matches any rule protocol or port.
+func EvaluateACL(policy *api.MeshACL, src, dst ACLEndpoint, proto string, port int) ACLDecision {
+	for i, r := range policy.Rules {
+		if !ACLRuleMatches(r, src, dst) || !aclProtoMatches(r.Protocol, proto) || !ACLPortsMatch(r.Ports, port) {
+			continue
+		}
+		return ACLDecision{Allowed: strings.EqualFold(r.Action, "accept"), Rule: i, Reason: ACLRuleName(r, i)}
+	}
+	def := strings.ToLower(policy.DefaultAction)
+	if def == "" {
+		def = "deny"
+	}
+	return ACLDecision{Allowed: def == "accept", Rule: -1, Reason: "default " + def}
+}
+
+// ACLRuleMatches reports whether r applies to traffic from src to dst,
+// ignoring protocol and ports.
+func ACLRuleMatches(r api.MeshACLRule, src, dst ACLEndpoint) bool {
+	return aclSelectorsMatch(r.Sources, src) && aclSelectorsMatch(r.Destinations, dst)
+}
+
+// ACLRuleName returns the rule's name, or its 1-based position if unnamed.
+func ACLRuleName(r api.MeshACLRule, i int) string {
+	if r.Name != "" {
+		return r.Name
+	}
+	return fmt.Sprintf("rule %d", i+1)
+}
+
+func aclSelectorsMatch(selectors []string, e ACLEndpoint) bool {
+	for _, s := range selectors {
+		if ACLSelectorMatches(s, e) {
+			return true
+		}
+	}
+	return false
+}
+
+// ACLSelectorMatches reports whether a single source or destination selector
+// matches e.
+func ACLSelectorMatches(sel string, e ACLEndpoint) bool {
+	sel = strings.TrimSpace(sel)
+	if sel == "*" {
+		return true
+	}
+	kind, value, ok := strings.Cut(sel, ":")
+	if ok {
+		switch kind {
+		case "device":
+			return value == e.DeviceID
+		case "cluster":
+			return e.Cluster != "" && strings.EqualFold(value, e.Cluster)
+		case "type":
+			return strings.EqualFold(value, e.PeerType)
+		}
+	}
+	if e.IP != nil {
+		if _, ipnet, err := net.ParseCIDR(sel); err == nil {
+			return ipnet.Contains(e.IP)
+		}
+		if ip := net.ParseIP(sel); ip != nil {
+			return ip.Equal(e.IP)
+		}
+	}
+	return sel == e.DeviceID
+}
+
+func aclProtoMatches(ruleProto, proto string) bool {
+	return ruleProto == "" || proto == "" || strings.EqualFold(ruleProto, proto)
+}
+
+// ACLPortsMatch reports whether port falls within one of the port specs. No
+// specs, or port 0, match everything.
+func ACLPortsMatch(specs []string, port int) bool {
+	if len(specs) == 0 || port == 0 {
+		return true
+	}
+	for _, spec := range specs {
+		if spec == "*" {
+			return true
+		}
+		lo, hi, isRange := strings.Cut(spec, "-")
+		if !isRange {
+			hi = lo
+		}
+		from, err1 := strconv.Atoi(strings.TrimSpace(lo))
+		to, err2 := strconv.Atoi(strings.TrimSpace(hi))
+		if err1 == nil && err2 == nil && port >= from && port <= to {
+			return true
+		}
+	}
+	return false
+}
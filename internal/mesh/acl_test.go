@@ -0,0 +1,70 @@
+package mesh
+
+import (
+	"net"
+	"testing"
+
+	"github.com/prysmsh/cli/internal/api"
+)
+
+func TestEvaluateACL(t *testing.T) {
+	policy := &api.MeshACL{
+		Rules: []api.MeshACLRule{
+			{Name: "no-ssh-prod", Action: "deny", Sources: []string{"type:client"}, Destinations: []string{"cluster:prod"}, Ports: []string{"22"}},
+			{Name: "web", Action: "accept", Sources: []string{"*"}, Destinations: []string{"cluster:prod"}, Ports: []string{"443", "8000-8100"}, Protocol: "tcp"},
+			{Action: "accept", Sources: []string{"device:laptop-1"}, Destinations: []string{"100.96.0.0/16"}},
+		},
+	}
+	laptop := ACLEndpoint{DeviceID: "laptop-1", PeerType: "client", IP: net.ParseIP("100.96.0.5")}
+	phone := ACLEndpoint{DeviceID: "phone", PeerType: "client", IP: net.ParseIP("100.96.0.6")}
+	prod := ACLEndpoint{DeviceID: "agent-prod", Cluster: "Prod", PeerType: "cluster", IP: net.ParseIP("100.96.1.2")}
+
+	tests := []struct {
+		name     string
+		src, dst ACLEndpoint
+		proto    string
+		port     int
+		allowed  bool
+		reason   string
+	}{
+		{"ssh denied before catch-all", laptop, prod, "tcp", 22, false, "no-ssh-prod"},
+		{"https allowed", phone, prod, "tcp", 443, true, "web"},
+		{"port range", phone, prod, "tcp", 8080, true, "web"},
+		{"udp not covered by web", phone, prod, "udp", 443, false, "default deny"},
+		{"cidr rule, unnamed", laptop, phone, "", 5432, true, "rule 3"},
+		{"no rule", phone, laptop, "tcp", 80, false, "default deny"},
+	}
+	for _, tt := range tests {
+		d := EvaluateACL(policy, tt.src, tt.dst, tt.proto, tt.port)
+		if d.Allowed != tt.allowed || d.Reason != tt.reason {
+			t.Errorf("%s: got allowed=%v reason=%q, want %v %q", tt.name, d.Allowed, d.Reason, tt.allowed, tt.reason)
+		}
+	}
+
+	policy.DefaultAction = "accept"
+	if d := EvaluateACL(policy, phone, laptop, "tcp", 80); !d.Allowed || d.Rule != -1 {
+		t.Errorf("default accept: got %+v", d)
+	}
+}
+
+func TestACLPortsMatch(t *testing.T) {
+	tests := []struct {
+		specs []string
+		port  int
+		want  bool
+	}{
+		{nil, 22, true},
+		{[]string{"443"}, 0, true},
+		{[]string{"443"}, 443, true},
+		{[]string{"443"}, 444, false},
+		{[]string{"8000-8100"}, 8100, true},
+		{[]string{"8000-8100"}, 7999, false},
+		{[]string{"*"}, 1, true},
+		{[]string{"bogus"}, 1, false},
+	}
+	for _, tt := range tests {
+		if got := ACLPortsMatch(tt.specs, tt.port); got != tt.want {
+			t.Errorf("ACLPortsMatch(%v, %d) = %v, want %v", tt.specs, tt.port, got, tt.want)
+		}
+	}
+}
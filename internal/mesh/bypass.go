@@ -0,0 +1,69 @@
+package mesh
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// ControlPlaneBypassCIDRs resolves DERP/API hosts and returns /32 CIDRs that
+// must never be redirected through exit routing.
+func ControlPlaneBypassCIDRs(ctx context.Context, relayURL, apiBaseURL string) []string {
+	return controlPlaneCIDRs(ctx, relayURL, apiBaseURL, false)
+}
+
+// ExitBypassCIDRs is ControlPlaneBypassCIDRs plus /128 CIDRs for the hosts'
+// IPv6 addresses, which exit routing also captures.
+func ExitBypassCIDRs(ctx context.Context, relayURL, apiBaseURL string) []string {
+	return controlPlaneCIDRs(ctx, relayURL, apiBaseURL, true)
+}
+
+func controlPlaneCIDRs(ctx context.Context, relayURL, apiBaseURL string, withIPv6 bool) []string {
+	hosts := []string{}
+	if h := hostFromURL(relayURL); h != "" {
+		hosts = append(hosts, h)
+	}
+	if h := hostFromURL(apiBaseURL); h != "" {
+		hosts = append(hosts, h)
+	}
+	uniqHosts := make(map[string]struct{}, len(hosts))
+	for _, h := range hosts {
+		uniqHosts[h] = struct{}{}
+	}
+	out := []string{}
+	seen := map[string]struct{}{}
+	for h := range uniqHosts {
+		ips, err := net.DefaultResolver.LookupIP(ctx, "ip", h)
+		if err != nil {
+			continue
+		}
+		for _, ip := range ips {
+			cidr := ""
+			if v4 := ip.To4(); v4 != nil {
+				cidr = v4.String() + "/32"
+			} else if withIPv6 {
+				cidr = ip.String() + "/128"
+			} else {
+				continue
+			}
+			if _, ok := seen[cidr]; ok {
+				continue
+			}
+			seen[cidr] = struct{}{}
+			out = append(out, cidr)
+		}
+	}
+	return out
+}
+
+func hostFromURL(raw string) string {
+	if strings.TrimSpace(raw) == "" {
+		return ""
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	return u.Hostname()
+}
@@ -0,0 +1,57 @@
+package mesh
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prysmsh/cli/internal/meshdns"
+)
+
+// SyncDNS points <device>.mesh.prysm names at the peers' current overlay IPs,
+// starting the local resolver the first time. Overlay IPs only route through
+// a TUN interface, so nothing is served for user-space tunnels.
+func (l *Lifecycle) SyncDNS(ctx context.Context) error {
+	l.mu.RLock()
+	apiClient, tun, disabled := l.apiClient, l.wgTunnel, l.dnsDisabled
+	l.mu.RUnlock()
+	if apiClient == nil || tun == nil {
+		return fmt.Errorf("not connected")
+	}
+	if tun.Userspace() || disabled {
+		return nil
+	}
+
+	listCtx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	nodes, err := apiClient.ListMeshNodes(listCtx)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("list mesh nodes: %w", err)
+	}
+	hosts := meshdns.HostIPs(nodes, l.cfg.DeviceID)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.dns != nil {
+		l.dns.SetHosts(hosts)
+		return nil
+	}
+	srv, err := meshdns.Start(hosts)
+	if err != nil {
+		// Split DNS needs resolvectl or /etc/resolver; don't retry every sync.
+		l.dnsDisabled = true
+		return fmt.Errorf("resolver disabled: %w", err)
+	}
+	l.dns = srv
+	l.logger.Printf("mesh DNS: *.%s answered on %s", meshdns.Suffix, meshdns.Address)
+	return nil
+}
+
+// stopDNSLocked stops the local resolver. l.mu must be held.
+func (l *Lifecycle) stopDNSLocked() {
+	if l.dns != nil {
+		l.dns.Close()
+		l.dns = nil
+	}
+	l.dnsDisabled = false
+}
@@ -0,0 +1,36 @@
+package mesh
+
+import (
+	"context"
+
+	"github.com/prysmsh/cli/internal/derp"
+)
+
+// notePeerEvent records a DERP peer membership or stats event and wakes
+// WaitPeerEvent callers.
+func (l *Lifecycle) notePeerEvent(derp.EventType) {
+	l.eventMu.Lock()
+	l.peerEvents++
+	close(l.peerEventCh)
+	l.peerEventCh = make(chan struct{})
+	l.eventMu.Unlock()
+}
+
+// WaitPeerEvent blocks until the peer event count exceeds since or ctx is
+// done, and returns the count. The count starts at 1, so since 0 returns at
+// once.
+func (l *Lifecycle) WaitPeerEvent(ctx context.Context, since int64) int64 {
+	for {
+		l.eventMu.Lock()
+		n, ch := l.peerEvents, l.peerEventCh
+		l.eventMu.Unlock()
+		if n > since {
+			return n
+		}
+		select {
+		case <-ctx.Done():
+			return n
+		case <-ch:
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package mesh
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prysmsh/cli/internal/derp"
+)
+
+func TestWaitPeerEvent(t *testing.T) {
+	l := New(Config{})
+	ctx := context.Background()
+	seq := l.WaitPeerEvent(ctx, 0)
+	if seq != 1 {
+		t.Fatalf("initial count = %d, want 1", seq)
+	}
+
+	got := make(chan int64, 1)
+	go func() { got <- l.WaitPeerEvent(ctx, seq) }()
+	l.notePeerEvent(derp.EventPeerJoined)
+	select {
+	case n := <-got:
+		if n != 2 {
+			t.Errorf("after event = %d, want 2", n)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("WaitPeerEvent did not wake up")
+	}
+
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if n := l.WaitPeerEvent(timeout, 2); n != 2 {
+		t.Errorf("timed out wait = %d, want 2", n)
+	}
+}
@@ -0,0 +1,157 @@
+package mesh
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prysmsh/cli/internal/wg"
+)
+
+// exitNode is the exit node selected with UseExitNode. It survives
+// reconnects and is re-applied to each new tunnel.
+type exitNode struct {
+	deviceID   string
+	killSwitch bool
+	bypass     []string // control-plane CIDRs routed around the tunnel
+}
+
+// UseExitNode routes all traffic through the WireGuard peer whose DERP
+// device ID is deviceID. With killSwitch, traffic is dropped rather than sent
+// unprotected while the tunnel is reconnecting.
+func (l *Lifecycle) UseExitNode(ctx context.Context, deviceID string, killSwitch bool) error {
+	l.mu.RLock()
+	derpURL, apiURL := l.cfg.DERPURL, l.cfg.APIURL
+	l.mu.RUnlock()
+	cidrs := ExitBypassCIDRs(ctx, derpURL, apiURL)
+	if len(cidrs) == 0 {
+		return fmt.Errorf("could not resolve the relay and API addresses to keep them outside the tunnel")
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.wgTunnel == nil {
+		return fmt.Errorf("WireGuard tunnel is not up")
+	}
+	publicKey, ok := devicePeerKey(l.wgTunnel, deviceID)
+	if !ok {
+		return fmt.Errorf("exit node %s is not a WireGuard peer of this device", deviceID)
+	}
+
+	l.clearExitNodeLocked()
+	bypass, err := wg.AddBypassRoutes(cidrs)
+	if err != nil {
+		return err
+	}
+	if killSwitch {
+		if err := wg.SetKillSwitch(true); err != nil {
+			wg.DeleteBypassRoutes(bypass)
+			return err
+		}
+	}
+	if err := l.wgTunnel.SetExitPeer(publicKey); err != nil {
+		if killSwitch {
+			_ = wg.SetKillSwitch(false)
+		}
+		wg.DeleteBypassRoutes(bypass)
+		return err
+	}
+	l.exit = &exitNode{deviceID: deviceID, killSwitch: killSwitch, bypass: bypass}
+	l.logger.Printf("exit node: routing all traffic through %s", deviceID)
+	return nil
+}
+
+// ClearExitNode stops routing traffic through the exit node.
+func (l *Lifecycle) ClearExitNode() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.clearExitNodeLocked()
+}
+
+func (l *Lifecycle) clearExitNodeLocked() {
+	if l.exit == nil {
+		return
+	}
+	if l.wgTunnel != nil {
+		if err := l.wgTunnel.ClearExitPeer(); err != nil {
+			l.logger.Printf("exit node: %v", err)
+		}
+	}
+	if l.exit.killSwitch {
+		if err := wg.SetKillSwitch(false); err != nil {
+			l.logger.Printf("exit node: %v", err)
+		}
+	}
+	wg.DeleteBypassRoutes(l.exit.bypass)
+	l.logger.Printf("exit node: %s released", l.exit.deviceID)
+	l.exit = nil
+}
+
+// reapplyExitNodeLocked points a freshly created tunnel at the selected exit
+// node. Bypass routes and the kill switch outlive the tunnel and are kept.
+func (l *Lifecycle) reapplyExitNodeLocked() {
+	if l.exit == nil || l.wgTunnel == nil {
+		return
+	}
+	publicKey, ok := devicePeerKey(l.wgTunnel, l.exit.deviceID)
+	if !ok {
+		l.logger.Printf("exit node: %s is no longer a peer", l.exit.deviceID)
+		return
+	}
+	if err := l.wgTunnel.SetExitPeer(publicKey); err != nil {
+		l.logger.Printf("exit node: %v", err)
+	}
+}
+
+// SyncExitGateway fetches this device's mesh node and forwards peers'
+// traffic as an exit node when it is exit-enabled, or stops doing so.
+func (l *Lifecycle) SyncExitGateway(ctx context.Context) error {
+	l.mu.RLock()
+	apiClient := l.apiClient
+	l.mu.RUnlock()
+	if apiClient == nil {
+		return fmt.Errorf("not connected")
+	}
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+	nodes, err := apiClient.ListMeshNodes(ctx)
+	if err != nil {
+		return fmt.Errorf("list mesh nodes: %w", err)
+	}
+	enabled := false
+	for _, n := range nodes {
+		if n.DeviceID == l.cfg.DeviceID {
+			enabled = n.ExitEnabled
+			break
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.wgTunnel == nil {
+		return fmt.Errorf("WireGuard tunnel is not up")
+	}
+	if enabled == l.wgTunnel.ExitGateway() {
+		return nil
+	}
+	if err := l.wgTunnel.SetExitGateway(enabled); err != nil {
+		return err
+	}
+	if enabled {
+		l.logger.Printf("exit node: forwarding traffic for mesh peers")
+	} else {
+		l.logger.Printf("exit node: no longer forwarding traffic for mesh peers")
+	}
+	return nil
+}
+
+// devicePeerKey finds the WireGuard peer for a DERP device ID; DERP-relayed
+// peers use the device ID as their endpoint.
+func devicePeerKey(tun *wg.Tunnel, deviceID string) (string, bool) {
+	for _, p := range tun.Peers() {
+		if p.Endpoint == deviceID {
+			return p.PublicKey, true
+		}
+	}
+	return "", false
+}
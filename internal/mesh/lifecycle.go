@@ -5,12 +5,14 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"slices"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/prysmsh/cli/internal/api"
 	"github.com/prysmsh/cli/internal/derp"
+	"github.com/prysmsh/cli/internal/meshdns"
 	"github.com/prysmsh/cli/internal/wg"
 )
 
@@ -26,30 +28,49 @@ type Config struct {
 	HomeDir      string
 	InsecureTLS  bool
 	WireGuard    bool
+	// PeerSyncInterval is how often WireGuard peers are re-fetched from the
+	// control plane and applied. Zero uses DefaultPeerSyncInterval; negative
+	// disables syncing.
+	PeerSyncInterval time.Duration
 }
 
+// DefaultPeerSyncInterval is the default for Config.PeerSyncInterval.
+const DefaultPeerSyncInterval = 30 * time.Second
+
 // Status represents the current state of the mesh lifecycle.
 // PeerStatus describes a WG peer for status display.
 type PeerStatus struct {
-	Name      string
-	OverlayIP string
-	Endpoint  string
+	Name          string
+	OverlayIP     string
+	Endpoint      string
+	PublicKey     string
+	LastHandshake time.Time // zero until a handshake completes
+	TxBytes       int64
+	RxBytes       int64
 }
 
 type Status struct {
-	State     string       `json:"state"`
-	OverlayIP string       `json:"overlay_ip"`
-	Interface string       `json:"interface"`
-	PeerCount int          `json:"peer_count"`
-	Peers     []PeerStatus `json:"peers"`
-	StartedAt time.Time    `json:"started_at"`
-	TxBytes   int64        `json:"tx_bytes"`
-	RxBytes   int64        `json:"rx_bytes"`
+	State      string       `json:"state"`
+	OverlayIP  string       `json:"overlay_ip"`
+	Interface  string       `json:"interface"`
+	PeerCount  int          `json:"peer_count"`
+	Peers      []PeerStatus `json:"peers"`
+	StartedAt  time.Time    `json:"started_at"`
+	TxBytes    int64        `json:"tx_bytes"`
+	RxBytes    int64        `json:"rx_bytes"`
+	ExitNode   string       `json:"exit_node,omitempty"` // device ID
+	KillSwitch bool         `json:"kill_switch,omitempty"`
+	// SubnetRoutes are the accepted subnet route IDs in use; Advertised are
+	// the CIDRs this device forwards for as a subnet gateway.
+	SubnetRoutes []int64  `json:"subnet_routes,omitempty"`
+	Advertised   []string `json:"advertised,omitempty"`
+	// ExitGateway is set while this device is an exit node for its peers.
+	ExitGateway bool `json:"exit_gateway,omitempty"`
 }
 
-// Lifecycle owns the DERP client, WireGuard tunnel, and keepalive ping loop.
-// It does NOT own exit proxy, subnet routing, or SOCKS5 — those remain in the
-// CLI command layer.
+// Lifecycle owns the DERP client, WireGuard tunnel, keepalive ping loop and
+// the resolver for peer names. It does NOT own exit proxy, subnet routing, or
+// SOCKS5 — those remain in the CLI command layer.
 type Lifecycle struct {
 	mu         sync.RWMutex
 	cfg        Config
@@ -59,8 +80,28 @@ type Lifecycle struct {
 	wgBind     *wg.DERPBind
 	cancel     context.CancelFunc
 	status     Status
-	done       chan struct{}
-	logger     *log.Logger
+	exit       *exitNode
+	// subnetRoutes and advertised record what the last applySubnetRoutes
+	// configured, for status.
+	subnetRoutes []int64
+	advertised   []string
+	done         chan struct{}
+	logger       *log.Logger
+
+	// dns answers <device>.mesh.prysm; dnsDisabled is set once it failed
+	// to start.
+	dns         *meshdns.Server
+	dnsDisabled bool
+
+	// peerEvents counts DERP peer events for WaitPeerEvent; peerEventCh is
+	// closed and replaced on each one.
+	eventMu     sync.Mutex
+	peerEvents  int64
+	peerEventCh chan struct{}
+
+	// pings maps relay ping request IDs to the RelayPing call waiting for
+	// the reply.
+	pings sync.Map
 }
 
 // New creates a Lifecycle in the disconnected state.
@@ -70,6 +111,9 @@ func New(cfg Config) *Lifecycle {
 		done:   make(chan struct{}),
 		status: Status{State: "disconnected"},
 		logger: log.New(log.Writer(), "mesh: ", log.LstdFlags),
+
+		peerEvents:  1,
+		peerEventCh: make(chan struct{}),
 	}
 }
 
@@ -185,6 +229,8 @@ func (l *Lifecycle) runOnce(ctx context.Context, apiClient *api.Client) error {
 		derp.WithCapabilities(capabilities),
 		derp.WithInsecure(l.cfg.InsecureTLS),
 		derp.WithSessionToken(l.cfg.AuthToken),
+		derp.WithPeerEventHandler(l.notePeerEvent),
+		derp.WithPingResponseHandler(l.notePingResponse),
 	)
 	l.mu.Lock()
 	l.derpClient = derpClient
@@ -211,6 +257,7 @@ func (l *Lifecycle) runOnce(ctx context.Context, apiClient *api.Client) error {
 			l.mu.Lock()
 			l.wgTunnel = tun
 			l.wgBind = bind
+			l.reapplyExitNodeLocked()
 			l.mu.Unlock()
 
 			derpClient.WGPacketHandler = func(fromPeerID string, packet []byte) {
@@ -263,10 +310,84 @@ func (l *Lifecycle) runOnce(ctx context.Context, apiClient *api.Client) error {
 		}
 	}()
 
+	// Peer sync — apply peers added or removed after connect.
+	if tun := l.currentTunnel(); tun != nil {
+		if err := l.SyncSubnetRoutes(ctx); err != nil {
+			l.logger.Printf("subnet routes: %v", err)
+		}
+		if err := l.SyncExitGateway(ctx); err != nil {
+			l.logger.Printf("exit node: %v", err)
+		}
+		if err := l.SyncDNS(ctx); err != nil {
+			l.logger.Printf("mesh DNS: %v", err)
+		}
+		syncCtx, stopSync := context.WithCancel(ctx)
+		defer stopSync()
+		go l.syncPeers(syncCtx, apiClient, tun)
+	}
+
 	// Run DERP client — blocks until disconnect or context cancel
 	return derpClient.Run(ctx)
 }
 
+func (l *Lifecycle) currentTunnel() *wg.Tunnel {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.wgTunnel
+}
+
+// syncPeers polls the control plane for WireGuard peer changes and applies
+// them to tun until ctx is cancelled.
+func (l *Lifecycle) syncPeers(ctx context.Context, apiClient *api.Client, tun *wg.Tunnel) {
+	interval := l.cfg.PeerSyncInterval
+	if interval == 0 {
+		interval = DefaultPeerSyncInterval
+	}
+	if interval < 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		planCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		diff, err := wg.PlanPeerSync(planCtx, apiClient, tun, l.cfg.HomeDir, l.cfg.DeviceID)
+		cancel()
+		switch {
+		case err != nil:
+			l.logger.Printf("peer sync: %v", err)
+		case !diff.Empty():
+			// Apply under l.mu so a reconnect cannot tear the tunnel down midway.
+			l.mu.Lock()
+			if l.wgTunnel == tun && ctx.Err() == nil {
+				if err := tun.ApplyPeerDiff(diff); err != nil {
+					l.logger.Printf("peer sync: %v", err)
+				} else {
+					l.logger.Printf("peer sync: %d added, %d updated, %d removed", len(diff.Add), len(diff.Update), len(diff.Remove))
+				}
+			}
+			l.mu.Unlock()
+		}
+
+		// Gateways may have joined or changed; re-apply subnet routes, this
+		// device's exit node role and peer names too.
+		if err := l.SyncSubnetRoutes(ctx); err != nil && ctx.Err() == nil {
+			l.logger.Printf("subnet routes: %v", err)
+		}
+		if err := l.SyncExitGateway(ctx); err != nil && ctx.Err() == nil {
+			l.logger.Printf("exit node: %v", err)
+		}
+		if err := l.SyncDNS(ctx); err != nil && ctx.Err() == nil {
+			l.logger.Printf("mesh DNS: %v", err)
+		}
+	}
+}
+
 // Stop cancels the lifecycle context and waits for shutdown to complete.
 func (l *Lifecycle) Stop() {
 	l.mu.RLock()
@@ -286,19 +407,32 @@ func (l *Lifecycle) GetStatus() Status {
 	l.mu.RLock()
 	defer l.mu.RUnlock()
 	st := l.status
+	if l.exit != nil {
+		st.ExitNode = l.exit.deviceID
+		st.KillSwitch = l.exit.killSwitch
+	}
+	st.SubnetRoutes = slices.Clone(l.subnetRoutes)
+	st.Advertised = slices.Clone(l.advertised)
 	if l.wgBind != nil {
 		st.TxBytes, st.RxBytes = l.wgBind.TrafficStats()
 	}
 	if l.wgTunnel != nil {
+		st.ExitGateway = l.wgTunnel.ExitGateway()
+		stats, err := l.wgTunnel.PeerStats()
+		if err != nil {
+			l.logger.Printf("peer stats: %v", err)
+		}
 		for _, p := range l.wgTunnel.Peers() {
-			ip := ""
-			if len(p.AllowedIPs) > 0 {
-				ip = strings.TrimSuffix(p.AllowedIPs[0], "/32")
-			}
+			ip := wg.PeerOverlayIP(p)
+			ps := stats[p.PublicKey]
 			st.Peers = append(st.Peers, PeerStatus{
-				Name:      p.Endpoint,
-				OverlayIP: ip,
-				Endpoint:  p.Endpoint,
+				Name:          p.Endpoint,
+				OverlayIP:     ip,
+				Endpoint:      p.Endpoint,
+				PublicKey:     p.PublicKey,
+				LastHandshake: ps.LastHandshake,
+				TxBytes:       ps.TxBytes,
+				RxBytes:       ps.RxBytes,
 			})
 		}
 		st.PeerCount = len(st.Peers)
@@ -320,6 +454,7 @@ func (l *Lifecycle) RefreshToken(token string) {
 type WGConfigData struct {
 	PrivateKey string              `json:"private_key"`
 	OverlayIP  string              `json:"overlay_ip"`
+	OverlayIPs []string            `json:"overlay_ips,omitempty"` // all addresses, IPv4 first, on dual-stack meshes
 	DERPURL    string              `json:"derp_url"`
 	Peers      []map[string]string `json:"peers"`
 }
@@ -343,12 +478,16 @@ func (l *Lifecycle) GetWGConfig() *WGConfigData {
 		if len(p.AllowedIPs) > 0 {
 			peer["allowed_ips"] = strings.Join(p.AllowedIPs, ",")
 		}
+		if p.PresharedKey != "" {
+			peer["preshared_key"] = p.PresharedKey
+		}
 		peers = append(peers, peer)
 	}
 
 	return &WGConfigData{
 		PrivateKey: l.wgTunnel.PrivateKeyBase64(),
 		OverlayIP:  l.wgTunnel.OverlayIP(),
+		OverlayIPs: l.wgTunnel.OverlayAddrs(),
 		DERPURL:    l.cfg.DERPURL,
 		Peers:      peers,
 	}
@@ -367,10 +506,13 @@ func (l *Lifecycle) shutdown() {
 		l.wgBind.Close()
 		l.wgBind = nil
 	}
+	l.clearExitNodeLocked()
 	if l.wgTunnel != nil {
 		_ = l.wgTunnel.Stop()
 		l.wgTunnel = nil
 	}
+	l.subnetRoutes, l.advertised = nil, nil
+	l.stopDNSLocked()
 
 	l.status.State = "disconnected"
 
@@ -0,0 +1,52 @@
+package mesh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// relayPingSeq numbers relay ping requests so replies can be matched.
+var relayPingSeq atomic.Int64
+
+// RelayPing sends a ping request for targetClient through the DERP relay on
+// the daemon's own connection and returns the round-trip time.
+func (l *Lifecycle) RelayPing(ctx context.Context, targetClient string) (time.Duration, error) {
+	l.mu.RLock()
+	client := l.derpClient
+	l.mu.RUnlock()
+	if client == nil {
+		return 0, errors.New("not connected")
+	}
+
+	id := fmt.Sprintf("relay-ping-%d-%d", time.Now().UnixNano(), relayPingSeq.Add(1))
+	ch := make(chan string, 1)
+	l.pings.Store(id, ch)
+	defer l.pings.Delete(id)
+
+	start := time.Now()
+	if err := client.SendPingRequest(l.cfg.OrgID, targetClient, id); err != nil {
+		return 0, err
+	}
+	select {
+	case msg := <-ch:
+		if msg != "" {
+			return 0, errors.New(msg)
+		}
+		return time.Since(start), nil
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+}
+
+// notePingResponse hands a relay ping reply to the RelayPing call waiting
+// for it.
+func (l *Lifecycle) notePingResponse(data map[string]interface{}) {
+	id, _ := data["request_id"].(string)
+	errMsg, _ := data["error"].(string)
+	if ch, ok := l.pings.LoadAndDelete(id); ok {
+		ch.(chan string) <- errMsg
+	}
+}
@@ -0,0 +1,163 @@
+package mesh
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/prysmsh/cli/internal/api"
+)
+
+// acceptedRoutesFile stores the subnet route IDs this device routes through
+// their gateways. Acceptance is local: each client opts in per route.
+const acceptedRoutesFile = "accepted_routes.json"
+
+type acceptedRoutes struct {
+	RouteIDs []int64 `json:"route_ids"`
+}
+
+// AcceptSubnetRoute routes the subnet route's CIDR through its gateway,
+// now and after restarts.
+func (l *Lifecycle) AcceptSubnetRoute(ctx context.Context, id int64) error {
+	routes, err := l.listSubnetRoutes(ctx)
+	if err != nil {
+		return err
+	}
+	idx := slices.IndexFunc(routes, func(r api.SubnetRoute) bool { return r.ID == id })
+	if idx < 0 {
+		return fmt.Errorf("subnet route %d not found", id)
+	}
+	if routes[idx].DeviceID == l.cfg.DeviceID {
+		return fmt.Errorf("subnet route %d is advertised by this device", id)
+	}
+
+	accepted, err := loadAcceptedRoutes(l.cfg.HomeDir)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(accepted, id) {
+		if err := saveAcceptedRoutes(l.cfg.HomeDir, append(accepted, id)); err != nil {
+			return err
+		}
+	}
+	return l.applySubnetRoutes(routes)
+}
+
+// RejectSubnetRoute stops routing an accepted subnet route.
+func (l *Lifecycle) RejectSubnetRoute(ctx context.Context, id int64) error {
+	accepted, err := loadAcceptedRoutes(l.cfg.HomeDir)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(accepted, id) {
+		return fmt.Errorf("subnet route %d is not accepted", id)
+	}
+	accepted = slices.DeleteFunc(accepted, func(v int64) bool { return v == id })
+	if err := saveAcceptedRoutes(l.cfg.HomeDir, accepted); err != nil {
+		return err
+	}
+	return l.SyncSubnetRoutes(ctx)
+}
+
+// SyncSubnetRoutes fetches the organization's subnet routes and applies the
+// accepted ones, plus forwarding for those this device advertises.
+func (l *Lifecycle) SyncSubnetRoutes(ctx context.Context) error {
+	routes, err := l.listSubnetRoutes(ctx)
+	if err != nil {
+		return err
+	}
+	return l.applySubnetRoutes(routes)
+}
+
+func (l *Lifecycle) listSubnetRoutes(ctx context.Context) ([]api.SubnetRoute, error) {
+	l.mu.RLock()
+	apiClient := l.apiClient
+	l.mu.RUnlock()
+	if apiClient == nil {
+		return nil, fmt.Errorf("not connected")
+	}
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+	routes, err := apiClient.ListSubnetRoutes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list subnet routes: %w", err)
+	}
+	return routes, nil
+}
+
+func (l *Lifecycle) applySubnetRoutes(routes []api.SubnetRoute) error {
+	accepted, err := loadAcceptedRoutes(l.cfg.HomeDir)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.wgTunnel == nil {
+		return fmt.Errorf("WireGuard tunnel is not up")
+	}
+
+	var (
+		byPeer   = map[string][]string{}
+		gateway  []string
+		routeIDs []int64
+		errs     []error
+	)
+	for _, r := range routes {
+		if r.DeviceID == l.cfg.DeviceID {
+			gateway = append(gateway, r.CIDR)
+			continue
+		}
+		if !slices.Contains(accepted, r.ID) {
+			continue
+		}
+		key, ok := devicePeerKey(l.wgTunnel, r.DeviceID)
+		if !ok {
+			errs = append(errs, fmt.Errorf("subnet route %d: gateway %s is not a WireGuard peer of this device", r.ID, r.DeviceID))
+			continue
+		}
+		byPeer[key] = append(byPeer[key], r.CIDR)
+		routeIDs = append(routeIDs, r.ID)
+	}
+
+	if err := l.wgTunnel.SetSubnetRoutes(byPeer); err != nil {
+		errs = append(errs, err)
+	}
+	if err := l.wgTunnel.SetSubnetGateway(gateway); err != nil {
+		errs = append(errs, err)
+	}
+	l.subnetRoutes = routeIDs
+	l.advertised = l.wgTunnel.SubnetGateway()
+	return errors.Join(errs...)
+}
+
+func loadAcceptedRoutes(homeDir string) ([]int64, error) {
+	data, err := os.ReadFile(filepath.Join(homeDir, acceptedRoutesFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read accepted routes: %w", err)
+	}
+	var a acceptedRoutes
+	if err := json.Unmarshal(data, &a); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", acceptedRoutesFile, err)
+	}
+	return a.RouteIDs, nil
+}
+
+func saveAcceptedRoutes(homeDir string, ids []int64) error {
+	data, err := json.MarshalIndent(acceptedRoutes{RouteIDs: ids}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(homeDir, acceptedRoutesFile), append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("save accepted routes: %w", err)
+	}
+	return nil
+}
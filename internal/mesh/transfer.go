@@ -0,0 +1,307 @@
+package mesh
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultTransferPort is the TCP port `prysm mesh receive` listens on, on
+// the device's overlay address.
+const DefaultTransferPort = 41670
+
+// maxTransferLine bounds the JSON control messages exchanged before and
+// after the file data.
+const maxTransferLine = 4096
+
+// TransferHeader opens a transfer: the sender announces the file and its
+// SHA-256 before any data is sent.
+type TransferHeader struct {
+	Name   string `json:"name"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// transferReply is sent by the receiver twice: once with the offset to
+// resume from, and once with the saved path after verification.
+type transferReply struct {
+	Offset int64  `json:"offset"`
+	Path   string `json:"path,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// TransferResult summarizes a completed transfer on either side.
+type TransferResult struct {
+	Name    string `json:"name"`
+	Size    int64  `json:"size"`
+	SHA256  string `json:"sha256"`
+	Resumed int64  `json:"resumed"` // bytes already present at the receiver
+	Path    string `json:"path"`    // where the receiver saved the file
+}
+
+// SendFile streams the file at path over conn. If the receiver holds part of
+// the same file from an interrupted transfer, only the remainder is sent.
+// progress, if set, is called with the number of bytes written so far.
+func SendFile(conn io.ReadWriter, path string, progress func(int64)) (*TransferResult, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	st, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if !st.Mode().IsRegular() {
+		return nil, fmt.Errorf("%s is not a regular file", path)
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return nil, fmt.Errorf("hash %s: %w", path, err)
+	}
+	hdr := TransferHeader{Name: filepath.Base(path), Size: st.Size(), SHA256: hex.EncodeToString(h.Sum(nil))}
+
+	br := bufio.NewReader(conn)
+	if err := writeTransferLine(conn, hdr); err != nil {
+		return nil, fmt.Errorf("send header: %w", err)
+	}
+	var reply transferReply
+	if err := readTransferLine(br, &reply); err != nil {
+		return nil, fmt.Errorf("read reply: %w", err)
+	}
+	if reply.Error != "" {
+		return nil, fmt.Errorf("receiver: %s", reply.Error)
+	}
+	if reply.Offset < 0 || reply.Offset > hdr.Size {
+		return nil, fmt.Errorf("receiver asked to resume at invalid offset %d", reply.Offset)
+	}
+
+	if _, err := f.Seek(reply.Offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	var w io.Writer = conn
+	if progress != nil {
+		w = &progressWriter{w: conn, n: reply.Offset, fn: progress}
+	}
+	if _, err := io.Copy(w, f); err != nil {
+		return nil, fmt.Errorf("send data: %w", err)
+	}
+
+	var done transferReply
+	if err := readTransferLine(br, &done); err != nil {
+		return nil, fmt.Errorf("read confirmation: %w", err)
+	}
+	if done.Error != "" {
+		return nil, fmt.Errorf("receiver: %s", done.Error)
+	}
+	return &TransferResult{Name: hdr.Name, Size: hdr.Size, SHA256: hdr.SHA256, Resumed: reply.Offset, Path: done.Path}, nil
+}
+
+// PartFileMaxAge is how long an incomplete transfer's .part file is kept for
+// the sender to resume before CleanStaleParts removes it.
+const PartFileMaxAge = 24 * time.Hour
+
+// ReceiveOptions controls which files ReceiveFile accepts.
+type ReceiveOptions struct {
+	Overwrite bool  // replace existing files with the same name
+	MaxSize   int64 // largest file accepted; 0 means only free space limits it
+	// Accept, if set, is asked about each file before any data is
+	// transferred; returning an error refuses it.
+	Accept func(TransferHeader) error
+}
+
+// receiving holds the .part files being written, so concurrent transfers of
+// the same file do not interleave and in-use files are not cleaned up.
+var receiving sync.Map
+
+// ReceiveFile accepts one file from conn into dir. Data is written to a
+// hidden .part file first, so an interrupted transfer resumes when the same
+// file is sent again; the file is renamed into place only once its SHA-256
+// matches the sender's. Files that are too large for opts.MaxSize or the free
+// disk space are refused before any data is sent.
+func ReceiveFile(conn io.ReadWriter, dir string, opts ReceiveOptions) (*TransferResult, error) {
+	br := bufio.NewReader(conn)
+	var hdr TransferHeader
+	if err := readTransferLine(br, &hdr); err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+	res, err := receiveFile(conn, br, hdr, dir, opts)
+	if err != nil {
+		_ = writeTransferLine(conn, transferReply{Error: err.Error()})
+		return nil, err
+	}
+	if err := writeTransferLine(conn, transferReply{Offset: hdr.Size, Path: res.Path}); err != nil {
+		return nil, fmt.Errorf("send confirmation: %w", err)
+	}
+	return res, nil
+}
+
+func receiveFile(conn io.Writer, br *bufio.Reader, hdr TransferHeader, dir string, opts ReceiveOptions) (*TransferResult, error) {
+	if err := validateTransferHeader(hdr); err != nil {
+		return nil, err
+	}
+	if opts.MaxSize > 0 && hdr.Size > opts.MaxSize {
+		return nil, fmt.Errorf("%s is %d bytes, over the %d byte limit", hdr.Name, hdr.Size, opts.MaxSize)
+	}
+	dest := filepath.Join(dir, hdr.Name)
+	if _, err := os.Stat(dest); err == nil && !opts.Overwrite {
+		return nil, fmt.Errorf("%s already exists", hdr.Name)
+	}
+	if opts.Accept != nil {
+		if err := opts.Accept(hdr); err != nil {
+			return nil, err
+		}
+	}
+
+	// The part file is keyed by content hash, so a different file with the
+	// same name never resumes from stale data.
+	part := filepath.Join(dir, "."+hdr.Name+"."+hdr.SHA256[:12]+".part")
+	if _, busy := receiving.LoadOrStore(part, struct{}{}); busy {
+		return nil, fmt.Errorf("%s is already being received", hdr.Name)
+	}
+	defer receiving.Delete(part)
+	f, err := os.OpenFile(part, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", filepath.Base(part), err)
+	}
+	defer f.Close()
+
+	st, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	offset := st.Size()
+	if offset > hdr.Size {
+		if err := f.Truncate(0); err != nil {
+			return nil, err
+		}
+		offset = 0
+	}
+	if free, err := diskFree(dir); err == nil && hdr.Size-offset > free {
+		return nil, fmt.Errorf("not enough disk space for %s: %d bytes needed, %d free", hdr.Name, hdr.Size-offset, free)
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, io.LimitReader(f, offset)); err != nil {
+		return nil, fmt.Errorf("hash partial file: %w", err)
+	}
+
+	if err := writeTransferLine(conn, transferReply{Offset: offset}); err != nil {
+		return nil, fmt.Errorf("send offset: %w", err)
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	n, err := io.Copy(io.MultiWriter(f, h), io.LimitReader(br, hdr.Size-offset))
+	if err == nil && n < hdr.Size-offset {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		// Keep what arrived; the next attempt resumes from it.
+		return nil, fmt.Errorf("receive %s: %w (%d of %d bytes saved)", hdr.Name, err, offset+n, hdr.Size)
+	}
+
+	if sum := hex.EncodeToString(h.Sum(nil)); sum != hdr.SHA256 {
+		f.Close()
+		os.Remove(part)
+		return nil, fmt.Errorf("checksum mismatch for %s: got %s, want %s", hdr.Name, sum, hdr.SHA256)
+	}
+	if err := f.Sync(); err != nil {
+		return nil, err
+	}
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(part, dest); err != nil {
+		return nil, fmt.Errorf("save %s: %w", hdr.Name, err)
+	}
+	return &TransferResult{Name: hdr.Name, Size: hdr.Size, SHA256: hdr.SHA256, Resumed: offset, Path: dest}, nil
+}
+
+// CleanStaleParts removes .part files in dir that have not been written to
+// for maxAge, and returns how many it removed. Transfers in progress are left
+// alone.
+func CleanStaleParts(dir string, maxAge time.Duration) (int, error) {
+	parts, err := filepath.Glob(filepath.Join(dir, ".*.part"))
+	if err != nil {
+		return 0, err
+	}
+	removed := 0
+	for _, part := range parts {
+		if _, busy := receiving.Load(part); busy {
+			continue
+		}
+		st, err := os.Stat(part)
+		if err != nil || !st.Mode().IsRegular() || time.Since(st.ModTime()) < maxAge {
+			continue
+		}
+		if err := os.Remove(part); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+func validateTransferHeader(hdr TransferHeader) error {
+	name := hdr.Name
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) || filepath.Base(name) != name {
+		return fmt.Errorf("invalid file name %q", name)
+	}
+	if hdr.Size < 0 {
+		return fmt.Errorf("invalid size %d", hdr.Size)
+	}
+	if b, err := hex.DecodeString(hdr.SHA256); err != nil || len(b) != sha256.Size {
+		return errors.New("invalid sha256")
+	}
+	return nil
+}
+
+func writeTransferLine(w io.Writer, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+func readTransferLine(br *bufio.Reader, v interface{}) error {
+	var line []byte
+	for {
+		chunk, err := br.ReadSlice('\n')
+		line = append(line, chunk...)
+		if len(line) > maxTransferLine {
+			return errors.New("control message too long")
+		}
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(line, v)
+	}
+}
+
+type progressWriter struct {
+	w  io.Writer
+	n  int64
+	fn func(int64)
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.n += int64(n)
+	p.fn(p.n)
+	return n, err
+}
@@ -0,0 +1,195 @@
+package mesh
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// transfer runs SendFile and ReceiveFile against each other over a pipe.
+func transfer(t *testing.T, src, dir string, opts ReceiveOptions) (sent, got *TransferResult, sendErr, recvErr error) {
+	t.Helper()
+	a, b := net.Pipe()
+	defer a.Close()
+	defer b.Close()
+
+	type result struct {
+		res *TransferResult
+		err error
+	}
+	recvCh := make(chan result, 1)
+	go func() {
+		res, err := ReceiveFile(b, dir, opts)
+		recvCh <- result{res, err}
+	}()
+	sent, sendErr = SendFile(a, src, nil)
+	a.Close()
+	r := <-recvCh
+	return sent, r.res, sendErr, r.err
+}
+
+func writeTransferSource(t *testing.T, data []byte) string {
+	t.Helper()
+	src := filepath.Join(t.TempDir(), "report.bin")
+	if err := os.WriteFile(src, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return src
+}
+
+func TestTransferFile(t *testing.T) {
+	data := bytes.Repeat([]byte("prysm mesh "), 10000)
+	src := writeTransferSource(t, data)
+	dir := t.TempDir()
+
+	sent, got, sendErr, recvErr := transfer(t, src, dir, ReceiveOptions{})
+	if sendErr != nil || recvErr != nil {
+		t.Fatalf("transfer: send=%v receive=%v", sendErr, recvErr)
+	}
+	if sent.Resumed != 0 || sent.Path != filepath.Join(dir, "report.bin") || got.Path != sent.Path {
+		t.Errorf("unexpected results: sent %+v, received %+v", sent, got)
+	}
+	saved, err := os.ReadFile(sent.Path)
+	if err != nil || !bytes.Equal(saved, data) {
+		t.Fatalf("saved file differs (err %v)", err)
+	}
+	if parts, _ := filepath.Glob(filepath.Join(dir, ".*.part")); len(parts) != 0 {
+		t.Errorf("part files left behind: %v", parts)
+	}
+
+	// A second send of the same name is refused unless overwriting.
+	if _, _, sendErr, _ := transfer(t, src, dir, ReceiveOptions{}); sendErr == nil || !strings.Contains(sendErr.Error(), "already exists") {
+		t.Errorf("expected already exists error, got %v", sendErr)
+	}
+	if _, _, sendErr, recvErr := transfer(t, src, dir, ReceiveOptions{Overwrite: true}); sendErr != nil || recvErr != nil {
+		t.Errorf("overwrite: send=%v receive=%v", sendErr, recvErr)
+	}
+}
+
+func TestTransferResume(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), 5000)
+	src := writeTransferSource(t, data)
+	dir := t.TempDir()
+	sum := sha256.Sum256(data)
+	part := filepath.Join(dir, ".report.bin."+hex.EncodeToString(sum[:])[:12]+".part")
+	if err := os.WriteFile(part, data[:20000], 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	sent, _, sendErr, recvErr := transfer(t, src, dir, ReceiveOptions{})
+	if sendErr != nil || recvErr != nil {
+		t.Fatalf("transfer: send=%v receive=%v", sendErr, recvErr)
+	}
+	if sent.Resumed != 20000 {
+		t.Errorf("Resumed = %d, want 20000", sent.Resumed)
+	}
+	if saved, _ := os.ReadFile(sent.Path); !bytes.Equal(saved, data) {
+		t.Error("resumed file differs from source")
+	}
+}
+
+func TestTransferChecksumMismatch(t *testing.T) {
+	data := bytes.Repeat([]byte("abcdef"), 1000)
+	src := writeTransferSource(t, data)
+	dir := t.TempDir()
+	sum := sha256.Sum256(data)
+	part := filepath.Join(dir, ".report.bin."+hex.EncodeToString(sum[:])[:12]+".part")
+	corrupt := bytes.Repeat([]byte("x"), 100)
+	if err := os.WriteFile(part, corrupt, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, sendErr, recvErr := transfer(t, src, dir, ReceiveOptions{})
+	if recvErr == nil || !strings.Contains(recvErr.Error(), "checksum mismatch") {
+		t.Fatalf("expected checksum mismatch, got %v", recvErr)
+	}
+	if sendErr == nil {
+		t.Error("sender should see the receiver's error")
+	}
+	if _, err := os.Stat(part); !os.IsNotExist(err) {
+		t.Error("corrupt part file should be removed")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "report.bin")); !os.IsNotExist(err) {
+		t.Error("unverified file must not be saved")
+	}
+
+	// The retry starts from scratch and succeeds.
+	if _, _, sendErr, recvErr := transfer(t, src, dir, ReceiveOptions{}); sendErr != nil || recvErr != nil {
+		t.Errorf("retry: send=%v receive=%v", sendErr, recvErr)
+	}
+}
+
+func TestTransferLimits(t *testing.T) {
+	src := writeTransferSource(t, bytes.Repeat([]byte("x"), 1000))
+	dir := t.TempDir()
+
+	if _, _, sendErr, _ := transfer(t, src, dir, ReceiveOptions{MaxSize: 999}); sendErr == nil || !strings.Contains(sendErr.Error(), "limit") {
+		t.Errorf("oversized file: got %v, want limit error", sendErr)
+	}
+	refuse := func(TransferHeader) error { return errors.New("declined") }
+	if _, _, sendErr, _ := transfer(t, src, dir, ReceiveOptions{Accept: refuse}); sendErr == nil || !strings.Contains(sendErr.Error(), "declined") {
+		t.Errorf("declined file: got %v", sendErr)
+	}
+	if parts, _ := filepath.Glob(filepath.Join(dir, ".*.part")); len(parts) != 0 {
+		t.Errorf("refused transfers left part files: %v", parts)
+	}
+	var asked TransferHeader
+	accept := func(h TransferHeader) error { asked = h; return nil }
+	if _, _, sendErr, recvErr := transfer(t, src, dir, ReceiveOptions{MaxSize: 1000, Accept: accept}); sendErr != nil || recvErr != nil {
+		t.Errorf("accepted file: send=%v receive=%v", sendErr, recvErr)
+	}
+	if asked.Name != "report.bin" || asked.Size != 1000 {
+		t.Errorf("Accept got %+v", asked)
+	}
+}
+
+func TestCleanStaleParts(t *testing.T) {
+	dir := t.TempDir()
+	stale := filepath.Join(dir, ".old.bin.0123456789ab.part")
+	fresh := filepath.Join(dir, ".new.bin.0123456789ab.part")
+	for _, p := range []string{stale, fresh} {
+		if err := os.WriteFile(p, []byte("partial"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	old := time.Now().Add(-2 * PartFileMaxAge)
+	if err := os.Chtimes(stale, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := CleanStaleParts(dir, PartFileMaxAge)
+	if err != nil || n != 1 {
+		t.Fatalf("CleanStaleParts = %d, %v; want 1", n, err)
+	}
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Error("stale part file was kept")
+	}
+	if _, err := os.Stat(fresh); err != nil {
+		t.Error("recent part file was removed")
+	}
+}
+
+func TestValidateTransferHeader(t *testing.T) {
+	sum := strings.Repeat("ab", sha256.Size)
+	for _, name := range []string{"", ".", "..", "../etc/passwd", "a/b", `a\b`} {
+		if err := validateTransferHeader(TransferHeader{Name: name, SHA256: sum}); err == nil {
+			t.Errorf("name %q: expected error", name)
+		}
+	}
+	if err := validateTransferHeader(TransferHeader{Name: "ok.txt", Size: -1, SHA256: sum}); err == nil {
+		t.Error("negative size: expected error")
+	}
+	if err := validateTransferHeader(TransferHeader{Name: "ok.txt", SHA256: "abc"}); err == nil {
+		t.Error("short sha256: expected error")
+	}
+	if err := validateTransferHeader(TransferHeader{Name: "ok.txt", Size: 3, SHA256: sum}); err != nil {
+		t.Errorf("valid header: %v", err)
+	}
+}
@@ -0,0 +1,15 @@
+//go:build unix
+
+package mesh
+
+import "golang.org/x/sys/unix"
+
+// diskFree returns the bytes available to this user on the filesystem
+// holding dir.
+func diskFree(dir string) (int64, error) {
+	var st unix.Statfs_t
+	if err := unix.Statfs(dir, &st); err != nil {
+		return 0, err
+	}
+	return int64(st.Bavail) * int64(st.Bsize), nil
+}
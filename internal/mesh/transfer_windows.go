@@ -0,0 +1,17 @@
+package mesh
+
+import "golang.org/x/sys/windows"
+
+// diskFree returns the bytes available to this user on the volume holding
+// dir.
+func diskFree(dir string) (int64, error) {
+	p, err := windows.UTF16PtrFromString(dir)
+	if err != nil {
+		return 0, err
+	}
+	var free uint64
+	if err := windows.GetDiskFreeSpaceEx(p, &free, nil, nil); err != nil {
+		return 0, err
+	}
+	return int64(free), nil
+}
@@ -2,6 +2,7 @@ package meshd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
 	"os"
@@ -66,6 +67,97 @@ func GetStatus() (*Response, error) {
 	return Send(Request{Cmd: "status"})
 }
 
+// GetHealth queries the daemon's health summary.
+func GetHealth() (*Health, error) {
+	resp, err := Send(Request{Cmd: "health"})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("meshd: %s", resp.Error)
+	}
+	if resp.Health == nil {
+		return nil, fmt.Errorf("meshd: daemon does not support health checks; restart it after upgrading")
+	}
+	return resp.Health, nil
+}
+
+// WaitPeerEvent blocks until the daemon has seen a DERP peer event after
+// since, or about 20 seconds have passed, and returns its event count. Since 0
+// returns the current count at once.
+func WaitPeerEvent(since int64) (int64, error) {
+	resp, err := Send(Request{Cmd: "peer_events", Since: since})
+	if err != nil {
+		return 0, err
+	}
+	if resp.Error != "" {
+		return 0, fmt.Errorf("meshd: %s", resp.Error)
+	}
+	if resp.EventSeq == 0 {
+		return 0, fmt.Errorf("meshd: daemon does not support peer events; restart it after upgrading")
+	}
+	return resp.EventSeq, nil
+}
+
+// RelayPing asks the daemon to ping target (a DERP client ID) through its
+// relay connection and returns the round-trip time.
+func RelayPing(target string, timeout time.Duration) (time.Duration, error) {
+	resp, err := Send(Request{Cmd: "relay_ping", Target: target, TimeoutMS: timeout.Milliseconds()})
+	if err != nil {
+		return 0, err
+	}
+	if resp.Error != "" {
+		return 0, errors.New(resp.Error)
+	}
+	if resp.RTTNanos == 0 {
+		return 0, fmt.Errorf("meshd: daemon does not support relay pings; restart it after upgrading")
+	}
+	return time.Duration(resp.RTTNanos), nil
+}
+
+// Reload tells the daemon to tear down and re-apply its last connect config.
+func Reload() (*Response, error) {
+	return Send(Request{Cmd: "reload"})
+}
+
+// UseExitNode asks the daemon to route all traffic through the exit node with
+// the given device ID.
+func UseExitNode(deviceID string, killSwitch bool) (*Response, error) {
+	return Send(Request{
+		Cmd:        "exit_use",
+		ExitNode:   deviceID,
+		KillSwitch: killSwitch,
+	})
+}
+
+// ClearExitNode asks the daemon to stop using an exit node.
+func ClearExitNode() (*Response, error) {
+	return Send(Request{Cmd: "exit_off"})
+}
+
+// SyncExitGateway asks the daemon to re-apply this device's exit node role,
+// e.g. after it was enabled or disabled.
+func SyncExitGateway() (*Response, error) {
+	return Send(Request{Cmd: "exit_sync"})
+}
+
+// AcceptSubnetRoute asks the daemon to route a subnet route's CIDR through
+// its gateway.
+func AcceptSubnetRoute(routeID int64) (*Response, error) {
+	return Send(Request{Cmd: "subnet_accept", RouteID: routeID})
+}
+
+// RejectSubnetRoute asks the daemon to stop routing an accepted subnet route.
+func RejectSubnetRoute(routeID int64) (*Response, error) {
+	return Send(Request{Cmd: "subnet_reject", RouteID: routeID})
+}
+
+// SyncSubnetRoutes asks the daemon to re-apply subnet routes, e.g. after this
+// device advertised or withdrew one.
+func SyncSubnetRoutes() (*Response, error) {
+	return Send(Request{Cmd: "subnet_sync"})
+}
+
 // RefreshToken sends a new auth token to the daemon.
 func RefreshToken(token string) (*Response, error) {
 	return Send(Request{
@@ -73,3 +165,13 @@ func RefreshToken(token string) (*Response, error) {
 		Token: token,
 	})
 }
+
+// Logs returns the daemon's recent log lines. With since > 0 it returns the
+// lines logged from that sequence number on; otherwise the last lines lines.
+func Logs(lines int, since int64) (*Response, error) {
+	return Send(Request{
+		Cmd:   "logs",
+		Lines: lines,
+		Since: since,
+	})
+}
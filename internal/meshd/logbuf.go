@@ -0,0 +1,73 @@
+package meshd
+
+import (
+	"bytes"
+	"sync"
+	"time"
+)
+
+// logBuffer keeps the most recent daemon log lines so clients can read them
+// over the socket without knowing where the service manager sends output.
+// Sequence numbers start at 1, so a follower never passes 0 (which asks for a
+// tail) to Since. They restart with every daemon run; epoch tells runs apart.
+type logBuffer struct {
+	mu      sync.Mutex
+	lines   []string
+	max     int
+	next    int64 // sequence number of the next line written
+	partial []byte
+	epoch   int64
+}
+
+func newLogBuffer(max int) *logBuffer {
+	return &logBuffer{max: max, next: 1, epoch: time.Now().UnixNano()}
+}
+
+// Write implements io.Writer, splitting p into lines.
+func (b *logBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	data := append(b.partial, p...)
+	for {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			break
+		}
+		b.lines = append(b.lines, string(data[:i]))
+		b.next++
+		data = data[i+1:]
+	}
+	b.partial = append([]byte(nil), data...)
+	if over := len(b.lines) - b.max; over > 0 {
+		b.lines = append([]string(nil), b.lines[over:]...)
+	}
+	return len(p), nil
+}
+
+// Tail returns the last n lines and the sequence number to pass to Since.
+func (b *logBuffer) Tail(n int) ([]string, int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if n <= 0 || n > len(b.lines) {
+		n = len(b.lines)
+	}
+	return append([]string(nil), b.lines[len(b.lines)-n:]...), b.next
+}
+
+// Since returns the lines written at or after seq. Lines that have already
+// been evicted are skipped.
+func (b *logBuffer) Since(seq int64) ([]string, int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	first := b.next - int64(len(b.lines))
+	if seq < first {
+		seq = first
+	}
+	if seq >= b.next {
+		return nil, b.next
+	}
+	return append([]string(nil), b.lines[seq-first:]...), b.next
+}
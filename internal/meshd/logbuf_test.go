@@ -0,0 +1,39 @@
+package meshd
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestLogBuffer(t *testing.T) {
+	b := newLogBuffer(3)
+	fmt.Fprint(b, "one\ntw")
+	fmt.Fprint(b, "o\nthree\n")
+
+	lines, seq := b.Tail(10)
+	if !reflect.DeepEqual(lines, []string{"one", "two", "three"}) || seq != 4 {
+		t.Fatalf("Tail = %q, %d", lines, seq)
+	}
+	if lines, _ := b.Tail(1); !reflect.DeepEqual(lines, []string{"three"}) {
+		t.Errorf("Tail(1) = %q", lines)
+	}
+
+	fmt.Fprint(b, "four\nfive\n")
+	lines, seq = b.Since(4)
+	if !reflect.DeepEqual(lines, []string{"four", "five"}) || seq != 6 {
+		t.Errorf("Since(4) = %q, %d", lines, seq)
+	}
+	// Evicted lines are skipped rather than reported.
+	if lines, _ := b.Since(1); !reflect.DeepEqual(lines, []string{"three", "four", "five"}) {
+		t.Errorf("Since(1) = %q", lines)
+	}
+	if lines, seq := b.Since(6); lines != nil || seq != 6 {
+		t.Errorf("Since(6) = %q, %d", lines, seq)
+	}
+
+	// An empty buffer still hands out a non-zero sequence number.
+	if _, seq := newLogBuffer(3).Tail(10); seq != 1 {
+		t.Errorf("empty Tail seq = %d, want 1", seq)
+	}
+}
@@ -0,0 +1,188 @@
+package meshd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Health summarizes daemon and tunnel state for monitoring.
+type Health struct {
+	Healthy   bool   `json:"healthy"`
+	State     string `json:"state"` // connected, reconnecting, disconnected
+	OverlayIP string `json:"overlay_ip,omitempty"`
+	Interface string `json:"interface,omitempty"`
+	PeerCount int    `json:"peer_count"`
+	Uptime    int64  `json:"uptime"` // seconds
+	TxBytes   int64  `json:"tx_bytes"`
+	RxBytes   int64  `json:"rx_bytes"`
+}
+
+// meshStates are reported as prysm_mesh_state{state=...}.
+var meshStates = []string{"connected", "reconnecting", "disconnected"}
+
+func (s *Server) health() Health {
+	st := s.handleStatus()
+	return Health{
+		Healthy:   st.Status == "connected",
+		State:     st.Status,
+		OverlayIP: st.OverlayIP,
+		Interface: st.Interface,
+		PeerCount: st.PeerCount,
+		Uptime:    st.Uptime,
+		TxBytes:   st.TxBytes,
+		RxBytes:   st.RxBytes,
+	}
+}
+
+func (s *Server) handleHealth() Response {
+	h := s.health()
+	return Response{Status: "ok", Health: &h}
+}
+
+// ServeMetrics serves /healthz and Prometheus /metrics over HTTP on addr
+// until ctx is cancelled. The endpoints are unauthenticated, so an address
+// without a host (":9469" or "9469") binds to loopback. The control socket
+// serves the same endpoints to its authorized users.
+func (s *Server) ServeMetrics(ctx context.Context, addr string) error {
+	ln, err := net.Listen("tcp", metricsListenAddr(addr))
+	if err != nil {
+		return fmt.Errorf("listen metrics: %w", err)
+	}
+	if tcp, ok := ln.Addr().(*net.TCPAddr); ok && !tcp.IP.IsLoopback() {
+		s.logger.Printf("warning: unauthenticated metrics on %s are reachable from other hosts", tcp)
+	}
+	srv := &http.Server{Handler: s.metricsHandler(), ReadHeaderTimeout: 5 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+	s.logger.Printf("metrics listening on %s", ln.Addr())
+	if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("serve metrics: %w", err)
+	}
+	return nil
+}
+
+// metricsListenAddr binds addresses without a host to loopback.
+func metricsListenAddr(addr string) string {
+	if _, err := strconv.Atoi(addr); err == nil {
+		return net.JoinHostPort("127.0.0.1", addr)
+	}
+	if host, port, err := net.SplitHostPort(addr); err == nil && host == "" {
+		return net.JoinHostPort("127.0.0.1", port)
+	}
+	return addr
+}
+
+// startSocketHTTP serves /healthz and /metrics to HTTP clients of the
+// control socket (curl --unix-socket) until ctx is cancelled.
+func (s *Server) startSocketHTTP(ctx context.Context, addr net.Addr) {
+	s.httpConns = &connListener{conns: make(chan net.Conn), done: make(chan struct{}), addr: addr}
+	srv := &http.Server{Handler: s.metricsHandler(), ReadHeaderTimeout: 5 * time.Second, IdleTimeout: 30 * time.Second}
+	go func() {
+		<-ctx.Done()
+		_ = srv.Close()
+	}()
+	go func() { _ = srv.Serve(s.httpConns) }()
+}
+
+// serveSocketHTTP hands an HTTP connection on the control socket to the
+// metrics server. It reports false if that server is not running.
+func (s *Server) serveSocketHTTP(conn net.Conn) bool {
+	if s.httpConns == nil {
+		return false
+	}
+	_ = conn.SetDeadline(time.Time{})
+	select {
+	case s.httpConns.conns <- conn:
+		return true
+	case <-s.httpConns.done:
+		return false
+	}
+}
+
+// isHTTPRequest reports whether a control socket client speaks HTTP rather
+// than the JSON protocol.
+func isHTTPRequest(br *bufio.Reader) bool {
+	b, err := br.Peek(4)
+	return err == nil && (string(b) == "GET " || string(b) == "HEAD")
+}
+
+// connListener is a net.Listener fed with connections accepted elsewhere.
+type connListener struct {
+	conns chan net.Conn
+	done  chan struct{}
+	once  sync.Once
+	addr  net.Addr
+}
+
+func (l *connListener) Accept() (net.Conn, error) {
+	select {
+	case c := <-l.conns:
+		return c, nil
+	case <-l.done:
+		return nil, net.ErrClosed
+	}
+}
+
+func (l *connListener) Close() error {
+	l.once.Do(func() { close(l.done) })
+	return nil
+}
+
+func (l *connListener) Addr() net.Addr { return l.addr }
+
+// bufferedConn is a connection whose first bytes were already read into r.
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(p []byte) (int, error) { return c.r.Read(p) }
+
+func (s *Server) metricsHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		h := s.health()
+		w.Header().Set("Content-Type", "application/json")
+		if !h.Healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(h)
+	})
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		h := s.health()
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		writeMetric(w, "prysm_mesh_up", "gauge", "Whether the mesh tunnel is connected.", boolMetric(h.Healthy))
+		fmt.Fprintf(w, "# HELP prysm_mesh_state Current mesh lifecycle state.\n# TYPE prysm_mesh_state gauge\n")
+		for _, state := range meshStates {
+			fmt.Fprintf(w, "prysm_mesh_state{state=%q} %d\n", state, boolMetric(h.State == state))
+		}
+		writeMetric(w, "prysm_mesh_peers", "gauge", "Number of WireGuard peers.", int64(h.PeerCount))
+		writeMetric(w, "prysm_mesh_uptime_seconds", "gauge", "Seconds since the mesh last connected.", h.Uptime)
+		writeMetric(w, "prysm_mesh_transmit_bytes_total", "counter", "Bytes sent over the mesh tunnel.", h.TxBytes)
+		writeMetric(w, "prysm_mesh_receive_bytes_total", "counter", "Bytes received over the mesh tunnel.", h.RxBytes)
+	})
+	return mux
+}
+
+func writeMetric(w http.ResponseWriter, name, typ, help string, value int64) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n%s %d\n", name, help, name, typ, name, value)
+}
+
+func boolMetric(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,113 @@
+package meshd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMetricsHandlerDisconnected(t *testing.T) {
+	s := NewServer(t.TempDir() + "/mesh.sock")
+	h := s.metricsHandler()
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("/healthz status = %d, want 503", rec.Code)
+	}
+	var health Health
+	if err := json.Unmarshal(rec.Body.Bytes(), &health); err != nil || health.Healthy || health.State != "disconnected" {
+		t.Errorf("/healthz body = %s (%v)", rec.Body, err)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := rec.Body.String()
+	for _, want := range []string{
+		"prysm_mesh_up 0\n",
+		`prysm_mesh_state{state="disconnected"} 1`,
+		`prysm_mesh_state{state="connected"} 0`,
+		"# TYPE prysm_mesh_receive_bytes_total counter",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("/metrics missing %q:\n%s", want, body)
+		}
+	}
+}
+
+func TestMetricsOnControlSocket(t *testing.T) {
+	dir, err := os.MkdirTemp("", "meshd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	sock := filepath.Join(dir, "mesh.sock")
+	s := NewServer(sock)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.Serve(ctx)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		conn, err := net.Dial("unix", sock)
+		if err == nil {
+			conn.Close()
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("daemon socket did not come up: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", sock)
+		},
+	}}
+	resp, err := client.Get("http://meshd/metrics")
+	if err != nil {
+		t.Fatalf("GET /metrics over the socket: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), "prysm_mesh_up 0") {
+		t.Errorf("/metrics = %d:\n%s", resp.StatusCode, body)
+	}
+
+	// JSON clients share the socket.
+	conn, err := net.Dial("unix", sock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	var health Response
+	if err := json.NewEncoder(conn).Encode(Request{Cmd: "health"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.NewDecoder(conn).Decode(&health); err != nil || health.Health == nil || health.Health.State != "disconnected" {
+		t.Errorf("health over the socket = %+v, %v", health, err)
+	}
+}
+
+func TestMetricsListenAddr(t *testing.T) {
+	for in, want := range map[string]string{
+		":9469":          "127.0.0.1:9469",
+		"9469":           "127.0.0.1:9469",
+		"127.0.0.1:9469": "127.0.0.1:9469",
+		"0.0.0.0:9469":   "0.0.0.0:9469",
+		"[::1]:9469":     "[::1]:9469",
+	} {
+		if got := metricsListenAddr(in); got != want {
+			t.Errorf("metricsListenAddr(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
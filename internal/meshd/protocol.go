@@ -2,19 +2,30 @@ package meshd
 
 // Request is a command from CLI to daemon.
 type Request struct {
-	Cmd      string `json:"cmd"`               // "connect", "disconnect", "status", "refresh_token"
+	Cmd      string `json:"cmd"`               // "connect", "disconnect", "status", "refresh_token", "reload", "health", "logs", "exit_use", "exit_off", "exit_sync", "subnet_accept", "subnet_reject", "subnet_sync", "peer_events", "relay_ping"
 	Token    string `json:"token,omitempty"`    // session token (for connect, refresh_token)
 	APIURL   string `json:"api_url,omitempty"`
 	DERPURL  string `json:"derp_url,omitempty"`
 	DeviceID string `json:"device_id,omitempty"`
 	HomeDir  string `json:"home_dir,omitempty"`
+	Lines    int    `json:"lines,omitempty"`   // logs: number of recent lines
+	Since    int64  `json:"since,omitempty"`   // logs: return lines from this sequence number; peer_events: wait for events after this count
+	ExitNode   string `json:"exit_node,omitempty"`   // exit_use: device ID of the exit node
+	KillSwitch bool   `json:"kill_switch,omitempty"` // exit_use: drop traffic while the tunnel is down
+	RouteID    int64  `json:"route_id,omitempty"`    // subnet_accept, subnet_reject: subnet route ID
+	Target     string `json:"target,omitempty"`      // relay_ping: DERP client ID of the peer
+	TimeoutMS  int64  `json:"timeout_ms,omitempty"`  // relay_ping: how long to wait for the reply
 }
 
 // PeerInfo describes a mesh peer for display purposes.
 type PeerInfo struct {
-	Name      string `json:"name"`
-	OverlayIP string `json:"overlay_ip"`
-	Endpoint  string `json:"endpoint"`
+	Name          string `json:"name"`
+	OverlayIP     string `json:"overlay_ip"`
+	Endpoint      string `json:"endpoint"`
+	PublicKey     string `json:"public_key,omitempty"`
+	LastHandshake int64  `json:"last_handshake,omitempty"` // unix seconds; 0 = never
+	TxBytes       int64  `json:"tx_bytes,omitempty"`
+	RxBytes       int64  `json:"rx_bytes,omitempty"`
 }
 
 // Response is a reply from daemon to CLI.
@@ -29,14 +40,24 @@ type Response struct {
 	RxBytes   int64      `json:"rx_bytes,omitempty"`
 	Error     string     `json:"error,omitempty"`
 	WGConfig  *WGConfig  `json:"wg_config,omitempty"`  // returned by "wg_config" command
+	Health    *Health    `json:"health,omitempty"`     // returned by "health" command
+	Logs      []string   `json:"logs,omitempty"`       // returned by "logs" command
+	LogSeq    int64      `json:"log_seq,omitempty"`    // pass as Since to read newer lines
+	LogEpoch  int64      `json:"log_epoch,omitempty"`  // changes when the daemon restarts and LogSeq starts over
+	EventSeq  int64      `json:"event_seq,omitempty"`  // peer_events: DERP peer events seen; pass as Since to wait for the next
+	RTTNanos  int64      `json:"rtt_ns,omitempty"`     // relay_ping: round-trip time
+	ExitNode   string    `json:"exit_node,omitempty"`   // device ID of the exit node in use
+	KillSwitch bool      `json:"kill_switch,omitempty"`
+	SubnetRoutes []int64  `json:"subnet_routes,omitempty"` // accepted subnet route IDs in use
+	Advertised   []string `json:"advertised,omitempty"`    // subnet CIDRs this device forwards for
+	ExitGateway  bool     `json:"exit_gateway,omitempty"`  // this device is an exit node for its peers
 }
 
 // WGConfig contains WireGuard tunnel configuration for the Network Extension.
 type WGConfig struct {
 	PrivateKey string              `json:"private_key"` // base64
 	OverlayIP  string              `json:"overlay_ip"`
+	OverlayIPs []string            `json:"overlay_ips,omitempty"` // all addresses, IPv4 first, on dual-stack meshes
 	DERPURL    string              `json:"derp_url"`
 	Peers      []map[string]string `json:"peers"`
 }
-
-const SocketPath = "/var/run/prysm/mesh.sock"
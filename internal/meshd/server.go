@@ -1,15 +1,16 @@
 package meshd
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net"
 	"os"
-	"os/user"
 	"path/filepath"
-	"strconv"
 	"sync"
 	"time"
 
@@ -27,27 +28,56 @@ type Server struct {
 	mu         sync.Mutex
 	running    bool
 	logger     *log.Logger
+	logs       *logBuffer
+	lastConfig *mesh.Config // config of the most recent connect, for reload
+	sockGroup  string       // group owning the socket; empty keeps the sudo user's group
+	sockMode   os.FileMode
+	httpConns  *connListener // HTTP clients of the socket, served /healthz and /metrics
 }
 
+// ServerOption configures a Server.
+type ServerOption func(*Server)
+
+// WithSocketGroup makes the socket owned by group (a name or numeric gid) so
+// its members can use the daemon without sudo.
+func WithSocketGroup(group string) ServerOption {
+	return func(s *Server) { s.sockGroup = group }
+}
+
+// WithSocketMode sets the socket's permission bits (default 0660).
+func WithSocketMode(mode os.FileMode) ServerOption {
+	return func(s *Server) { s.sockMode = mode }
+}
+
+// logBufferLines is how many recent log lines the daemon keeps for "logs".
+const logBufferLines = 2000
+
 // NewServer creates a daemon server bound to the given socket path.
-func NewServer(socketPath string) *Server {
-	return &Server{
+func NewServer(socketPath string, opts ...ServerOption) *Server {
+	s := &Server{
 		socketPath: socketPath,
 		logger:     log.New(log.Writer(), "meshd: ", log.LstdFlags),
+		logs:       newLogBuffer(logBufferLines),
+		sockMode:   0660,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // Serve creates the socket directory, removes any stale socket, and accepts
 // connections until ctx is cancelled.
 func (s *Server) Serve(ctx context.Context) error {
+	// Mirror all log output (including the mesh lifecycle's) into the
+	// buffer served by the "logs" command.
+	out := io.MultiWriter(log.Writer(), s.logs)
+	log.SetOutput(out)
+	s.logger.SetOutput(out)
+
 	dir := filepath.Dir(s.socketPath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("create socket dir: %w", err)
-	}
-	// Ensure the directory is world-accessible so non-root processes
-	// (e.g. the tray app) can reach the socket inside.
-	if err := os.Chmod(dir, 0755); err != nil {
-		return fmt.Errorf("chmod socket dir: %w", err)
+	if err := prepareSocketDir(dir); err != nil {
+		return err
 	}
 
 	// Remove stale socket from a previous run.
@@ -61,22 +91,13 @@ func (s *Server) Serve(ctx context.Context) error {
 	}
 	s.listener = ln
 
-	if err := os.Chmod(s.socketPath, 0660); err != nil {
+	if err := secureSocket(s.socketPath, dir, s.sockMode, s.sockGroup); err != nil {
 		ln.Close()
-		return fmt.Errorf("chmod socket: %w", err)
-	}
-	// Set socket group to the invoking user's primary group so non-root
-	// processes (tray app, CLI) can connect. On macOS this is typically "staff".
-	if sudoUID := os.Getenv("SUDO_UID"); sudoUID != "" {
-		if u, err := user.LookupId(sudoUID); err == nil {
-			if gid, err := strconv.Atoi(u.Gid); err == nil {
-				_ = os.Chown(s.socketPath, 0, gid)
-				_ = os.Chown(dir, 0, gid)
-			}
-		}
+		return err
 	}
 
 	s.logger.Printf("listening on %s", s.socketPath)
+	s.startSocketHTTP(ctx, ln.Addr())
 
 	// Close listener when context is done so Accept unblocks.
 	go func() {
@@ -99,11 +120,15 @@ func (s *Server) Serve(ctx context.Context) error {
 }
 
 func (s *Server) handleConn(ctx context.Context, conn net.Conn) {
-	defer conn.Close()
 	conn.SetDeadline(time.Now().Add(30 * time.Second))
+	br := bufio.NewReader(conn)
+	if isHTTPRequest(br) && s.serveSocketHTTP(&bufferedConn{Conn: conn, r: br}) {
+		return
+	}
+	defer conn.Close()
 
 	var req Request
-	if err := json.NewDecoder(conn).Decode(&req); err != nil {
+	if err := json.NewDecoder(br).Decode(&req); err != nil {
 		s.writeResponse(conn, Response{Status: "error", Error: "invalid request: " + err.Error()})
 		return
 	}
@@ -120,6 +145,24 @@ func (s *Server) handleConn(ctx context.Context, conn net.Conn) {
 		resp = s.handleRefreshToken(req)
 	case "wg_config":
 		resp = s.handleWGConfig(ctx, req)
+	case "reload":
+		resp = s.handleReload(ctx)
+	case "health":
+		resp = s.handleHealth()
+	case "logs":
+		resp = s.handleLogs(req)
+	case "exit_use":
+		resp = s.handleExitUse(ctx, req)
+	case "exit_off":
+		resp = s.handleExitOff()
+	case "exit_sync":
+		resp = s.handleExitSync(ctx)
+	case "subnet_accept", "subnet_reject", "subnet_sync":
+		resp = s.handleSubnet(ctx, req)
+	case "peer_events":
+		resp = s.handlePeerEvents(ctx, req)
+	case "relay_ping":
+		resp = s.handleRelayPing(ctx, req)
 	default:
 		resp = Response{Status: "error", Error: "unknown command: " + req.Cmd}
 	}
@@ -199,6 +242,13 @@ func (s *Server) handleConnect(ctx context.Context, req Request) Response {
 		WireGuard:    true,
 	}
 
+	s.lastConfig = &cfg
+	return s.startLifecycle(ctx, cfg)
+}
+
+// startLifecycle starts the mesh with cfg and waits briefly for it to connect
+// or fail. s.mu must be held.
+func (s *Server) startLifecycle(ctx context.Context, cfg mesh.Config) Response {
 	lc := mesh.New(cfg)
 	s.lifecycle = lc
 	s.running = true
@@ -211,8 +261,11 @@ func (s *Server) handleConnect(ctx context.Context, req Request) Response {
 			s.logger.Printf("lifecycle exited: %v", err)
 		}
 		s.mu.Lock()
-		s.running = false
-		s.lifecycle = nil
+		// A reload may already have replaced this lifecycle.
+		if s.lifecycle == lc {
+			s.running = false
+			s.lifecycle = nil
+		}
 		s.mu.Unlock()
 		exited <- err
 	}()
@@ -245,6 +298,27 @@ func (s *Server) handleConnect(ctx context.Context, req Request) Response {
 	return resp
 }
 
+// handleReload re-applies the last connect config: the running lifecycle (if
+// any) is stopped, which tears down the WireGuard interface, and a fresh one
+// is started. Used to recover from wedged tunnel state without restarting
+// the service.
+func (s *Server) handleReload(ctx context.Context) Response {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.lastConfig == nil {
+		return Response{Status: "error", Error: "nothing to reload — run `prysm mesh connect` first"}
+	}
+	if s.running && s.lifecycle != nil {
+		s.logger.Printf("reload: stopping mesh")
+		s.lifecycle.Stop()
+		s.running = false
+		s.lifecycle = nil
+	}
+	s.logger.Printf("reload: starting mesh")
+	return s.startLifecycle(ctx, *s.lastConfig)
+}
+
 func (s *Server) handleDisconnect() Response {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -269,19 +343,31 @@ func (s *Server) handleStatus() Response {
 
 	st := s.lifecycle.GetStatus()
 	resp := Response{
-		Status:    st.State,
-		OverlayIP: st.OverlayIP,
-		Interface: st.Interface,
-		PeerCount: st.PeerCount,
-		TxBytes:   st.TxBytes,
-		RxBytes:   st.RxBytes,
+		Status:       st.State,
+		OverlayIP:    st.OverlayIP,
+		Interface:    st.Interface,
+		PeerCount:    st.PeerCount,
+		TxBytes:      st.TxBytes,
+		RxBytes:      st.RxBytes,
+		ExitNode:     st.ExitNode,
+		KillSwitch:   st.KillSwitch,
+		SubnetRoutes: st.SubnetRoutes,
+		Advertised:   st.Advertised,
+		ExitGateway:  st.ExitGateway,
 	}
 	for _, p := range st.Peers {
-		resp.Peers = append(resp.Peers, PeerInfo{
+		info := PeerInfo{
 			Name:      p.Name,
 			OverlayIP: p.OverlayIP,
 			Endpoint:  p.Endpoint,
-		})
+			PublicKey: p.PublicKey,
+			TxBytes:   p.TxBytes,
+			RxBytes:   p.RxBytes,
+		}
+		if !p.LastHandshake.IsZero() {
+			info.LastHandshake = p.LastHandshake.Unix()
+		}
+		resp.Peers = append(resp.Peers, info)
 	}
 	if !st.StartedAt.IsZero() {
 		resp.Uptime = int64(time.Since(st.StartedAt).Seconds())
@@ -289,6 +375,84 @@ func (s *Server) handleStatus() Response {
 	return resp
 }
 
+// handleExitUse routes all traffic through the exit node in req.ExitNode.
+func (s *Server) handleExitUse(ctx context.Context, req Request) Response {
+	if req.ExitNode == "" {
+		return Response{Status: "error", Error: "exit node is required"}
+	}
+	s.mu.Lock()
+	lc := s.lifecycle
+	running := s.running
+	s.mu.Unlock()
+	if !running || lc == nil {
+		return Response{Status: "error", Error: "not connected"}
+	}
+
+	if err := lc.UseExitNode(ctx, req.ExitNode, req.KillSwitch); err != nil {
+		return Response{Status: "error", Error: err.Error()}
+	}
+	return Response{Status: "ok", ExitNode: req.ExitNode, KillSwitch: req.KillSwitch}
+}
+
+func (s *Server) handleExitOff() Response {
+	s.mu.Lock()
+	lc := s.lifecycle
+	s.mu.Unlock()
+	if lc != nil {
+		lc.ClearExitNode()
+	}
+	return Response{Status: "ok"}
+}
+
+// handleExitSync re-applies this device's exit node role after it was
+// enabled or disabled.
+func (s *Server) handleExitSync(ctx context.Context) Response {
+	s.mu.Lock()
+	lc := s.lifecycle
+	running := s.running
+	s.mu.Unlock()
+	if !running || lc == nil {
+		return Response{Status: "error", Error: "not connected"}
+	}
+	err := lc.SyncExitGateway(ctx)
+	resp := Response{Status: "ok", ExitGateway: lc.GetStatus().ExitGateway}
+	if err != nil {
+		resp.Status, resp.Error = "error", err.Error()
+	}
+	return resp
+}
+
+// handleSubnet accepts or rejects a subnet route, or re-applies subnet routes
+// after one was advertised or withdrawn.
+func (s *Server) handleSubnet(ctx context.Context, req Request) Response {
+	if req.Cmd != "subnet_sync" && req.RouteID == 0 {
+		return Response{Status: "error", Error: "route id is required"}
+	}
+	s.mu.Lock()
+	lc := s.lifecycle
+	running := s.running
+	s.mu.Unlock()
+	if !running || lc == nil {
+		return Response{Status: "error", Error: "not connected"}
+	}
+
+	var err error
+	switch req.Cmd {
+	case "subnet_accept":
+		err = lc.AcceptSubnetRoute(ctx, req.RouteID)
+	case "subnet_reject":
+		err = lc.RejectSubnetRoute(ctx, req.RouteID)
+	default:
+		err = lc.SyncSubnetRoutes(ctx)
+	}
+	st := lc.GetStatus()
+	resp := Response{Status: "ok", SubnetRoutes: st.SubnetRoutes, Advertised: st.Advertised}
+	if err != nil {
+		resp.Status, resp.Error = "error", err.Error()
+	}
+	return resp
+}
+
 func (s *Server) handleRefreshToken(req Request) Response {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -298,6 +462,9 @@ func (s *Server) handleRefreshToken(req Request) Response {
 	}
 
 	s.lifecycle.RefreshToken(req.Token)
+	if s.lastConfig != nil {
+		s.lastConfig.AuthToken = req.Token
+	}
 	return Response{Status: "ok"}
 }
 
@@ -322,12 +489,79 @@ func (s *Server) handleWGConfig(_ context.Context, _ Request) Response {
 		WGConfig: &WGConfig{
 			PrivateKey: wgCfg.PrivateKey,
 			OverlayIP:  wgCfg.OverlayIP,
+			OverlayIPs: wgCfg.OverlayIPs,
 			DERPURL:    wgCfg.DERPURL,
 			Peers:      wgCfg.Peers,
 		},
 	}
 }
 
+// peerEventWait is how long a peer_events request waits for an event; it
+// stays below the connection deadline.
+const peerEventWait = 20 * time.Second
+
+// handlePeerEvents waits for a DERP peer event after req.Since and returns
+// the event count, so clients can follow peer changes without opening a
+// relay session of their own.
+func (s *Server) handlePeerEvents(ctx context.Context, req Request) Response {
+	s.mu.Lock()
+	lc := s.lifecycle
+	running := s.running
+	s.mu.Unlock()
+	if !running || lc == nil {
+		return Response{Status: "error", Error: "not connected"}
+	}
+	ctx, cancel := context.WithTimeout(ctx, peerEventWait)
+	defer cancel()
+	return Response{Status: "ok", EventSeq: lc.WaitPeerEvent(ctx, req.Since)}
+}
+
+// maxRelayPingWait caps relay_ping timeouts below the connection deadline.
+const maxRelayPingWait = 20 * time.Second
+
+// handleRelayPing pings a peer through the DERP relay on the daemon's own
+// connection, so clients don't open a second relay session with this
+// device's ID.
+func (s *Server) handleRelayPing(ctx context.Context, req Request) Response {
+	if req.Target == "" {
+		return Response{Status: "error", Error: "target is required"}
+	}
+	s.mu.Lock()
+	lc := s.lifecycle
+	running := s.running
+	s.mu.Unlock()
+	if !running || lc == nil {
+		return Response{Status: "error", Error: "not connected"}
+	}
+	wait := time.Duration(req.TimeoutMS) * time.Millisecond
+	if wait <= 0 || wait > maxRelayPingWait {
+		wait = maxRelayPingWait
+	}
+	ctx, cancel := context.WithTimeout(ctx, wait)
+	defer cancel()
+	rtt, err := lc.RelayPing(ctx, req.Target)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return Response{Status: "error", Error: fmt.Sprintf("no reply within %s", wait)}
+	}
+	if err != nil {
+		return Response{Status: "error", Error: err.Error()}
+	}
+	return Response{Status: "ok", RTTNanos: int64(rtt)}
+}
+
+// handleLogs returns recent daemon log lines: the last req.Lines lines, or
+// everything from sequence number req.Since when following.
+func (s *Server) handleLogs(req Request) Response {
+	var lines []string
+	var seq int64
+	if req.Since > 0 {
+		lines, seq = s.logs.Since(req.Since)
+	} else {
+		lines, seq = s.logs.Tail(req.Lines)
+	}
+	return Response{Status: "ok", Logs: lines, LogSeq: seq, LogEpoch: s.logs.epoch}
+}
+
 func (s *Server) writeResponse(conn net.Conn, resp Response) {
 	if err := json.NewEncoder(conn).Encode(resp); err != nil {
 		s.logger.Printf("write response: %v", err)
@@ -0,0 +1,59 @@
+package meshd
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestHandleReloadWithoutConnect(t *testing.T) {
+	s := NewServer(t.TempDir() + "/mesh.sock")
+	resp := s.handleReload(context.Background())
+	if resp.Status != "error" || !strings.Contains(resp.Error, "nothing to reload") {
+		t.Errorf("reload before connect = %+v", resp)
+	}
+}
+
+func TestHandleSubnetValidation(t *testing.T) {
+	s := NewServer(t.TempDir() + "/mesh.sock")
+	tests := []struct {
+		req  Request
+		want string
+	}{
+		{Request{Cmd: "subnet_accept"}, "route id is required"},
+		{Request{Cmd: "subnet_reject"}, "route id is required"},
+		{Request{Cmd: "subnet_accept", RouteID: 3}, "not connected"},
+		{Request{Cmd: "subnet_sync"}, "not connected"},
+	}
+	for _, tt := range tests {
+		resp := s.handleSubnet(context.Background(), tt.req)
+		if resp.Status != "error" || !strings.Contains(resp.Error, tt.want) {
+			t.Errorf("%s(%d) = %+v, want error %q", tt.req.Cmd, tt.req.RouteID, resp, tt.want)
+		}
+	}
+}
+
+func TestHandlePeerEventsWithoutConnect(t *testing.T) {
+	s := NewServer(t.TempDir() + "/mesh.sock")
+	resp := s.handlePeerEvents(context.Background(), Request{Cmd: "peer_events"})
+	if resp.Status != "error" || resp.Error != "not connected" {
+		t.Errorf("peer_events before connect = %+v", resp)
+	}
+}
+
+func TestHandleRelayPingValidation(t *testing.T) {
+	s := NewServer(t.TempDir() + "/mesh.sock")
+	tests := []struct {
+		req  Request
+		want string
+	}{
+		{Request{Cmd: "relay_ping"}, "target is required"},
+		{Request{Cmd: "relay_ping", Target: "peer"}, "not connected"},
+	}
+	for _, tt := range tests {
+		resp := s.handleRelayPing(context.Background(), tt.req)
+		if resp.Status != "error" || resp.Error != tt.want {
+			t.Errorf("relay_ping to %q = %+v, want error %q", tt.req.Target, resp, tt.want)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+//go:build !windows
+
+package meshd
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"strconv"
+)
+
+// SocketPath is the daemon's control socket.
+const SocketPath = "/var/run/prysm/mesh.sock"
+
+func prepareSocketDir(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("create socket dir: %w", err)
+	}
+	// Ensure the directory is world-accessible so non-root processes
+	// (e.g. the tray app) can reach the socket inside.
+	if err := os.Chmod(dir, 0755); err != nil {
+		return fmt.Errorf("chmod socket dir: %w", err)
+	}
+	return nil
+}
+
+func secureSocket(path, dir string, mode os.FileMode, group string) error {
+	if err := os.Chmod(path, mode); err != nil {
+		return fmt.Errorf("chmod socket: %w", err)
+	}
+	if group != "" {
+		gid, err := LookupGroupID(group)
+		if err != nil {
+			return err
+		}
+		if err := os.Chown(path, 0, gid); err != nil {
+			return fmt.Errorf("chown socket: %w", err)
+		}
+		if err := os.Chown(dir, 0, gid); err != nil {
+			return fmt.Errorf("chown socket dir: %w", err)
+		}
+		return nil
+	}
+	// Set socket group to the invoking user's primary group so non-root
+	// processes (tray app, CLI) can connect. On macOS this is typically "staff".
+	if sudoUID := os.Getenv("SUDO_UID"); sudoUID != "" {
+		if u, err := user.LookupId(sudoUID); err == nil {
+			if gid, err := strconv.Atoi(u.Gid); err == nil {
+				_ = os.Chown(path, 0, gid)
+				_ = os.Chown(dir, 0, gid)
+			}
+		}
+	}
+	return nil
+}
+
+// LookupGroupID resolves a group name or numeric gid.
+func LookupGroupID(group string) (int, error) {
+	if gid, err := strconv.Atoi(group); err == nil {
+		return gid, nil
+	}
+	g, err := user.LookupGroup(group)
+	if err != nil {
+		return 0, fmt.Errorf("socket group %q: %w", group, err)
+	}
+	return strconv.Atoi(g.Gid)
+}
@@ -0,0 +1,85 @@
+//go:build windows
+
+package meshd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// SocketPath is the daemon's control socket. Windows 10 (1803) and later
+// support AF_UNIX sockets; access is governed by the DACL prepareSocketDir
+// sets on %ProgramData%\prysm.
+var SocketPath = filepath.Join(programData(), "prysm", "mesh.sock")
+
+func programData() string {
+	if dir := os.Getenv("ProgramData"); dir != "" {
+		return dir
+	}
+	return `C:\ProgramData`
+}
+
+// socketDirSDDL gives SYSTEM, Administrators and the user running the daemon
+// full control of the socket directory and everything created in it, and
+// nobody else any access. The DACL is protected: by default any local user
+// may create files under %ProgramData%, and that must not be inherited.
+func socketDirSDDL(userSID string) string {
+	return "O:BAD:P(A;OICI;FA;;;SY)(A;OICI;FA;;;BA)(A;OICI;FA;;;" + userSID + ")"
+}
+
+// prepareSocketDir creates dir with socketDirSDDL, or resets it to that
+// security descriptor (owner included) if it already exists, possibly
+// created by another user.
+func prepareSocketDir(dir string) error {
+	tu, err := windows.GetCurrentProcessToken().GetTokenUser()
+	if err != nil {
+		return fmt.Errorf("socket dir: current user: %w", err)
+	}
+	sd, err := windows.SecurityDescriptorFromString(socketDirSDDL(tu.User.Sid.String()))
+	if err != nil {
+		return fmt.Errorf("socket dir: security descriptor: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dir), 0755); err != nil {
+		return fmt.Errorf("create socket dir: %w", err)
+	}
+	path, err := windows.UTF16PtrFromString(dir)
+	if err != nil {
+		return fmt.Errorf("create socket dir: %w", err)
+	}
+	sa := &windows.SecurityAttributes{SecurityDescriptor: sd}
+	sa.Length = uint32(unsafe.Sizeof(*sa))
+	if err := windows.CreateDirectory(path, sa); err != nil && !errors.Is(err, windows.ERROR_ALREADY_EXISTS) {
+		return fmt.Errorf("create socket dir: %w", err)
+	}
+
+	owner, _, err := sd.Owner()
+	if err != nil {
+		return fmt.Errorf("socket dir: security descriptor: %w", err)
+	}
+	dacl, _, err := sd.DACL()
+	if err != nil {
+		return fmt.Errorf("socket dir: security descriptor: %w", err)
+	}
+	info := windows.SECURITY_INFORMATION(windows.OWNER_SECURITY_INFORMATION | windows.DACL_SECURITY_INFORMATION | windows.PROTECTED_DACL_SECURITY_INFORMATION)
+	if err := windows.SetNamedSecurityInfo(dir, windows.SE_FILE_OBJECT, info, owner, nil, dacl, nil); err != nil {
+		return fmt.Errorf("secure socket dir: %w", err)
+	}
+	return nil
+}
+
+// secureSocket has nothing left to do on Windows: POSIX modes and owners do
+// not apply, and the socket inherits the DACL prepareSocketDir set on dir.
+func secureSocket(_, _ string, _ os.FileMode, _ string) error {
+	return nil
+}
+
+// LookupGroupID is unsupported on Windows; socket access follows ACLs.
+func LookupGroupID(group string) (int, error) {
+	return 0, fmt.Errorf("socket groups are not supported on Windows")
+}
@@ -0,0 +1,107 @@
+// Package meshdns resolves mesh names locally: <device>.mesh.prysm for peers
+// and *.mesh for routes. A small resolver on 127.0.0.1:53 answers them and the
+// system resolver is configured to forward those domains to it.
+package meshdns
+
+import (
+	"net"
+	"net/netip"
+	"sort"
+	"strings"
+
+	"github.com/prysmsh/cli/internal/api"
+)
+
+// Suffix is the domain under which mesh peers resolve to their overlay IPs,
+// e.g. laptop-1.mesh.prysm.
+const Suffix = "mesh.prysm"
+
+// Address is where the local resolver listens.
+const Address = "127.0.0.1:53"
+
+// Record is the name of one mesh peer and its overlay addresses, IPv4 first.
+type Record struct {
+	Name     string   `json:"name"`
+	IPs      []string `json:"ips"`
+	DeviceID string   `json:"device_id"`
+	Status   string   `json:"status"`
+	Self     bool     `json:"self,omitempty"`
+}
+
+// Records returns a record for every mesh node with an overlay address,
+// sorted by name.
+func Records(nodes []api.MeshNode, selfDeviceID string) []Record {
+	records := []Record{}
+	seen := map[string]bool{}
+	for _, n := range nodes {
+		ips := overlayIPs(n.WGAddress)
+		label := Label(n.DeviceID)
+		if len(ips) == 0 || label == "" {
+			continue
+		}
+		name := label + "." + Suffix
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		records = append(records, Record{
+			Name:     name,
+			IPs:      ips,
+			DeviceID: n.DeviceID,
+			Status:   n.Status,
+			Self:     n.DeviceID == selfDeviceID,
+		})
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].Name < records[j].Name })
+	return records
+}
+
+// overlayIPs parses a node's overlay address: one address, or a
+// comma-separated IPv4/IPv6 pair on dual-stack meshes, each optionally with
+// a prefix length. IPv4 comes first.
+func overlayIPs(addr string) []string {
+	var v4, v6 []string
+	for _, part := range strings.Split(addr, ",") {
+		host, _, _ := strings.Cut(strings.TrimSpace(part), "/")
+		a, err := netip.ParseAddr(host)
+		if err != nil {
+			continue
+		}
+		if a = a.Unmap(); a.Is4() {
+			v4 = append(v4, a.String())
+		} else {
+			v6 = append(v6, a.String())
+		}
+	}
+	return append(v4, v6...)
+}
+
+// Label turns a device ID into a DNS label: lowercase letters, digits and
+// single hyphens, at most 63 bytes.
+func Label(deviceID string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(deviceID) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case b.Len() > 0 && !strings.HasSuffix(b.String(), "-"):
+			b.WriteByte('-')
+		}
+	}
+	label := b.String()
+	if len(label) > 63 {
+		label = label[:63]
+	}
+	return strings.Trim(label, "-")
+}
+
+// HostIPs maps <device>.mesh.prysm names to overlay IPs for the resolver.
+func HostIPs(nodes []api.MeshNode, selfDeviceID string) map[string][]net.IP {
+	out := map[string][]net.IP{}
+	for _, r := range Records(nodes, selfDeviceID) {
+		for _, ip := range r.IPs {
+			out[r.Name] = append(out[r.Name], net.ParseIP(ip))
+		}
+	}
+	return out
+}
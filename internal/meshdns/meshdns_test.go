@@ -0,0 +1,92 @@
+package meshdns
+
+import (
+	"encoding/binary"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/prysmsh/cli/internal/api"
+)
+
+func TestRecords(t *testing.T) {
+	nodes := []api.MeshNode{
+		{DeviceID: "Laptop_1", WGAddress: "100.96.0.2/32", Status: "connected"},
+		{DeviceID: "agent-prod", WGAddress: "fd7a::3/128, 100.96.0.3", Status: "connected"},
+		{DeviceID: "no-address"},
+		{DeviceID: "v6-only", WGAddress: "fd7a::2"},
+	}
+	records := Records(nodes, "agent-prod")
+	if len(records) != 3 {
+		t.Fatalf("got %d records, want 3: %+v", len(records), records)
+	}
+	if r := records[0]; r.Name != "agent-prod.mesh.prysm" || !reflect.DeepEqual(r.IPs, []string{"100.96.0.3", "fd7a::3"}) || !r.Self {
+		t.Errorf("records[0] = %+v", r)
+	}
+	if r := records[1]; r.Name != "laptop-1.mesh.prysm" || !reflect.DeepEqual(r.IPs, []string{"100.96.0.2"}) || r.Self {
+		t.Errorf("records[1] = %+v", r)
+	}
+	if r := records[2]; r.Name != "v6-only.mesh.prysm" || !reflect.DeepEqual(r.IPs, []string{"fd7a::2"}) {
+		t.Errorf("records[2] = %+v", r)
+	}
+
+	hosts := HostIPs(nodes, "")
+	if ips := hosts["laptop-1.mesh.prysm"]; len(ips) != 1 || ips[0].String() != "100.96.0.2" {
+		t.Errorf("laptop-1 = %v", ips)
+	}
+}
+
+// dnsQuery builds a query for name with the given type.
+func dnsQuery(name string, qtype uint16) []byte {
+	q := []byte{0x12, 0x34, 0x01, 0x00, 0x00, 0x01, 0, 0, 0, 0, 0, 0}
+	for _, label := range strings.Split(name, ".") {
+		q = append(q, byte(len(label)))
+		q = append(q, label...)
+	}
+	q = append(q, 0)
+	q = binary.BigEndian.AppendUint16(q, qtype)
+	return binary.BigEndian.AppendUint16(q, 1) // class IN
+}
+
+func TestHandleQuery(t *testing.T) {
+	s := &Server{hosts: normalizeHosts(map[string][]net.IP{
+		"dual.mesh.prysm.": {net.ParseIP("100.96.0.3"), net.ParseIP("fd7a::3")},
+		"v4.mesh.prysm":    {net.ParseIP("100.96.0.2")},
+	})}
+
+	tests := []struct {
+		name    string
+		qtype   uint16
+		rcode   byte
+		answers uint16
+		rdata   []byte
+	}{
+		{"dual.mesh.prysm", typeA, 0, 1, net.ParseIP("100.96.0.3").To4()},
+		{"DUAL.mesh.prysm", typeAAAA, 0, 1, net.ParseIP("fd7a::3")},
+		{"v4.mesh.prysm", typeAAAA, 0, 0, nil},
+		{"missing.mesh.prysm", typeA, 3, 0, nil},
+	}
+	for _, tt := range tests {
+		req := dnsQuery(tt.name, tt.qtype)
+		resp := s.handleQuery(req)
+		if len(resp) < 12 {
+			t.Fatalf("%s/%d: short response %x", tt.name, tt.qtype, resp)
+		}
+		if rcode := resp[3] & 0x0f; rcode != tt.rcode {
+			t.Errorf("%s/%d: rcode = %d, want %d", tt.name, tt.qtype, rcode, tt.rcode)
+		}
+		if an := binary.BigEndian.Uint16(resp[6:8]); an != tt.answers {
+			t.Errorf("%s/%d: answers = %d, want %d", tt.name, tt.qtype, an, tt.answers)
+		}
+		if tt.rdata != nil {
+			answer := resp[len(req):]
+			if got := binary.BigEndian.Uint16(answer[2:4]); got != tt.qtype {
+				t.Errorf("%s/%d: answer type = %d", tt.name, tt.qtype, got)
+			}
+			if rdata := answer[12:]; !reflect.DeepEqual(rdata, tt.rdata) {
+				t.Errorf("%s/%d: rdata = %x, want %x", tt.name, tt.qtype, rdata, tt.rdata)
+			}
+		}
+	}
+}
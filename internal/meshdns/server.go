@@ -0,0 +1,186 @@
+package meshdns
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+)
+
+const (
+	typeA    = 1
+	typeAAAA = 28
+)
+
+// Server answers A and AAAA queries for mesh names on Address.
+type Server struct {
+	conn    *net.UDPConn
+	mu      sync.RWMutex
+	hosts   map[string][]net.IP
+	stopCh  chan struct{}
+	cleanup func() error
+}
+
+// Start listens on Address, answers queries from hosts and points the system
+// resolver at it for *.mesh and *.mesh.prysm. Close undoes both.
+func Start(hosts map[string][]net.IP) (*Server, error) {
+	addr, err := net.ResolveUDPAddr("udp", Address)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := net.ListenUDP("udp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("listen local DNS: %w", err)
+	}
+	s := &Server{
+		conn:   conn,
+		hosts:  normalizeHosts(hosts),
+		stopCh: make(chan struct{}),
+	}
+	go s.serve()
+
+	cleanup, err := configureSplitDNS()
+	if err != nil {
+		close(s.stopCh)
+		conn.Close()
+		return nil, err
+	}
+	s.cleanup = cleanup
+	return s, nil
+}
+
+// SetHosts replaces the names the server answers.
+func (s *Server) SetHosts(hosts map[string][]net.IP) {
+	h := normalizeHosts(hosts)
+	s.mu.Lock()
+	s.hosts = h
+	s.mu.Unlock()
+}
+
+// Close stops the server and reverts the system resolver configuration.
+func (s *Server) Close() {
+	close(s.stopCh)
+	_ = s.conn.Close()
+	if s.cleanup != nil {
+		_ = s.cleanup()
+	}
+}
+
+func normalizeHosts(in map[string][]net.IP) map[string][]net.IP {
+	out := make(map[string][]net.IP, len(in))
+	for h, ips := range in {
+		h = strings.ToLower(strings.TrimSuffix(h, "."))
+		for _, ip := range ips {
+			if v4 := ip.To4(); v4 != nil {
+				ip = v4
+			} else if ip.To16() == nil {
+				continue
+			}
+			out[h] = append(out[h], ip)
+		}
+	}
+	return out
+}
+
+func (s *Server) serve() {
+	buf := make([]byte, 1500)
+	for {
+		n, raddr, err := s.conn.ReadFromUDP(buf)
+		if err != nil {
+			select {
+			case <-s.stopCh:
+				return
+			default:
+				continue
+			}
+		}
+		resp := s.handleQuery(buf[:n])
+		if len(resp) > 0 {
+			_, _ = s.conn.WriteToUDP(resp, raddr)
+		}
+	}
+}
+
+// handleQuery answers A and AAAA queries for known names. Known names without
+// an address of the queried type get an empty answer rather than NXDOMAIN,
+// so resolvers asking for both types keep the other one.
+func (s *Server) handleQuery(req []byte) []byte {
+	name, qtype, qend, ok := parseDNSQuery(req)
+	if !ok {
+		return nil
+	}
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	s.mu.RLock()
+	ips, found := s.hosts[name]
+	s.mu.RUnlock()
+	var answers []net.IP
+	for _, ip := range ips {
+		if is4 := len(ip) == net.IPv4len; (qtype == typeA && is4) || (qtype == typeAAAA && !is4) {
+			answers = append(answers, ip)
+		}
+	}
+	return buildDNSResponse(req, qend, qtype, answers, found)
+}
+
+func parseDNSQuery(req []byte) (string, uint16, int, bool) {
+	if len(req) < 12 {
+		return "", 0, 0, false
+	}
+	qd := binary.BigEndian.Uint16(req[4:6])
+	if qd == 0 {
+		return "", 0, 0, false
+	}
+	i := 12
+	labels := []string{}
+	for {
+		if i >= len(req) {
+			return "", 0, 0, false
+		}
+		l := int(req[i])
+		i++
+		if l == 0 {
+			break
+		}
+		if i+l > len(req) {
+			return "", 0, 0, false
+		}
+		labels = append(labels, string(req[i:i+l]))
+		i += l
+	}
+	if i+4 > len(req) {
+		return "", 0, 0, false
+	}
+	qtype := binary.BigEndian.Uint16(req[i : i+2])
+	i += 4 // qtype + qclass
+	return strings.Join(labels, "."), qtype, i, true
+}
+
+func buildDNSResponse(req []byte, qend int, qtype uint16, answers []net.IP, found bool) []byte {
+	if len(req) < 12 || qend > len(req) {
+		return nil
+	}
+	resp := make([]byte, 12)
+	copy(resp[0:2], req[0:2]) // txid
+	// standard response, recursion desired/available
+	flags := uint16(0x8180)
+	if !found {
+		flags = 0x8183 // NXDOMAIN
+	}
+	binary.BigEndian.PutUint16(resp[2:4], flags)
+	copy(resp[4:6], req[4:6]) // qdcount
+	binary.BigEndian.PutUint16(resp[6:8], uint16(len(answers)))
+	// nscount/arcount remain zero
+	resp = append(resp, req[12:qend]...) // question
+
+	for _, ip := range answers {
+		// Answer name pointer to question name at offset 12.
+		resp = append(resp, 0xc0, 0x0c)
+		resp = binary.BigEndian.AppendUint16(resp, qtype)
+		resp = append(resp, 0x00, 0x01)             // class IN
+		resp = append(resp, 0x00, 0x00, 0x00, 0x1e) // TTL 30s
+		resp = binary.BigEndian.AppendUint16(resp, uint16(len(ip)))
+		resp = append(resp, ip...)
+	}
+	return resp
+}
@@ -0,0 +1,86 @@
+package meshdns
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// configureSplitDNS forwards *.mesh and *.mesh.prysm to the local resolver and
+// returns a function that reverts it.
+func configureSplitDNS() (func() error, error) {
+	switch runtime.GOOS {
+	case "linux":
+		if _, err := exec.LookPath("resolvectl"); err != nil {
+			return nil, fmt.Errorf("resolvectl not found (required for .mesh split DNS)")
+		}
+		link, err := detectResolvectlLink()
+		if err != nil {
+			return nil, err
+		}
+		if out, err := exec.Command("resolvectl", "dns", link, "127.0.0.1").CombinedOutput(); err != nil {
+			return nil, fmt.Errorf("configure resolvectl dns %s: %w (%s)", link, err, string(out))
+		}
+		if out, err := exec.Command("resolvectl", "domain", link, "~mesh", "~"+Suffix).CombinedOutput(); err != nil {
+			return nil, fmt.Errorf("configure resolvectl domain %s: %w (%s)", link, err, string(out))
+		}
+		return func() error {
+			_, _ = exec.Command("resolvectl", "revert", link).CombinedOutput()
+			return nil
+		}, nil
+	case "darwin":
+		resolverDir := "/etc/resolver"
+		if err := os.MkdirAll(resolverDir, 0o755); err != nil {
+			return nil, fmt.Errorf("create %s: %w", resolverDir, err)
+		}
+		content := "nameserver 127.0.0.1\nport 53\n"
+		var paths []string
+		for _, domain := range []string{"mesh", Suffix} {
+			path := filepath.Join(resolverDir, domain)
+			if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+				for _, p := range paths {
+					_ = os.Remove(p)
+				}
+				return nil, fmt.Errorf("write %s: %w", path, err)
+			}
+			paths = append(paths, path)
+		}
+		return func() error {
+			for _, p := range paths {
+				_ = os.Remove(p)
+			}
+			return nil
+		}, nil
+	default:
+		return nil, fmt.Errorf("split DNS not supported on %s", runtime.GOOS)
+	}
+}
+
+func detectResolvectlLink() (string, error) {
+	out, err := exec.Command("resolvectl", "status").CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("inspect resolvectl status: %w (%s)", err, string(out))
+	}
+
+	for _, raw := range strings.Split(string(out), "\n") {
+		line := strings.TrimSpace(raw)
+		if !strings.HasPrefix(line, "Link ") {
+			continue
+		}
+		start := strings.Index(line, "(")
+		end := strings.Index(line, ")")
+		if start < 0 || end <= start+1 {
+			continue
+		}
+		link := strings.TrimSpace(line[start+1 : end])
+		if link == "" || link == "lo" {
+			continue
+		}
+		return link, nil
+	}
+
+	return "", fmt.Errorf("no non-loopback resolvectl link found")
+}
@@ -0,0 +1,150 @@
+// Package minisign verifies detached minisign signatures, used for external
+// plugins and CLI release artifacts.
+package minisign
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// Verify checks data against a minisign signature file made by one of
+// publicKeys. Keys are either the bare base64 line or the full contents of a
+// minisign.pub file.
+func Verify(publicKeys []string, data, sig []byte) error {
+	keys := make([]publicKey, 0, len(publicKeys))
+	for _, raw := range publicKeys {
+		k, err := parsePublicKey(raw)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, k)
+	}
+	return verify(keys, data, sig)
+}
+
+// Sign produces a minisign signature file over data with the BLAKE2b-prehashed
+// ("ED") algorithm. It exists for tests and tooling; releases are signed with
+// the minisign CLI.
+func Sign(priv ed25519.PrivateKey, keyID [8]byte, data []byte, trustedComment string) []byte {
+	h := blake2b.Sum512(data)
+	sig := ed25519.Sign(priv, h[:])
+	blob := append([]byte("ED"), keyID[:]...)
+	blob = append(blob, sig...)
+	global := ed25519.Sign(priv, append(append([]byte{}, sig...), trustedComment...))
+	return []byte(fmt.Sprintf("untrusted comment: signature from prysm\n%s\ntrusted comment: %s\n%s\n",
+		base64.StdEncoding.EncodeToString(blob), trustedComment, base64.StdEncoding.EncodeToString(global)))
+}
+
+// EncodePublicKey returns pub in minisign.pub format.
+func EncodePublicKey(pub ed25519.PublicKey, keyID [8]byte) string {
+	b := append([]byte("Ed"), keyID[:]...)
+	b = append(b, pub...)
+	return fmt.Sprintf("untrusted comment: minisign public key %X\n%s\n", reverse(keyID[:]), base64.StdEncoding.EncodeToString(b))
+}
+
+type publicKey struct {
+	id  [8]byte
+	pub ed25519.PublicKey
+}
+
+// parsePublicKey decodes "Ed" || key id (8) || ed25519 public key (32).
+func parsePublicKey(raw string) (publicKey, error) {
+	line := lastNonCommentLine(raw)
+	b, err := base64.StdEncoding.DecodeString(line)
+	if err != nil || len(b) != 2+8+ed25519.PublicKeySize || string(b[:2]) != "Ed" {
+		return publicKey{}, fmt.Errorf("invalid minisign public key %q", truncateKey(line))
+	}
+	var k publicKey
+	copy(k.id[:], b[2:10])
+	k.pub = ed25519.PublicKey(b[10:])
+	return k, nil
+}
+
+// verify verifies a minisign signature file:
+//
+//	untrusted comment: ...
+//	base64("Ed"|"ED" || key id || signature)
+//	trusted comment: ...
+//	base64(global signature over signature || trusted comment)
+//
+// "ED" signatures are computed over the BLAKE2b-512 hash of the data.
+func verify(keys []publicKey, data, sigFile []byte) error {
+	lines := strings.Split(strings.ReplaceAll(string(sigFile), "\r\n", "\n"), "\n")
+	if len(lines) < 4 {
+		return fmt.Errorf("malformed minisign signature")
+	}
+	sigBlob, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[1]))
+	if err != nil || len(sigBlob) != 2+8+ed25519.SignatureSize {
+		return fmt.Errorf("malformed minisign signature")
+	}
+	trusted, ok := strings.CutPrefix(lines[2], "trusted comment: ")
+	if !ok {
+		return fmt.Errorf("malformed minisign signature: missing trusted comment")
+	}
+	globalSig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[3]))
+	if err != nil || len(globalSig) != ed25519.SignatureSize {
+		return fmt.Errorf("malformed minisign signature: bad global signature")
+	}
+
+	alg, keyID, sig := string(sigBlob[:2]), sigBlob[2:10], sigBlob[10:]
+	var key *publicKey
+	for i := range keys {
+		if bytes.Equal(keys[i].id[:], keyID) {
+			key = &keys[i]
+			break
+		}
+	}
+	if key == nil {
+		return fmt.Errorf("signed by untrusted key %X", reverse(keyID))
+	}
+
+	msg := data
+	switch alg {
+	case "ED":
+		h := blake2b.Sum512(data)
+		msg = h[:]
+	case "Ed":
+	default:
+		return fmt.Errorf("unsupported minisign signature algorithm %q", alg)
+	}
+	if !ed25519.Verify(key.pub, msg, sig) {
+		return fmt.Errorf("signature verification failed")
+	}
+	if !ed25519.Verify(key.pub, append(append([]byte{}, sig...), trusted...), globalSig) {
+		return fmt.Errorf("trusted comment verification failed")
+	}
+	return nil
+}
+
+func lastNonCommentLine(s string) string {
+	var last string
+	for _, l := range strings.Split(s, "\n") {
+		l = strings.TrimSpace(l)
+		if l == "" || strings.HasPrefix(l, "untrusted comment:") {
+			continue
+		}
+		last = l
+	}
+	return last
+}
+
+// reverse returns the key id in the byte order minisign prints it.
+func reverse(b []byte) []byte {
+	out := make([]byte, len(b))
+	for i := range b {
+		out[len(b)-1-i] = b[i]
+	}
+	return out
+}
+
+func truncateKey(s string) string {
+	if len(s) > 16 {
+		return s[:16] + "..."
+	}
+	return s
+}
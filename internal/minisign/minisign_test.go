@@ -0,0 +1,51 @@
+package minisign
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"strings"
+	"testing"
+)
+
+func newKey(t *testing.T, id byte) (ed25519.PrivateKey, [8]byte, string) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var keyID [8]byte
+	keyID[0] = id
+	return priv, keyID, EncodePublicKey(pub, keyID)
+}
+
+func TestVerify(t *testing.T) {
+	priv, id, pub := newKey(t, 1)
+	otherPriv, otherID, _ := newKey(t, 2)
+	data := []byte("SHA256SUMS contents")
+
+	sig := Sign(priv, id, data, "timestamp:1 file:SHA256SUMS")
+	if err := Verify([]string{pub}, data, sig); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	// Bare key line without the comment is accepted too.
+	if err := Verify([]string{strings.Split(pub, "\n")[1]}, data, sig); err != nil {
+		t.Errorf("Verify with bare key: %v", err)
+	}
+
+	if err := Verify([]string{pub}, []byte("tampered"), sig); err == nil {
+		t.Error("tampered data verified")
+	}
+	if err := Verify([]string{pub}, data, Sign(otherPriv, otherID, data, "x")); err == nil || !strings.Contains(err.Error(), "untrusted key") {
+		t.Errorf("untrusted key err = %v", err)
+	}
+	forged := strings.Replace(string(sig), "timestamp:1", "timestamp:2", 1)
+	if err := Verify([]string{pub}, data, []byte(forged)); err == nil {
+		t.Error("modified trusted comment verified")
+	}
+	if err := Verify([]string{pub}, data, []byte("garbage")); err == nil || !strings.Contains(err.Error(), "malformed") {
+		t.Errorf("malformed err = %v", err)
+	}
+	if err := Verify([]string{"not-a-key"}, data, sig); err == nil || !strings.Contains(err.Error(), "invalid minisign public key") {
+		t.Errorf("bad key err = %v", err)
+	}
+}
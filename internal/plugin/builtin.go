@@ -16,12 +16,14 @@ import (
 	"github.com/prysmsh/cli/internal/style"
 	"github.com/prysmsh/cli/internal/config"
 	"github.com/prysmsh/cli/internal/session"
+	"github.com/prysmsh/cli/internal/ui"
 )
 
 // AppContext holds references to the CLI app state needed by host services.
 type AppContext struct {
 	Config   *config.Config
 	Sessions *session.Store
+	Secrets  *session.SecretStore
 	API      *api.Client
 	Format   string
 	Debug    bool
@@ -31,6 +33,15 @@ type AppContext struct {
 // Used by builtin plugins to call host services in-process without gRPC overhead.
 type BuiltinHostServices struct {
 	app *AppContext
+
+	// Set by ForPlugin. An unscoped host (plugin == "") is the CLI itself and
+	// skips scope checks.
+	plugin   string
+	declared map[Scope]bool
+	builtin  bool
+	grants   *GrantStore
+	grantKey string
+	denied   map[Scope]bool
 }
 
 // NewBuiltinHostServices creates a HostServices backed by the given app context.
@@ -38,14 +49,83 @@ func NewBuiltinHostServices(app *AppContext) *BuiltinHostServices {
 	return &BuiltinHostServices{app: app}
 }
 
-// GetAuthContext returns the current authenticated user's context.
+// ForPlugin returns host services restricted to the scopes declared in m.
+// Declared scopes are confirmed with the user on first use and the answer is
+// persisted; builtin plugins ship with the CLI and get their declared scopes
+// without asking.
+func (h *BuiltinHostServices) ForPlugin(m Manifest, builtin bool) *BuiltinHostServices {
+	declared := make(map[Scope]bool, len(m.Scopes))
+	for _, s := range m.Scopes {
+		declared[s] = true
+	}
+	if declared[ScopeAPIWrite] {
+		declared[ScopeAPIRead] = true
+	}
+	homeDir := ""
+	if h.app.Config != nil {
+		homeDir = h.app.Config.HomeDir
+	}
+	return &BuiltinHostServices{
+		app:      h.app,
+		plugin:   m.Name,
+		declared: declared,
+		builtin:  builtin,
+		grants:   NewGrantStore(homeDir),
+		grantKey: m.Name,
+		denied:   make(map[Scope]bool),
+	}
+}
+
+// ForBinary ties the grants h persists to the plugin binary with the given
+// SHA-256, so a reinstalled or replaced plugin has to ask again.
+func (h *BuiltinHostServices) ForBinary(sha256 string) *BuiltinHostServices {
+	h.grantKey = GrantKey(h.plugin, sha256)
+	return h
+}
+
+// Authorize checks that the plugin declared scope and the user has granted it,
+// asking (once) if it has not been granted yet.
+func (h *BuiltinHostServices) Authorize(ctx context.Context, scope Scope) error {
+	if h.plugin == "" {
+		return nil
+	}
+	if !h.declared[scope] {
+		return fmt.Errorf("plugin %q did not declare the %q scope in its manifest", h.plugin, scope)
+	}
+	if h.builtin || h.grants.Granted(h.grantKey, scope) {
+		return nil
+	}
+	if h.denied[scope] {
+		return fmt.Errorf("plugin %q was denied the %q scope", h.plugin, scope)
+	}
+
+	ok, err := h.confirm(fmt.Sprintf("Allow plugin %q to %s (%s)?", h.plugin, scopeDescriptions[scope], scope))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		h.denied[scope] = true
+		return fmt.Errorf("plugin %q was denied the %q scope", h.plugin, scope)
+	}
+	return h.grants.Grant(h.grantKey, scope)
+}
+
+// GetAuthContext returns the current authenticated user's context. Scoped
+// plugins only receive the session token when they hold api:write.
 func (h *BuiltinHostServices) GetAuthContext(ctx context.Context) (*AuthContext, error) {
+	if err := h.Authorize(ctx, ScopeAPIRead); err != nil {
+		return nil, err
+	}
 	sess, err := h.app.Sessions.Load()
 	if err != nil || sess == nil {
 		return nil, fmt.Errorf("not logged in — run `prysm login` first")
 	}
+	token := sess.Token
+	if h.plugin != "" && (!h.declared[ScopeAPIWrite] || h.Authorize(ctx, ScopeAPIWrite) != nil) {
+		token = ""
+	}
 	return &AuthContext{
-		Token:      sess.Token,
+		Token:      token,
 		OrgID:      uint64(sess.Organization.ID),
 		OrgName:    sess.Organization.Name,
 		UserID:     uint64(sess.User.ID),
@@ -56,6 +136,14 @@ func (h *BuiltinHostServices) GetAuthContext(ctx context.Context) (*AuthContext,
 
 // APIRequest proxies an HTTP request through the host's authenticated API client.
 func (h *BuiltinHostServices) APIRequest(ctx context.Context, method, endpoint string, body []byte) (int, []byte, error) {
+	scope := ScopeAPIWrite
+	if m := strings.ToUpper(method); m == http.MethodGet || m == http.MethodHead {
+		scope = ScopeAPIRead
+	}
+	if err := h.Authorize(ctx, scope); err != nil {
+		return 0, nil, err
+	}
+
 	// Pass body as json.RawMessage so api.Client.Do encodes it as-is
 	// (not as a Go struct). A nil interface{} signals no body.
 	var payload interface{}
@@ -102,7 +190,12 @@ func (h *BuiltinHostServices) Log(ctx context.Context, level LogLevel, message s
 			fmt.Fprintln(os.Stderr, style.MutedStyle.Render("[debug] "+message))
 		}
 	case LogLevelPlain:
-		fmt.Fprintln(os.Stdout, message)
+		// Keep stdout parseable when the user asked for JSON.
+		if h.wantsJSON() {
+			fmt.Fprintln(os.Stderr, message)
+		} else {
+			fmt.Fprintln(os.Stdout, message)
+		}
 	default: // Info
 		fmt.Fprintln(os.Stderr, style.Info.Render(message))
 	}
@@ -111,6 +204,9 @@ func (h *BuiltinHostServices) Log(ctx context.Context, level LogLevel, message s
 
 // PromptInput reads a line from the terminal. If isSecret is true, input is masked.
 func (h *BuiltinHostServices) PromptInput(ctx context.Context, label string, isSecret bool) (string, error) {
+	if err := h.Authorize(ctx, ScopePrompt); err != nil {
+		return "", err
+	}
 	fmt.Fprintf(os.Stderr, "%s: ", label)
 	if isSecret {
 		b, err := term.ReadPassword(int(os.Stdin.Fd()))
@@ -126,6 +222,13 @@ func (h *BuiltinHostServices) PromptInput(ctx context.Context, label string, isS
 
 // PromptConfirm asks a yes/no question and returns the answer.
 func (h *BuiltinHostServices) PromptConfirm(ctx context.Context, label string) (bool, error) {
+	if err := h.Authorize(ctx, ScopePrompt); err != nil {
+		return false, err
+	}
+	return h.confirm(label)
+}
+
+func (h *BuiltinHostServices) confirm(label string) (bool, error) {
 	fmt.Fprintf(os.Stderr, "%s [y/N]: ", label)
 	scanner := bufio.NewScanner(os.Stdin)
 	if scanner.Scan() {
@@ -135,6 +238,73 @@ func (h *BuiltinHostServices) PromptConfirm(ctx context.Context, label string) (
 	return false, nil
 }
 
+// GetSecret returns a secret from the plugin's namespace in the encrypted store.
+func (h *BuiltinHostServices) GetSecret(ctx context.Context, key string) (string, bool, error) {
+	if h.app.Secrets == nil {
+		return "", false, fmt.Errorf("secret storage is not available")
+	}
+	return h.app.Secrets.Get(h.secretNamespace(), key)
+}
+
+// SetSecret stores (or, with an empty value, deletes) a secret in the plugin's namespace.
+func (h *BuiltinHostServices) SetSecret(ctx context.Context, key, value string) error {
+	if h.app.Secrets == nil {
+		return fmt.Errorf("secret storage is not available")
+	}
+	return h.app.Secrets.Set(h.secretNamespace(), key, value)
+}
+
+func (h *BuiltinHostServices) secretNamespace() string {
+	if h.plugin == "" {
+		return "cli"
+	}
+	return SecretNamespace(h.plugin)
+}
+
+// SecretNamespace is the secret store namespace that isolates a plugin's secrets.
+func SecretNamespace(pluginName string) string {
+	return "plugin:" + pluginName
+}
+
+// RenderTable prints rows as a table, or as a JSON array of objects keyed by
+// the lower-cased headers when the output format is json.
+func (h *BuiltinHostServices) RenderTable(ctx context.Context, headers []string, rows [][]string) error {
+	if !h.wantsJSON() {
+		ui.PrintTable(headers, rows)
+		return nil
+	}
+	out := make([]map[string]string, 0, len(rows))
+	for _, row := range rows {
+		obj := make(map[string]string, len(headers))
+		for i, header := range headers {
+			if i < len(row) {
+				obj[strings.ToLower(strings.ReplaceAll(header, " ", "_"))] = row[i]
+			}
+		}
+		out = append(out, obj)
+	}
+	return writeIndentedJSON(out)
+}
+
+// RenderJSON pretty-prints a JSON document to stdout.
+func (h *BuiltinHostServices) RenderJSON(ctx context.Context, data []byte) error {
+	var v json.RawMessage
+	if err := json.Unmarshal(data, &v); err != nil {
+		return fmt.Errorf("render json: invalid document: %w", err)
+	}
+	return writeIndentedJSON(v)
+}
+
+func (h *BuiltinHostServices) wantsJSON() bool {
+	return strings.EqualFold(strings.TrimSpace(h.app.Format), "json")
+}
+
+func writeIndentedJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
 // doAPIRaw is a helper to make raw HTTP requests through the API client.
 func (h *BuiltinHostServices) doAPIRaw(ctx context.Context, method, endpoint string, body io.Reader) (*http.Response, error) {
 	var result json.RawMessage
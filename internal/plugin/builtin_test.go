@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/prysmsh/cli/internal/api"
@@ -365,3 +366,167 @@ func TestBuiltinHostServices_APIRequest_APIError(t *testing.T) {
 		t.Error("body should be non-empty")
 	}
 }
+
+func withStdin(t *testing.T, input string) {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldStdin := os.Stdin
+	os.Stdin = r
+	t.Cleanup(func() { os.Stdin = oldStdin })
+	go func() {
+		w.WriteString(input)
+		w.Close()
+	}()
+}
+
+func TestBuiltinHostServices_ForPlugin_Scopes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	home := t.TempDir()
+	app := &AppContext{
+		Config:   &config.Config{APIBaseURL: srv.URL, HomeDir: home},
+		Sessions: session.NewStore(home + "/session.json"),
+		API:      api.NewClient(srv.URL),
+	}
+	h := NewBuiltinHostServices(app).ForPlugin(Manifest{Name: "reader", Scopes: []Scope{ScopeAPIRead}}, false)
+
+	if _, _, err := h.APIRequest(context.Background(), "POST", "/test", nil); err == nil {
+		t.Error("POST without api:write should be rejected")
+	}
+	if _, err := h.PromptInput(context.Background(), "Label", false); err == nil {
+		t.Error("PromptInput without prompt scope should be rejected")
+	}
+
+	withStdin(t, "n\n")
+	if _, _, err := h.APIRequest(context.Background(), "GET", "/test", nil); err == nil {
+		t.Error("GET should fail when the user denies api:read")
+	}
+	if _, _, err := h.APIRequest(context.Background(), "GET", "/test", nil); err == nil {
+		t.Error("denial should stick for the rest of the session")
+	}
+
+	h = NewBuiltinHostServices(app).ForPlugin(Manifest{Name: "reader", Scopes: []Scope{ScopeAPIRead}}, false)
+	withStdin(t, "y\n")
+	if _, _, err := h.APIRequest(context.Background(), "GET", "/test", nil); err != nil {
+		t.Fatalf("GET after grant: %v", err)
+	}
+	if !NewGrantStore(home).Granted("reader", ScopeAPIRead) {
+		t.Error("grant was not persisted")
+	}
+
+	// A fresh host picks up the persisted grant without prompting (stdin is at EOF).
+	withStdin(t, "")
+	h = NewBuiltinHostServices(app).ForPlugin(Manifest{Name: "reader", Scopes: []Scope{ScopeAPIRead}}, false)
+	if _, _, err := h.APIRequest(context.Background(), "GET", "/test", nil); err != nil {
+		t.Errorf("GET with persisted grant: %v", err)
+	}
+}
+
+func TestBuiltinHostServices_ForPlugin_TokenRequiresWrite(t *testing.T) {
+	home := t.TempDir()
+	store := session.NewStore(home + "/session.json")
+	if err := store.Save(&session.Session{Token: "token123", Organization: session.SessionOrg{ID: 1}}); err != nil {
+		t.Fatal(err)
+	}
+	app := &AppContext{Config: &config.Config{HomeDir: home}, Sessions: store}
+
+	reader := NewBuiltinHostServices(app).ForPlugin(Manifest{Name: "reader", Scopes: []Scope{ScopeAPIRead}}, true)
+	auth, err := reader.GetAuthContext(context.Background())
+	if err != nil {
+		t.Fatalf("GetAuthContext: %v", err)
+	}
+	if auth.Token != "" {
+		t.Error("api:read plugin should not receive the session token")
+	}
+
+	writer := NewBuiltinHostServices(app).ForPlugin(Manifest{Name: "writer", Scopes: []Scope{ScopeAPIWrite}}, true)
+	auth, err = writer.GetAuthContext(context.Background())
+	if err != nil {
+		t.Fatalf("GetAuthContext: %v", err)
+	}
+	if auth.Token != "token123" {
+		t.Errorf("Token = %q, want session token for api:write plugin", auth.Token)
+	}
+
+	if err := Authorize(context.Background(), writer, ScopeExec); err == nil {
+		t.Error("undeclared exec scope should be rejected")
+	}
+}
+
+// captureStdout returns what fn writes to os.Stdout.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := os.Stdout
+	os.Stdout = w
+	fn()
+	os.Stdout = old
+	w.Close()
+	out, _ := io.ReadAll(r)
+	return string(out)
+}
+
+func TestBuiltinHostServices_RenderTable(t *testing.T) {
+	ctx := context.Background()
+	headers := []string{"NAME", "CLUSTER ID"}
+	rows := [][]string{{"agent-1", "42"}}
+
+	h := NewBuiltinHostServices(&AppContext{Format: "json"})
+	out := captureStdout(t, func() {
+		if err := h.RenderTable(ctx, headers, rows); err != nil {
+			t.Fatalf("RenderTable: %v", err)
+		}
+	})
+	var got []map[string]string
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("json output %q: %v", out, err)
+	}
+	if len(got) != 1 || got[0]["name"] != "agent-1" || got[0]["cluster_id"] != "42" {
+		t.Errorf("RenderTable json = %v", got)
+	}
+
+	h = NewBuiltinHostServices(&AppContext{Format: "table"})
+	out = captureStdout(t, func() {
+		if err := h.RenderTable(ctx, headers, rows); err != nil {
+			t.Fatalf("RenderTable: %v", err)
+		}
+	})
+	if !strings.Contains(out, "CLUSTER ID") || !strings.Contains(out, "agent-1") {
+		t.Errorf("RenderTable table = %q", out)
+	}
+}
+
+func TestBuiltinHostServices_RenderJSON(t *testing.T) {
+	h := NewBuiltinHostServices(&AppContext{})
+	out := captureStdout(t, func() {
+		if err := h.RenderJSON(context.Background(), []byte(`{"ok":true}`)); err != nil {
+			t.Fatalf("RenderJSON: %v", err)
+		}
+	})
+	if out != "{\n  \"ok\": true\n}\n" {
+		t.Errorf("RenderJSON = %q", out)
+	}
+	if err := h.RenderJSON(context.Background(), []byte(`{not json`)); err == nil {
+		t.Error("RenderJSON accepted invalid JSON")
+	}
+}
+
+func TestBuiltinHostServices_LogPlainJSONMode(t *testing.T) {
+	h := NewBuiltinHostServices(&AppContext{Format: "json"})
+	out := captureStdout(t, func() {
+		_ = h.Log(context.Background(), LogLevelPlain, "progress line")
+	})
+	if out != "" {
+		t.Errorf("plain log wrote %q to stdout in json mode", out)
+	}
+}
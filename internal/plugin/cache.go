@@ -0,0 +1,141 @@
+package plugin
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// manifestCacheFile caches external plugin manifests so help and completion
+// don't have to start every plugin subprocess.
+const manifestCacheFile = "cache.json"
+
+type manifestCacheEntry struct {
+	SHA256   string    `json:"sha256"` // hash of the binary the manifest came from
+	Size     int64     `json:"size"`
+	ModTime  time.Time `json:"mod_time"`
+	Manifest Manifest  `json:"manifest"`
+}
+
+// ManifestCache maps plugin names to the manifest reported by a specific
+// binary. Entries are invalidated when the binary's hash changes; size and
+// mtime are used to skip rehashing unchanged files.
+type ManifestCache struct {
+	path    string
+	mu      sync.Mutex
+	entries map[string]manifestCacheEntry
+	dirty   bool
+}
+
+// LoadManifestCache reads $PRYSM_HOME/plugins/cache.json. A missing or corrupt
+// cache yields an empty one.
+func LoadManifestCache(homeDir string) *ManifestCache {
+	c := &ManifestCache{
+		path:    filepath.Join(homeDir, "plugins", manifestCacheFile),
+		entries: make(map[string]manifestCacheEntry),
+	}
+	if data, err := os.ReadFile(c.path); err == nil {
+		_ = json.Unmarshal(data, &c.entries)
+	}
+	return c
+}
+
+// Get returns the cached manifest for name if it was recorded for the binary
+// currently at path.
+func (c *ManifestCache) Get(name, path string) (Manifest, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[name]
+	if !ok {
+		return Manifest{}, false
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return Manifest{}, false
+	}
+	if info.Size() == entry.Size && info.ModTime().Equal(entry.ModTime) {
+		return entry.Manifest, true
+	}
+	sum, err := HashFile(path)
+	if err != nil || sum != entry.SHA256 {
+		return Manifest{}, false
+	}
+	// Same content, new mtime (e.g. copied or touched): refresh the stat key.
+	entry.Size, entry.ModTime = info.Size(), info.ModTime()
+	c.entries[name] = entry
+	c.dirty = true
+	return entry.Manifest, true
+}
+
+// Put records m as the manifest of the binary at path.
+func (c *ManifestCache) Put(name, path string, m Manifest) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	sum, err := HashFile(path)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[name] = manifestCacheEntry{SHA256: sum, Size: info.Size(), ModTime: info.ModTime(), Manifest: m}
+	c.dirty = true
+	return nil
+}
+
+// Delete drops the entry for name.
+func (c *ManifestCache) Delete(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[name]; ok {
+		delete(c.entries, name)
+		c.dirty = true
+	}
+}
+
+// Save writes the cache back to disk if it changed.
+func (c *ManifestCache) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.dirty {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o700); err != nil {
+		return fmt.Errorf("create plugins dir: %w", err)
+	}
+	data, err := json.MarshalIndent(c.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("write plugin manifest cache: %w", err)
+	}
+	if err := os.Rename(tmp, c.path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("write plugin manifest cache: %w", err)
+	}
+	c.dirty = false
+	return nil
+}
+
+// HashFile returns the hex SHA-256 of the file at path.
+func HashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
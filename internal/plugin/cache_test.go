@@ -0,0 +1,112 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func TestManifestCache(t *testing.T) {
+	home := t.TempDir()
+	bin := filepath.Join(home, "plugins", "prysm-plugin-foo")
+	if err := writeExecutable(bin, []byte("v1")); err != nil {
+		t.Fatal(err)
+	}
+	manifest := Manifest{
+		Name:        "foo",
+		Version:     "1.0.0",
+		Description: "Foo tools",
+		Commands:    []CommandSpec{{Name: "sync", Short: "Sync things"}},
+		Hooks:       []HookSpec{{Phase: HookBefore, Command: "tunnel expose"}},
+	}
+
+	c := LoadManifestCache(home)
+	if _, ok := c.Get("foo", bin); ok {
+		t.Fatal("empty cache returned a manifest")
+	}
+	if err := c.Put("foo", bin, manifest); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	c = LoadManifestCache(home)
+	got, ok := c.Get("foo", bin)
+	if !ok || got.Version != "1.0.0" || len(got.Commands) != 1 || got.Hooks[0].Command != "tunnel expose" {
+		t.Fatalf("Get = %+v, %v", got, ok)
+	}
+
+	// Same content with a new mtime is still a hit.
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(bin, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.Get("foo", bin); !ok {
+		t.Error("touching the binary should not invalidate the cache")
+	}
+
+	// A replaced binary invalidates the entry.
+	if err := writeExecutable(bin, []byte("v2")); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.Get("foo", bin); ok {
+		t.Error("changed binary should invalidate the cached manifest")
+	}
+
+	c.Delete("foo")
+	if err := c.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if len(LoadManifestCache(home).entries) != 0 {
+		t.Error("Delete did not persist")
+	}
+}
+
+func TestManager_CachedManifest(t *testing.T) {
+	home := t.TempDir()
+	pluginsDir := filepath.Join(home, "plugins")
+	bin := filepath.Join(pluginsDir, "prysm-plugin-foo")
+	if err := writeExecutable(bin, []byte("#!/bin/sh\nexit 1\n")); err != nil {
+		t.Fatal(err)
+	}
+	c := LoadManifestCache(home)
+	if err := c.Put("foo", bin, Manifest{Name: "foo", Version: "2.0.0", Description: "Foo tools",
+		Commands: []CommandSpec{{Name: "sync", Short: "Sync", Subcommands: []CommandSpec{{Name: "now"}}}, {Name: "debug", Hidden: true}}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Save(); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", "")
+
+	m := NewManager(nil, home, false)
+	m.DiscoverExternalPlugins()
+
+	// Served from the cache: the stub binary would fail to load.
+	v, err := m.ExternalVersion("foo")
+	if err != nil || v != "2.0.0" {
+		t.Errorf("ExternalVersion = %q, %v", v, err)
+	}
+
+	root := &cobra.Command{Use: "prysm"}
+	m.RegisterCommands(root)
+	cmd, _, err := root.Find([]string{"foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cmd.Short != "Foo tools" {
+		t.Errorf("Short = %q, want cached description", cmd.Short)
+	}
+	comps, _ := cmd.ValidArgsFunction(cmd, nil, "")
+	if len(comps) != 1 || comps[0] != "sync\tSync" {
+		t.Errorf("completions = %v", comps)
+	}
+	comps, _ = cmd.ValidArgsFunction(cmd, []string{"sync"}, "")
+	if len(comps) != 1 || comps[0] != "now\t" {
+		t.Errorf("nested completions = %v", comps)
+	}
+}
@@ -80,3 +80,47 @@ func (s *GRPCHostServer) PromptConfirm(ctx context.Context, req *pluginv1.Prompt
 	}
 	return &pluginv1.PromptConfirmResponse{Confirmed: confirmed}, nil
 }
+
+func (s *GRPCHostServer) GetSecret(ctx context.Context, req *pluginv1.GetSecretRequest) (*pluginv1.GetSecretResponse, error) {
+	value, found, err := s.host.GetSecret(ctx, req.Key)
+	if err != nil {
+		return nil, err
+	}
+	return &pluginv1.GetSecretResponse{Value: value, Found: found}, nil
+}
+
+func (s *GRPCHostServer) SetSecret(ctx context.Context, req *pluginv1.SetSecretRequest) (*pluginv1.SetSecretResponse, error) {
+	if err := s.host.SetSecret(ctx, req.Key, req.Value); err != nil {
+		return nil, err
+	}
+	return &pluginv1.SetSecretResponse{}, nil
+}
+
+func (s *GRPCHostServer) RenderTable(ctx context.Context, req *pluginv1.RenderTableRequest) (*pluginv1.RenderTableResponse, error) {
+	rows := make([][]string, len(req.Rows))
+	for i, r := range req.Rows {
+		rows[i] = r.Cells
+	}
+	if err := s.host.RenderTable(ctx, req.Headers, rows); err != nil {
+		return nil, err
+	}
+	return &pluginv1.RenderTableResponse{}, nil
+}
+
+func (s *GRPCHostServer) RenderJSON(ctx context.Context, req *pluginv1.RenderJSONRequest) (*pluginv1.RenderJSONResponse, error) {
+	if err := s.host.RenderJSON(ctx, req.Json); err != nil {
+		return nil, err
+	}
+	return &pluginv1.RenderJSONResponse{}, nil
+}
+
+func (s *GRPCHostServer) Authorize(ctx context.Context, req *pluginv1.AuthorizeRequest) (*pluginv1.AuthorizeResponse, error) {
+	scope, err := ParseScope(req.Scope)
+	if err != nil {
+		return nil, err
+	}
+	if err := Authorize(ctx, s.host, scope); err != nil {
+		return nil, err
+	}
+	return &pluginv1.AuthorizeResponse{}, nil
+}
@@ -0,0 +1,104 @@
+package plugin
+
+import (
+	"context"
+
+	pluginv1 "github.com/prysmsh/cli/proto/plugin/v1"
+)
+
+// GRPCHostClient implements HostServices by calling the CLI host over gRPC.
+// Used on the plugin side; the host serves it over the go-plugin broker.
+type GRPCHostClient struct {
+	client pluginv1.HostServiceClient
+}
+
+func (c *GRPCHostClient) GetAuthContext(ctx context.Context) (*AuthContext, error) {
+	resp, err := c.client.GetAuthContext(ctx, &pluginv1.GetAuthContextRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return &AuthContext{
+		Token:      resp.Token,
+		OrgID:      resp.OrgId,
+		OrgName:    resp.OrgName,
+		UserID:     resp.UserId,
+		UserEmail:  resp.UserEmail,
+		APIBaseURL: resp.ApiBaseUrl,
+	}, nil
+}
+
+func (c *GRPCHostClient) APIRequest(ctx context.Context, method, endpoint string, body []byte) (int, []byte, error) {
+	resp, err := c.client.APIRequest(ctx, &pluginv1.APIRequestRequest{Method: method, Endpoint: endpoint, Body: body})
+	if err != nil {
+		return 0, nil, err
+	}
+	return int(resp.StatusCode), resp.Body, nil
+}
+
+func (c *GRPCHostClient) GetConfig(ctx context.Context) (*HostConfig, error) {
+	resp, err := c.client.GetConfig(ctx, &pluginv1.GetConfigRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return &HostConfig{
+		APIBaseURL:   resp.ApiBaseUrl,
+		DERPURL:      resp.DerpUrl,
+		HomeDir:      resp.HomeDir,
+		OutputFormat: resp.OutputFormat,
+	}, nil
+}
+
+func (c *GRPCHostClient) Log(ctx context.Context, level LogLevel, message string) error {
+	_, err := c.client.Log(ctx, &pluginv1.LogRequest{Level: pluginv1.LogLevel(level), Message: message})
+	return err
+}
+
+func (c *GRPCHostClient) PromptInput(ctx context.Context, label string, isSecret bool) (string, error) {
+	resp, err := c.client.PromptInput(ctx, &pluginv1.PromptInputRequest{Label: label, IsSecret: isSecret})
+	if err != nil {
+		return "", err
+	}
+	return resp.Value, nil
+}
+
+func (c *GRPCHostClient) PromptConfirm(ctx context.Context, label string) (bool, error) {
+	resp, err := c.client.PromptConfirm(ctx, &pluginv1.PromptConfirmRequest{Label: label})
+	if err != nil {
+		return false, err
+	}
+	return resp.Confirmed, nil
+}
+
+func (c *GRPCHostClient) GetSecret(ctx context.Context, key string) (string, bool, error) {
+	resp, err := c.client.GetSecret(ctx, &pluginv1.GetSecretRequest{Key: key})
+	if err != nil {
+		return "", false, err
+	}
+	return resp.Value, resp.Found, nil
+}
+
+func (c *GRPCHostClient) SetSecret(ctx context.Context, key, value string) error {
+	_, err := c.client.SetSecret(ctx, &pluginv1.SetSecretRequest{Key: key, Value: value})
+	return err
+}
+
+func (c *GRPCHostClient) RenderTable(ctx context.Context, headers []string, rows [][]string) error {
+	req := &pluginv1.RenderTableRequest{Headers: headers}
+	for _, r := range rows {
+		req.Rows = append(req.Rows, &pluginv1.TableRow{Cells: r})
+	}
+	_, err := c.client.RenderTable(ctx, req)
+	return err
+}
+
+func (c *GRPCHostClient) RenderJSON(ctx context.Context, data []byte) error {
+	_, err := c.client.RenderJSON(ctx, &pluginv1.RenderJSONRequest{Json: data})
+	return err
+}
+
+// Authorize asks the host to check scope, so Authorize(ctx, host, scope)
+// works the same in external plugins as in builtins.
+func (c *GRPCHostClient) Authorize(ctx context.Context, scope Scope) error {
+	_, err := c.client.Authorize(ctx, &pluginv1.AuthorizeRequest{Scope: string(scope)})
+	return err
+}
@@ -145,3 +145,29 @@ func TestGRPCHostServer_APIRequest(t *testing.T) {
 		t.Errorf("StatusCode = %d", resp.StatusCode)
 	}
 }
+
+func TestGRPCHostServer_Secrets(t *testing.T) {
+	dir := t.TempDir()
+	secrets := session.NewSecretStore(dir + "/secrets.json")
+	host := NewBuiltinHostServices(&AppContext{Config: &config.Config{HomeDir: dir}, Secrets: secrets})
+	srv := NewGRPCHostServer(host.ForPlugin(Manifest{Name: "onboard"}, false))
+
+	if _, err := srv.SetSecret(context.Background(), &pluginv1.SetSecretRequest{Key: "aws", Value: "AKIA..."}); err != nil {
+		t.Fatalf("SetSecret: %v", err)
+	}
+	resp, err := srv.GetSecret(context.Background(), &pluginv1.GetSecretRequest{Key: "aws"})
+	if err != nil {
+		t.Fatalf("GetSecret: %v", err)
+	}
+	if !resp.Found || resp.Value != "AKIA..." {
+		t.Errorf("GetSecret = %+v", resp)
+	}
+
+	// Other plugins and the CLI itself don't see the plugin's namespace.
+	if _, found, _ := host.GetSecret(context.Background(), "aws"); found {
+		t.Error("unscoped host read a plugin secret")
+	}
+	if _, found, _ := secrets.Get(SecretNamespace("onboard"), "aws"); !found {
+		t.Error("secret not stored under the plugin namespace")
+	}
+}
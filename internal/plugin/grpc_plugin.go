@@ -3,6 +3,9 @@ package plugin
 import (
 	"context"
 
+	goplugin "github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+
 	pluginv1 "github.com/prysmsh/cli/proto/plugin/v1"
 )
 
@@ -10,6 +13,23 @@ import (
 // Used on the host side to talk to external plugin binaries.
 type GRPCPluginClient struct {
 	client pluginv1.PluginServiceClient
+	broker *goplugin.GRPCBroker
+	hostID uint32 // broker ID of the host services served to the plugin
+}
+
+// ServeHost serves host to the plugin over the go-plugin broker. Execute and
+// RunHook calls carry its broker ID so the plugin can reach it.
+func (c *GRPCPluginClient) ServeHost(host HostServices) {
+	if c.broker == nil || host == nil {
+		return
+	}
+	id := c.broker.NextId()
+	go c.broker.AcceptAndServe(id, func(opts []grpc.ServerOption) *grpc.Server {
+		s := grpc.NewServer(opts...)
+		pluginv1.RegisterHostServiceServer(s, NewGRPCHostServer(host))
+		return s
+	})
+	c.hostID = id
 }
 
 func (c *GRPCPluginClient) Manifest() Manifest {
@@ -22,16 +42,19 @@ func (c *GRPCPluginClient) Manifest() Manifest {
 		Version:     resp.Version,
 		Description: resp.Description,
 		Commands:    fromProtoCommandSpecs(resp.Commands),
+		Scopes:      fromProtoScopes(resp.Scopes),
+		Hooks:       fromProtoHookSpecs(resp.Hooks),
 	}
 }
 
 func (c *GRPCPluginClient) Execute(ctx context.Context, req ExecuteRequest) ExecuteResponse {
 	resp, err := c.client.Execute(ctx, &pluginv1.ExecuteRequest{
-		Args:         req.Args,
-		Env:          req.Env,
-		WorkingDir:   req.WorkingDir,
-		OutputFormat: req.OutputFormat,
-		Debug:        req.Debug,
+		Args:          req.Args,
+		Env:           req.Env,
+		WorkingDir:    req.WorkingDir,
+		OutputFormat:  req.OutputFormat,
+		Debug:         req.Debug,
+		HostServiceId: c.hostID,
 	})
 	if err != nil {
 		return ExecuteResponse{ExitCode: 1, Error: err.Error()}
@@ -43,6 +66,22 @@ func (c *GRPCPluginClient) Execute(ctx context.Context, req ExecuteRequest) Exec
 	}
 }
 
+func (c *GRPCPluginClient) RunHook(ctx context.Context, req HookRequest) HookResponse {
+	resp, err := c.client.RunHook(ctx, &pluginv1.RunHookRequest{
+		Phase:         string(req.Phase),
+		Command:       req.Command,
+		Args:          req.Args,
+		Flags:         req.Flags,
+		WorkingDir:    req.WorkingDir,
+		Error:         req.Error,
+		HostServiceId: c.hostID,
+	})
+	if err != nil {
+		return HookResponse{Error: err.Error()}
+	}
+	return HookResponse{Error: resp.Error}
+}
+
 func fromProtoCommandSpecs(specs []*pluginv1.CommandSpec) []CommandSpec {
 	out := make([]CommandSpec, len(specs))
 	for i, s := range specs {
@@ -55,3 +94,25 @@ func fromProtoCommandSpecs(specs []*pluginv1.CommandSpec) []CommandSpec {
 	}
 	return out
 }
+
+func fromProtoScopes(scopes []string) []Scope {
+	var out []Scope
+	for _, s := range scopes {
+		if scope, err := ParseScope(s); err == nil {
+			out = append(out, scope)
+		}
+	}
+	return out
+}
+
+func fromProtoHookSpecs(specs []*pluginv1.HookSpec) []HookSpec {
+	var out []HookSpec
+	for _, s := range specs {
+		phase := HookPhase(s.Phase)
+		if phase != HookBefore && phase != HookAfter {
+			continue
+		}
+		out = append(out, HookSpec{Phase: phase, Command: s.Command})
+	}
+	return out
+}
@@ -0,0 +1,62 @@
+package plugin
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	goplugin "github.com/hashicorp/go-plugin"
+
+	"github.com/prysmsh/cli/internal/config"
+)
+
+// hostCallingPlugin reports the host's home dir and whether it may run local
+// commands, through the host services it is given.
+type hostCallingPlugin struct{}
+
+func (hostCallingPlugin) Manifest() Manifest { return Manifest{Name: "caller"} }
+
+func (hostCallingPlugin) Execute(ctx context.Context, req ExecuteRequest) ExecuteResponse {
+	host := HostServicesFromContext(ctx)
+	if host == nil {
+		return ExecuteResponse{ExitCode: 1, Error: "no host services"}
+	}
+	cfg, err := host.GetConfig(ctx)
+	if err != nil {
+		return ExecuteResponse{ExitCode: 1, Error: err.Error()}
+	}
+	resp := ExecuteResponse{Stdout: cfg.HomeDir}
+	if err := Authorize(ctx, host, ScopeExec); err != nil {
+		resp.ExitCode, resp.Error = 1, err.Error()
+	}
+	return resp
+}
+
+func TestGRPCPluginClient_ServeHost(t *testing.T) {
+	client, server := goplugin.TestPluginGRPCConn(t, false, map[string]goplugin.Plugin{
+		PluginKey: &GRPCPluginImpl{Impl: hostCallingPlugin{}},
+	})
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense(PluginKey)
+	if err != nil {
+		t.Fatalf("Dispense: %v", err)
+	}
+	p := raw.(*GRPCPluginClient)
+
+	home := t.TempDir()
+	host := NewBuiltinHostServices(&AppContext{Config: &config.Config{HomeDir: home}})
+	p.ServeHost(host.ForPlugin(Manifest{Name: "caller"}, false).ForBinary("abc123"))
+
+	// Twice: the plugin reuses its connection to the host.
+	for i := 0; i < 2; i++ {
+		resp := p.Execute(context.Background(), ExecuteRequest{})
+		if resp.Stdout != home {
+			t.Errorf("Stdout = %q, want %q (error %q)", resp.Stdout, home, resp.Error)
+		}
+		if !strings.Contains(resp.Error, `did not declare the "exec" scope`) {
+			t.Errorf("Error = %q, want the undeclared exec scope refused", resp.Error)
+		}
+	}
+}
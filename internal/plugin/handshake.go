@@ -2,9 +2,13 @@ package plugin
 
 import (
 	"context"
+	"log"
+	"sync"
 
 	goplugin "github.com/hashicorp/go-plugin"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	pluginv1 "github.com/prysmsh/cli/proto/plugin/v1"
 )
@@ -29,19 +33,43 @@ type GRPCPluginImpl struct {
 
 // GRPCServer registers the PluginService server (plugin side).
 func (p *GRPCPluginImpl) GRPCServer(broker *goplugin.GRPCBroker, s *grpc.Server) error {
-	pluginv1.RegisterPluginServiceServer(s, &grpcPluginServer{impl: p.Impl})
+	pluginv1.RegisterPluginServiceServer(s, &grpcPluginServer{impl: p.Impl, broker: broker})
 	return nil
 }
 
 // GRPCClient returns a PluginService client (host side).
 func (p *GRPCPluginImpl) GRPCClient(ctx context.Context, broker *goplugin.GRPCBroker, c *grpc.ClientConn) (interface{}, error) {
-	return &GRPCPluginClient{client: pluginv1.NewPluginServiceClient(c)}, nil
+	return &GRPCPluginClient{client: pluginv1.NewPluginServiceClient(c), broker: broker}, nil
 }
 
 // grpcPluginServer wraps a Plugin into a gRPC server implementation.
 type grpcPluginServer struct {
 	pluginv1.UnimplementedPluginServiceServer
-	impl Plugin
+	impl   Plugin
+	broker *goplugin.GRPCBroker
+
+	mu     sync.Mutex
+	host   HostServices
+	hostID uint32
+}
+
+// withHost adds the host services served under broker ID id to ctx. The
+// connection is dialed once and reused: the broker hands out each ID once.
+func (s *grpcPluginServer) withHost(ctx context.Context, id uint32) context.Context {
+	if id == 0 || s.broker == nil {
+		return ctx
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.host == nil || s.hostID != id {
+		conn, err := s.broker.Dial(id)
+		if err != nil {
+			log.Printf("[plugin] connect to host services: %v", err)
+			return ctx
+		}
+		s.host, s.hostID = &GRPCHostClient{client: pluginv1.NewHostServiceClient(conn)}, id
+	}
+	return contextWithHostServices(ctx, s.host)
 }
 
 func (s *grpcPluginServer) GetManifest(ctx context.Context, req *pluginv1.GetManifestRequest) (*pluginv1.GetManifestResponse, error) {
@@ -51,11 +79,13 @@ func (s *grpcPluginServer) GetManifest(ctx context.Context, req *pluginv1.GetMan
 		Version:     m.Version,
 		Description: m.Description,
 		Commands:    convertCommandSpecs(m.Commands),
+		Scopes:      convertScopes(m.Scopes),
+		Hooks:       convertHookSpecs(m.Hooks),
 	}, nil
 }
 
 func (s *grpcPluginServer) Execute(ctx context.Context, req *pluginv1.ExecuteRequest) (*pluginv1.ExecuteResponse, error) {
-	resp := s.impl.Execute(ctx, ExecuteRequest{
+	resp := s.impl.Execute(s.withHost(ctx, req.HostServiceId), ExecuteRequest{
 		Args:         req.Args,
 		Env:          req.Env,
 		WorkingDir:   req.WorkingDir,
@@ -69,6 +99,22 @@ func (s *grpcPluginServer) Execute(ctx context.Context, req *pluginv1.ExecuteReq
 	}, nil
 }
 
+func (s *grpcPluginServer) RunHook(ctx context.Context, req *pluginv1.RunHookRequest) (*pluginv1.RunHookResponse, error) {
+	runner, ok := s.impl.(HookRunner)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "plugin does not implement hooks")
+	}
+	resp := runner.RunHook(s.withHost(ctx, req.HostServiceId), HookRequest{
+		Phase:      HookPhase(req.Phase),
+		Command:    req.Command,
+		Args:       req.Args,
+		Flags:      req.Flags,
+		WorkingDir: req.WorkingDir,
+		Error:      req.Error,
+	})
+	return &pluginv1.RunHookResponse{Error: resp.Error}, nil
+}
+
 func convertCommandSpecs(specs []CommandSpec) []*pluginv1.CommandSpec {
 	out := make([]*pluginv1.CommandSpec, len(specs))
 	for i, s := range specs {
@@ -81,3 +127,19 @@ func convertCommandSpecs(specs []CommandSpec) []*pluginv1.CommandSpec {
 	}
 	return out
 }
+
+func convertScopes(scopes []Scope) []string {
+	out := make([]string, len(scopes))
+	for i, s := range scopes {
+		out[i] = string(s)
+	}
+	return out
+}
+
+func convertHookSpecs(hooks []HookSpec) []*pluginv1.HookSpec {
+	out := make([]*pluginv1.HookSpec, len(hooks))
+	for i, h := range hooks {
+		out[i] = &pluginv1.HookSpec{Phase: string(h.Phase), Command: h.Command}
+	}
+	return out
+}
@@ -0,0 +1,160 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+)
+
+// HookPhase says whether a hook runs before or after the command.
+type HookPhase string
+
+const (
+	HookBefore HookPhase = "before"
+	HookAfter  HookPhase = "after"
+)
+
+// HookSpec registers a plugin hook for a command path (e.g. "connect k8s",
+// "tunnel expose"). A spec also matches subcommands of its path, and "*"
+// matches every command.
+type HookSpec struct {
+	Phase   HookPhase
+	Command string
+}
+
+// Matches reports whether the spec applies to the given command path.
+func (s HookSpec) Matches(command string) bool {
+	want := strings.Join(strings.Fields(s.Command), " ")
+	if want == "*" {
+		return true
+	}
+	return want != "" && (command == want || strings.HasPrefix(command, want+" "))
+}
+
+// HookRequest is the structured context passed to a hook.
+type HookRequest struct {
+	Phase      HookPhase
+	Command    string            // command path without the "prysm" prefix
+	Args       []string          // positional arguments
+	Flags      map[string]string // flags set explicitly on the command line
+	WorkingDir string
+	Error      string // after hooks only: the command's error, if it failed
+}
+
+// HookResponse is a hook's verdict. A non-empty Error from a before hook
+// aborts the command.
+type HookResponse struct {
+	Error string
+}
+
+// HookRunner is implemented by plugins that declare hooks in their Manifest.
+type HookRunner interface {
+	RunHook(ctx context.Context, req HookRequest) HookResponse
+}
+
+// RunHooks invokes every plugin hook registered for req.Phase and req.Command,
+// in plugin name order. A failing before hook stops the command; after hook
+// failures are returned joined but never change the command's outcome.
+//
+// External plugins are only started when their cached manifest declares a
+// matching hook; the cache is filled at install and upgrade time and when a
+// plugin first runs, never by starting a plugin just to read its hooks. A
+// plugin whose cached manifest declares a matching hook but which cannot be
+// loaded (unsigned, not matching plugins.lock, crashing) counts as a failing
+// hook, so guardrails fail closed.
+func (m *Manager) RunHooks(ctx context.Context, req HookRequest) error {
+	var failures []string
+	for _, name := range m.hookPluginNames() {
+		specs, known := m.cachedHooks(name)
+		if !known {
+			if m.debug {
+				log.Printf("[plugin] skipping hooks from %q: no cached manifest", name)
+			}
+			continue
+		}
+		if !hooksMatch(specs, req) {
+			continue
+		}
+		p, err := m.pluginForHooks(name)
+		if err != nil {
+			if req.Phase == HookBefore {
+				return fmt.Errorf("plugin %q hooks this command but could not be loaded: %w", name, err)
+			}
+			failures = append(failures, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		runner, ok := p.(HookRunner)
+		if !ok {
+			continue
+		}
+		if !hooksMatch(p.Manifest().Hooks, req) {
+			continue
+		}
+		resp := runner.RunHook(ctx, req)
+		if resp.Error == "" {
+			continue
+		}
+		if req.Phase == HookBefore {
+			return fmt.Errorf("blocked by plugin %q: %s", name, resp.Error)
+		}
+		failures = append(failures, fmt.Sprintf("%s: %s", name, resp.Error))
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("plugin hooks failed: %s", strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// hooksMatch reports whether any spec applies to req; a plugin is called at
+// most once per phase even if several of its specs match.
+func hooksMatch(specs []HookSpec, req HookRequest) bool {
+	for _, spec := range specs {
+		if spec.Phase == req.Phase && spec.Matches(req.Command) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Manager) hookPluginNames() []string {
+	names := make([]string, 0, len(m.builtins)+len(m.externals))
+	for name := range m.builtins {
+		names = append(names, name)
+	}
+	for name := range m.externals {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// cachedHooks returns a plugin's hooks: a builtin's from its manifest, an
+// external's from its cached manifest, so plugins without matching hooks are
+// never started.
+func (m *Manager) cachedHooks(name string) ([]HookSpec, bool) {
+	if p, ok := m.builtins[name]; ok {
+		return p.Manifest().Hooks, true
+	}
+	entry, ok := m.externals[name]
+	if !ok || entry.manifest == nil {
+		return nil, false
+	}
+	return entry.manifest.Hooks, true
+}
+
+// pluginForHooks returns a plugin for hook dispatch, loading externals on
+// demand.
+func (m *Manager) pluginForHooks(name string) (Plugin, error) {
+	if p, ok := m.builtins[name]; ok {
+		return p, nil
+	}
+	entry := m.externals[name]
+	if entry.plugin == nil {
+		if err := m.loadExternal(entry); err != nil {
+			return nil, err
+		}
+	}
+	return entry.plugin, nil
+}
@@ -0,0 +1,136 @@
+package plugin
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// hookPlugin records the hook requests it receives.
+type hookPlugin struct {
+	mockPlugin
+	calls []HookRequest
+	resp  HookResponse
+}
+
+func (p *hookPlugin) RunHook(ctx context.Context, req HookRequest) HookResponse {
+	p.calls = append(p.calls, req)
+	return p.resp
+}
+
+func TestHookSpec_Matches(t *testing.T) {
+	tests := []struct {
+		spec    string
+		command string
+		want    bool
+	}{
+		{"connect k8s", "connect k8s", true},
+		{"connect", "connect k8s", true},
+		{"connect  k8s", "connect k8s", true},
+		{"connect k8s", "connect", false},
+		{"tunnel expose", "tunnel exposed", false},
+		{"*", "mesh peers", true},
+		{"", "mesh peers", false},
+	}
+	for _, tt := range tests {
+		if got := (HookSpec{Phase: HookBefore, Command: tt.spec}).Matches(tt.command); got != tt.want {
+			t.Errorf("HookSpec{%q}.Matches(%q) = %v, want %v", tt.spec, tt.command, got, tt.want)
+		}
+	}
+}
+
+func TestManager_RunHooks(t *testing.T) {
+	guard := &hookPlugin{mockPlugin: mockPlugin{manifest: Manifest{Name: "guard", Hooks: []HookSpec{
+		{Phase: HookBefore, Command: "tunnel expose"},
+		{Phase: HookBefore, Command: "tunnel"},
+	}}}}
+	audit := &hookPlugin{mockPlugin: mockPlugin{manifest: Manifest{Name: "audit", Hooks: []HookSpec{
+		{Phase: HookAfter, Command: "*"},
+	}}}}
+	m := NewManager(nil, t.TempDir(), false)
+	m.RegisterBuiltin("guard", guard)
+	m.RegisterBuiltin("audit", audit)
+	m.RegisterBuiltin("plain", &mockPlugin{manifest: Manifest{Name: "plain"}})
+
+	req := HookRequest{Phase: HookBefore, Command: "tunnel expose", Args: []string{"8080"}, Flags: map[string]string{"reason": "INC-1"}}
+	if err := m.RunHooks(context.Background(), req); err != nil {
+		t.Fatalf("RunHooks: %v", err)
+	}
+	if len(guard.calls) != 1 {
+		t.Fatalf("guard called %d times, want once", len(guard.calls))
+	}
+	if got := guard.calls[0]; got.Flags["reason"] != "INC-1" || got.Args[0] != "8080" {
+		t.Errorf("hook request = %+v", got)
+	}
+	if len(audit.calls) != 0 {
+		t.Error("after hook ran in the before phase")
+	}
+
+	guard.resp = HookResponse{Error: "a ticket ID is required"}
+	err := m.RunHooks(context.Background(), req)
+	if err == nil || !strings.Contains(err.Error(), `blocked by plugin "guard"`) {
+		t.Errorf("err = %v, want blocked by guard", err)
+	}
+
+	if err := m.RunHooks(context.Background(), HookRequest{Phase: HookBefore, Command: "mesh peers"}); err != nil {
+		t.Errorf("non-matching command: %v", err)
+	}
+
+	audit.resp = HookResponse{Error: "audit sink unreachable"}
+	err = m.RunHooks(context.Background(), HookRequest{Phase: HookAfter, Command: "mesh peers", Error: "boom"})
+	if err == nil || !strings.Contains(err.Error(), "audit sink unreachable") {
+		t.Errorf("after hook err = %v", err)
+	}
+	if len(audit.calls) != 1 || audit.calls[0].Error != "boom" {
+		t.Errorf("audit calls = %+v", audit.calls)
+	}
+}
+
+func TestManager_RunHooksFailsClosedWhenPluginCannotLoad(t *testing.T) {
+	home := t.TempDir()
+	bin := filepath.Join(home, "prysm-plugin-guard")
+	if err := os.WriteFile(bin, []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	m := NewManager(nil, home, false)
+	// No signature and unsigned plugins not allowed: loading fails.
+	m.externals["guard"] = &externalEntry{
+		disc: DiscoveredPlugin{Name: "guard", Path: bin},
+		manifest: &Manifest{Name: "guard", Hooks: []HookSpec{
+			{Phase: HookBefore, Command: "tunnel expose"},
+			{Phase: HookAfter, Command: "tunnel expose"},
+		}},
+	}
+	m.externals["other"] = &externalEntry{disc: DiscoveredPlugin{Name: "other", Path: bin}}
+
+	err := m.RunHooks(context.Background(), HookRequest{Phase: HookBefore, Command: "tunnel expose"})
+	if err == nil || !strings.Contains(err.Error(), `plugin "guard" hooks this command but could not be loaded`) {
+		t.Errorf("before err = %v, want load failure", err)
+	}
+	err = m.RunHooks(context.Background(), HookRequest{Phase: HookAfter, Command: "tunnel expose"})
+	if err == nil || !strings.Contains(err.Error(), "guard:") {
+		t.Errorf("after err = %v, want load failure", err)
+	}
+	// Commands the cached manifest does not hook are unaffected, as are
+	// plugins without a cached manifest.
+	if err := m.RunHooks(context.Background(), HookRequest{Phase: HookBefore, Command: "mesh peers"}); err != nil {
+		t.Errorf("unhooked command err = %v", err)
+	}
+}
+
+func TestManager_RunHooksDoesNotStartUncachedPlugins(t *testing.T) {
+	home := t.TempDir()
+	m := NewManager(nil, home, false)
+	m.SetSignaturePolicy(SignaturePolicy{AllowUnsigned: true})
+	// Starting this binary would fail; it must not be attempted.
+	m.externals["other"] = &externalEntry{disc: DiscoveredPlugin{Name: "other", Path: filepath.Join(home, "missing")}}
+
+	if err := m.RunHooks(context.Background(), HookRequest{Phase: HookBefore, Command: "tunnel expose"}); err != nil {
+		t.Errorf("RunHooks = %v, want uncached plugin skipped", err)
+	}
+	if m.externals["other"].plugin != nil {
+		t.Error("uncached plugin was started")
+	}
+}
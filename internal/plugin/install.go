@@ -0,0 +1,559 @@
+package plugin
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultRegistryURL is the plugin index consulted when installing by name.
+const DefaultRegistryURL = "https://plugins.prysm.sh/index.json"
+
+// installedFile records provenance for plugins installed via `prysm plugin install`.
+const installedFile = "installed.json"
+
+var (
+	validPluginName = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+	platformSuffix  = regexp.MustCompile(`[-_](linux|darwin|windows)([-_].*)?$`)
+
+	// errNotFound marks a download that failed with HTTP 404.
+	errNotFound = errors.New("not found")
+)
+
+// RegistryIndex is the document served at the registry URL.
+type RegistryIndex struct {
+	Plugins []RegistryEntry `json:"plugins"`
+}
+
+// RegistryEntry describes one plugin published to the registry.
+type RegistryEntry struct {
+	Name        string                      `json:"name"`
+	Description string                      `json:"description"`
+	Version     string                      `json:"version"`
+	Publisher   string                      `json:"publisher"`
+	Platforms   map[string]RegistryArtifact `json:"platforms"` // keyed by "<goos>-<goarch>"
+}
+
+// RegistryArtifact is a downloadable plugin build for a single platform.
+type RegistryArtifact struct {
+	URL       string `json:"url"`
+	SHA256    string `json:"sha256"`
+	Signature string `json:"signature,omitempty"` // URL of the binary's minisign signature
+}
+
+// InstallRecord captures where an installed plugin binary came from.
+type InstallRecord struct {
+	Name        string    `json:"name"`
+	Version     string    `json:"version,omitempty"`
+	Source      string    `json:"source"`
+	ArtifactURL string    `json:"artifact_url,omitempty"`
+	SHA256      string    `json:"sha256"` // hash of the installed binary
+	Path        string    `json:"path"`
+	InstalledAt time.Time `json:"installed_at"`
+}
+
+// InstallOptions tweaks a single install.
+type InstallOptions struct {
+	Name   string // override the plugin name derived from the source
+	SHA256 string // expected artifact checksum for URL sources
+	Force  bool   // overwrite an existing binary
+}
+
+// Installer downloads plugin binaries into $PRYSM_HOME/plugins.
+type Installer struct {
+	HomeDir     string
+	RegistryURL string
+	HTTPClient  *http.Client
+	GOOS        string
+	GOARCH      string
+	Signatures  SignaturePolicy // applied before a binary is written
+}
+
+// NewInstaller returns an Installer for the current platform.
+func NewInstaller(homeDir, registryURL string) *Installer {
+	if registryURL == "" {
+		registryURL = DefaultRegistryURL
+	}
+	return &Installer{
+		HomeDir:     homeDir,
+		RegistryURL: registryURL,
+		HTTPClient:  &http.Client{Timeout: 2 * time.Minute},
+		GOOS:        runtime.GOOS,
+		GOARCH:      runtime.GOARCH,
+	}
+}
+
+// PluginsDir returns the directory plugins are installed into.
+func (i *Installer) PluginsDir() string {
+	return filepath.Join(i.HomeDir, "plugins")
+}
+
+// Install resolves source (registry name, https:// URL, or oci:// reference),
+// verifies the artifact checksum, and writes the binary into the plugins dir.
+func (i *Installer) Install(ctx context.Context, source string, opts InstallOptions) (*InstallRecord, error) {
+	source = strings.TrimSpace(source)
+	if source == "" {
+		return nil, fmt.Errorf("plugin source is empty")
+	}
+
+	var (
+		data        []byte
+		sig         []byte
+		artifactURL string
+		err         error
+	)
+	rec := InstallRecord{Source: source}
+
+	switch {
+	case strings.HasPrefix(source, "oci://"):
+		var version string
+		data, sig, version, err = i.fetchOCI(ctx, strings.TrimPrefix(source, "oci://"), opts.Name)
+		if err != nil {
+			return nil, err
+		}
+		rec.Name = nameFromRef(ociRepository(strings.TrimPrefix(source, "oci://")))
+		rec.Version = version
+		artifactURL = source
+	case strings.HasPrefix(source, "https://") || strings.HasPrefix(source, "http://"):
+		if strings.HasPrefix(source, "http://") && !isLoopbackURL(source) {
+			return nil, fmt.Errorf("refusing to download plugin over plaintext http: %s", source)
+		}
+		expected := strings.TrimSpace(opts.SHA256)
+		if expected == "" {
+			expected, err = i.fetchSidecarChecksum(ctx, source)
+			if err != nil {
+				return nil, fmt.Errorf("no checksum for %s (pass --sha256): %w", source, err)
+			}
+		}
+		data, err = i.download(ctx, source)
+		if err != nil {
+			return nil, err
+		}
+		if err := verifySHA256(data, expected); err != nil {
+			return nil, fmt.Errorf("integrity check failed for %s: %w", source, err)
+		}
+		rec.Name = nameFromRef(source)
+		artifactURL = source
+		if sig, err = i.fetchSignature(ctx, source+SignatureSuffix); err != nil {
+			return nil, err
+		}
+	default:
+		entry, err := i.lookup(ctx, source)
+		if err != nil {
+			return nil, err
+		}
+		return i.InstallEntry(ctx, entry, opts)
+	}
+
+	return i.finish(rec, data, sig, artifactURL, opts)
+}
+
+// InstallEntry installs (or reinstalls) a plugin from an already-fetched
+// registry entry. Callers that walk the index, such as upgrade, use this to
+// avoid re-downloading it per plugin.
+func (i *Installer) InstallEntry(ctx context.Context, entry *RegistryEntry, opts InstallOptions) (*InstallRecord, error) {
+	artifact, ok := entry.Platforms[i.GOOS+"-"+i.GOARCH]
+	if !ok {
+		return nil, fmt.Errorf("plugin %q has no build for %s/%s", entry.Name, i.GOOS, i.GOARCH)
+	}
+	data, err := i.download(ctx, artifact.URL)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifySHA256(data, artifact.SHA256); err != nil {
+		return nil, fmt.Errorf("integrity check failed for %s: %w", entry.Name, err)
+	}
+	var sig []byte
+	if artifact.Signature != "" {
+		if sig, err = i.download(ctx, artifact.Signature); err != nil {
+			return nil, fmt.Errorf("fetch signature for %s: %w", entry.Name, err)
+		}
+	}
+	rec := InstallRecord{Name: entry.Name, Version: entry.Version, Source: entry.Name}
+	return i.finish(rec, data, sig, artifact.URL, opts)
+}
+
+// finish extracts, lock- and signature-checks, writes, and records a verified
+// plugin artifact.
+func (i *Installer) finish(rec InstallRecord, data, sig []byte, artifactURL string, opts InstallOptions) (*InstallRecord, error) {
+	if opts.Name != "" {
+		rec.Name = opts.Name
+	}
+	if !validPluginName.MatchString(rec.Name) {
+		return nil, fmt.Errorf("invalid plugin name %q (use --name to set one)", rec.Name)
+	}
+
+	binary, err := extractPluginBinary(data)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(binary)
+	rec.SHA256 = hex.EncodeToString(sum[:])
+
+	lock, err := LoadLock(i.HomeDir)
+	if err != nil {
+		return nil, err
+	}
+	if err := lock.CheckVersion(rec.Name, rec.Version); err != nil {
+		return nil, err
+	}
+	if err := lock.CheckHash(rec.Name, rec.SHA256); err != nil {
+		return nil, err
+	}
+
+	if err := i.Signatures.VerifyBytes(binary, sig); err != nil {
+		if errors.Is(err, ErrUnsigned) {
+			return nil, fmt.Errorf("plugin %q is not signed (use --allow-unsigned to install anyway)", rec.Name)
+		}
+		return nil, fmt.Errorf("verify plugin %q: %w", rec.Name, err)
+	}
+
+	dest := filepath.Join(i.PluginsDir(), pluginPrefix+rec.Name)
+	if _, err := os.Stat(dest); err == nil && !opts.Force {
+		return nil, fmt.Errorf("plugin %q is already installed at %s (use --force to overwrite)", rec.Name, dest)
+	}
+	if err := writeExecutable(dest, binary); err != nil {
+		return nil, err
+	}
+	if len(sig) > 0 {
+		if err := os.WriteFile(dest+SignatureSuffix, sig, 0o644); err != nil {
+			return nil, fmt.Errorf("write plugin signature: %w", err)
+		}
+	} else if err := os.Remove(dest + SignatureSuffix); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("remove stale plugin signature: %w", err)
+	}
+
+	rec.ArtifactURL = artifactURL
+	rec.Path = dest
+	rec.InstalledAt = time.Now().UTC()
+
+	records, err := LoadInstallRecords(i.HomeDir)
+	if err != nil {
+		return nil, err
+	}
+	records[rec.Name] = rec
+	if err := SaveInstallRecords(i.HomeDir, records); err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
+
+// Remove deletes a plugin binary from the plugins dir and drops its install
+// record, scope grants and cached manifest. Binaries elsewhere (e.g. on $PATH)
+// are never touched. It returns the removed path.
+func Remove(homeDir, name string) (string, error) {
+	dest := filepath.Join(homeDir, "plugins", pluginPrefix+name)
+	records, err := LoadInstallRecords(homeDir)
+	if err != nil {
+		return "", err
+	}
+	_, recorded := records[name]
+
+	if err := os.Remove(dest); err != nil {
+		if !os.IsNotExist(err) {
+			return "", fmt.Errorf("remove plugin binary: %w", err)
+		}
+		if !recorded {
+			return "", fmt.Errorf("plugin %q is not installed in %s", name, filepath.Dir(dest))
+		}
+	}
+
+	if err := os.Remove(dest + SignatureSuffix); err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf("remove plugin signature: %w", err)
+	}
+
+	if recorded {
+		delete(records, name)
+		if err := SaveInstallRecords(homeDir, records); err != nil {
+			return "", err
+		}
+	}
+	if err := NewGrantStore(homeDir).Revoke(name); err != nil {
+		return "", err
+	}
+	cache := LoadManifestCache(homeDir)
+	cache.Delete(name)
+	if err := cache.Save(); err != nil {
+		return "", err
+	}
+	return dest, nil
+}
+
+// FetchIndex downloads and decodes the registry index.
+func (i *Installer) FetchIndex(ctx context.Context) (*RegistryIndex, error) {
+	data, err := i.download(ctx, i.RegistryURL)
+	if err != nil {
+		return nil, fmt.Errorf("fetch plugin registry: %w", err)
+	}
+	var idx RegistryIndex
+	if err := json.Unmarshal(data, &idx); err != nil {
+		return nil, fmt.Errorf("parse plugin registry: %w", err)
+	}
+	return &idx, nil
+}
+
+func (i *Installer) lookup(ctx context.Context, name string) (*RegistryEntry, error) {
+	idx, err := i.FetchIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if entry := idx.Find(name); entry != nil {
+		return entry, nil
+	}
+	return nil, fmt.Errorf("plugin %q not found in registry %s", name, i.RegistryURL)
+}
+
+// Find returns the entry for name (case-insensitive), or nil.
+func (idx *RegistryIndex) Find(name string) *RegistryEntry {
+	for _, e := range idx.Plugins {
+		if strings.EqualFold(e.Name, name) {
+			entry := e
+			return &entry
+		}
+	}
+	return nil
+}
+
+// Search returns entries whose name, description or publisher contains query
+// (case-insensitive). Name matches sort first; an empty query returns every entry.
+func (idx *RegistryIndex) Search(query string) []RegistryEntry {
+	q := strings.ToLower(strings.TrimSpace(query))
+	var byName, other []RegistryEntry
+	for _, e := range idx.Plugins {
+		switch {
+		case strings.Contains(strings.ToLower(e.Name), q):
+			byName = append(byName, e)
+		case strings.Contains(strings.ToLower(e.Description), q),
+			strings.Contains(strings.ToLower(e.Publisher), q):
+			other = append(other, e)
+		}
+	}
+	for _, list := range [][]RegistryEntry{byName, other} {
+		sort.Slice(list, func(a, b int) bool { return list[a].Name < list[b].Name })
+	}
+	return append(byName, other...)
+}
+
+// Supports reports whether the entry publishes a build for goos/goarch.
+func (e *RegistryEntry) Supports(goos, goarch string) bool {
+	_, ok := e.Platforms[goos+"-"+goarch]
+	return ok
+}
+
+func (i *Installer) download(ctx context.Context, rawURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "prysm-cli/plugin-installer")
+	resp, err := i.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("download %s: %w", rawURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("download %s: %w", rawURL, errNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download %s: HTTP %d", rawURL, resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// fetchSignature downloads a detached signature. A missing one (HTTP 404)
+// returns nil so the signature policy decides; any other failure is an error
+// rather than being mistaken for an unsigned plugin.
+func (i *Installer) fetchSignature(ctx context.Context, rawURL string) ([]byte, error) {
+	sig, err := i.download(ctx, rawURL)
+	if errors.Is(err, errNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fetch plugin signature: %w", err)
+	}
+	return sig, nil
+}
+
+// fetchSidecarChecksum looks for "<url>.sha256" next to a direct download.
+func (i *Installer) fetchSidecarChecksum(ctx context.Context, rawURL string) (string, error) {
+	data, err := i.download(ctx, rawURL+".sha256")
+	if err != nil {
+		return "", err
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return "", fmt.Errorf("empty checksum file")
+	}
+	return fields[0], nil
+}
+
+// LoadInstallRecords reads the provenance file; a missing file yields an empty map.
+func LoadInstallRecords(homeDir string) (map[string]InstallRecord, error) {
+	records := make(map[string]InstallRecord)
+	data, err := os.ReadFile(filepath.Join(homeDir, "plugins", installedFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return records, nil
+		}
+		return nil, fmt.Errorf("read plugin records: %w", err)
+	}
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("parse plugin records: %w", err)
+	}
+	return records, nil
+}
+
+// SaveInstallRecords writes the provenance file.
+func SaveInstallRecords(homeDir string, records map[string]InstallRecord) error {
+	dir := filepath.Join(homeDir, "plugins")
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("create plugins dir: %w", err)
+	}
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, installedFile), data, 0o600)
+}
+
+// SortedRecords returns records ordered by name.
+func SortedRecords(records map[string]InstallRecord) []InstallRecord {
+	out := make([]InstallRecord, 0, len(records))
+	for _, r := range records {
+		out = append(out, r)
+	}
+	sort.Slice(out, func(a, b int) bool { return out[a].Name < out[b].Name })
+	return out
+}
+
+// extractPluginBinary returns the plugin executable from a raw binary, tar.gz, or zip payload.
+func extractPluginBinary(data []byte) ([]byte, error) {
+	switch {
+	case bytes.HasPrefix(data, []byte{0x1f, 0x8b}):
+		gz, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("open gzip: %w", err)
+		}
+		defer gz.Close()
+		tr := tar.NewReader(gz)
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("read tar: %w", err)
+			}
+			if hdr.Typeflag == tar.TypeReg && strings.HasPrefix(filepath.Base(hdr.Name), pluginPrefix) {
+				return io.ReadAll(tr)
+			}
+		}
+		return nil, fmt.Errorf("no %s* binary found in archive", pluginPrefix)
+	case bytes.HasPrefix(data, []byte("PK\x03\x04")):
+		zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return nil, fmt.Errorf("open zip: %w", err)
+		}
+		for _, f := range zr.File {
+			if !strings.HasPrefix(filepath.Base(f.Name), pluginPrefix) {
+				continue
+			}
+			rc, err := f.Open()
+			if err != nil {
+				return nil, fmt.Errorf("open file in zip: %w", err)
+			}
+			defer rc.Close()
+			return io.ReadAll(rc)
+		}
+		return nil, fmt.Errorf("no %s* binary found in archive", pluginPrefix)
+	}
+	return data, nil
+}
+
+// writeExecutable atomically writes data to dest with mode 0755.
+func writeExecutable(dest string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(dest), 0o700); err != nil {
+		return fmt.Errorf("create plugins dir: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(dest), filepath.Base(dest)+".install-*")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("write plugin binary: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("close plugin binary: %w", err)
+	}
+	if err := os.Chmod(tmpPath, 0o755); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("set permissions: %w", err)
+	}
+	if err := os.Rename(tmpPath, dest); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("move plugin into place: %w", err)
+	}
+	return nil
+}
+
+// verifySHA256 compares the SHA-256 of data against expectedHex in constant time.
+func verifySHA256(data []byte, expectedHex string) error {
+	expectedHex = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(expectedHex)), "sha256:")
+	expected, err := hex.DecodeString(expectedHex)
+	if err != nil || len(expected) != sha256.Size {
+		return fmt.Errorf("invalid sha256 checksum %q", expectedHex)
+	}
+	actual := sha256.Sum256(data)
+	if subtle.ConstantTimeCompare(actual[:], expected) != 1 {
+		return fmt.Errorf("checksum mismatch: expected %s, got %x", expectedHex, actual)
+	}
+	return nil
+}
+
+// nameFromRef derives a plugin name from the last path element of a URL or
+// repository, stripping the prysm-plugin- prefix and any platform/archive suffix
+// (prysm-plugin-foo_linux_amd64.tar.gz -> foo).
+func nameFromRef(ref string) string {
+	if u, err := url.Parse(ref); err == nil && u.Path != "" {
+		ref = u.Path
+	}
+	base := path.Base(ref)
+	for _, ext := range []string{".tar.gz", ".tgz", ".zip", ".exe"} {
+		base = strings.TrimSuffix(base, ext)
+	}
+	base = strings.TrimPrefix(base, pluginPrefix)
+	base = platformSuffix.ReplaceAllString(base, "")
+	return strings.ToLower(base)
+}
+
+func isLoopbackURL(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	host := u.Hostname()
+	return host == "localhost" || host == "127.0.0.1" || host == "::1"
+}
@@ -0,0 +1,329 @@
+package plugin
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func sha256Hex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func tarGz(t *testing.T, name string, content []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o755, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write(content); err != nil {
+		t.Fatal(err)
+	}
+	tw.Close()
+	gz.Close()
+	return buf.Bytes()
+}
+
+func newTestInstaller(t *testing.T, srv *httptest.Server) *Installer {
+	t.Helper()
+	inst := NewInstaller(t.TempDir(), srv.URL+"/index.json")
+	inst.HTTPClient = srv.Client()
+	inst.GOOS, inst.GOARCH = "linux", "amd64"
+	inst.Signatures.AllowUnsigned = true
+	return inst
+}
+
+func TestInstaller_InstallFromRegistry(t *testing.T) {
+	binary := []byte("#!/bin/sh\necho terraform\n")
+	archive := tarGz(t, "prysm-plugin-terraform", binary)
+
+	mux := http.NewServeMux()
+	srv := httptest.NewTLSServer(mux)
+	defer srv.Close()
+	mux.HandleFunc("/index.json", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(RegistryIndex{Plugins: []RegistryEntry{{
+			Name:    "terraform",
+			Version: "1.2.0",
+			Platforms: map[string]RegistryArtifact{
+				"linux-amd64": {URL: srv.URL + "/tf.tar.gz", SHA256: sha256Hex(archive)},
+			},
+		}}})
+	})
+	mux.HandleFunc("/tf.tar.gz", func(w http.ResponseWriter, r *http.Request) { w.Write(archive) })
+
+	inst := newTestInstaller(t, srv)
+	rec, err := inst.Install(context.Background(), "terraform", InstallOptions{})
+	if err != nil {
+		t.Fatalf("Install: %v", err)
+	}
+	if rec.Name != "terraform" || rec.Version != "1.2.0" {
+		t.Errorf("rec = %+v", rec)
+	}
+	if rec.SHA256 != sha256Hex(binary) {
+		t.Errorf("SHA256 = %s, want hash of extracted binary", rec.SHA256)
+	}
+
+	got, err := os.ReadFile(filepath.Join(inst.PluginsDir(), "prysm-plugin-terraform"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, binary) {
+		t.Errorf("installed binary = %q", got)
+	}
+	if d := DiscoverExternal(inst.HomeDir); len(d) == 0 || d[0].Name != "terraform" {
+		t.Errorf("installed plugin not discoverable: %+v", d)
+	}
+
+	records, err := LoadInstallRecords(inst.HomeDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if records["terraform"].Source != "terraform" {
+		t.Errorf("provenance not recorded: %+v", records)
+	}
+
+	if _, err := inst.Install(context.Background(), "terraform", InstallOptions{}); err == nil || !strings.Contains(err.Error(), "already installed") {
+		t.Errorf("second install err = %v, want already installed", err)
+	}
+	if _, err := inst.Install(context.Background(), "terraform", InstallOptions{Force: true}); err != nil {
+		t.Errorf("forced reinstall: %v", err)
+	}
+}
+
+func TestInstaller_InstallFromURL_SidecarChecksum(t *testing.T) {
+	binary := []byte("plugin-bytes")
+	mux := http.NewServeMux()
+	mux.HandleFunc("/prysm-plugin-foo_linux_amd64", func(w http.ResponseWriter, r *http.Request) { w.Write(binary) })
+	mux.HandleFunc("/prysm-plugin-foo_linux_amd64.sha256", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%s  prysm-plugin-foo_linux_amd64\n", sha256Hex(binary))
+	})
+	srv := httptest.NewTLSServer(mux)
+	defer srv.Close()
+
+	inst := newTestInstaller(t, srv)
+	rec, err := inst.Install(context.Background(), srv.URL+"/prysm-plugin-foo_linux_amd64", InstallOptions{})
+	if err != nil {
+		t.Fatalf("Install: %v", err)
+	}
+	if rec.Name != "foo" {
+		t.Errorf("Name = %q, want foo", rec.Name)
+	}
+}
+
+func TestInstaller_InstallFromURL_ChecksumMismatch(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("tampered"))
+	}))
+	defer srv.Close()
+
+	inst := newTestInstaller(t, srv)
+	_, err := inst.Install(context.Background(), srv.URL+"/prysm-plugin-foo", InstallOptions{SHA256: sha256Hex([]byte("original"))})
+	if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("err = %v, want checksum mismatch", err)
+	}
+	if _, statErr := os.Stat(filepath.Join(inst.PluginsDir(), "prysm-plugin-foo")); !os.IsNotExist(statErr) {
+		t.Error("binary should not be written when verification fails")
+	}
+}
+
+func TestInstaller_InstallFromURL_NoChecksum(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, ".sha256") {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("bytes"))
+	}))
+	defer srv.Close()
+
+	inst := newTestInstaller(t, srv)
+	if _, err := inst.Install(context.Background(), srv.URL+"/prysm-plugin-foo", InstallOptions{}); err == nil {
+		t.Fatal("expected error when no checksum is available")
+	}
+}
+
+func TestInstaller_InstallFromOCI(t *testing.T) {
+	binary := []byte("oci-plugin")
+	digest := "sha256:" + sha256Hex(binary)
+	manifestDigest := "sha256:manifest-amd64"
+
+	mux := http.NewServeMux()
+	srv := httptest.NewTLSServer(mux)
+	defer srv.Close()
+
+	authed := func(w http.ResponseWriter, r *http.Request) bool {
+		if r.Header.Get("Authorization") != "Bearer anon" {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="test",scope="repository:acme/prysm-plugin-bar:pull"`, srv.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return false
+		}
+		return true
+	}
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("scope") != "repository:acme/prysm-plugin-bar:pull" {
+			t.Errorf("token scope = %q", r.URL.Query().Get("scope"))
+		}
+		w.Write([]byte(`{"token":"anon"}`))
+	})
+	mux.HandleFunc("/v2/acme/prysm-plugin-bar/manifests/1.4.0", func(w http.ResponseWriter, r *http.Request) {
+		if !authed(w, r) {
+			return
+		}
+		fmt.Fprintf(w, `{"mediaType":%q,"manifests":[{"digest":"sha256:other","platform":{"os":"darwin","architecture":"arm64"}},{"digest":%q,"platform":{"os":"linux","architecture":"amd64"}}]}`, mediaTypeOCIIndex, manifestDigest)
+	})
+	mux.HandleFunc("/v2/acme/prysm-plugin-bar/manifests/"+manifestDigest, func(w http.ResponseWriter, r *http.Request) {
+		if !authed(w, r) {
+			return
+		}
+		fmt.Fprintf(w, `{"mediaType":%q,"layers":[{"digest":%q,"annotations":{"org.opencontainers.image.title":"prysm-plugin-bar"}}]}`, mediaTypeOCIManifest, digest)
+	})
+	mux.HandleFunc("/v2/acme/prysm-plugin-bar/blobs/"+digest, func(w http.ResponseWriter, r *http.Request) {
+		if !authed(w, r) {
+			return
+		}
+		w.Write(binary)
+	})
+
+	inst := newTestInstaller(t, srv)
+	host := strings.TrimPrefix(srv.URL, "https://")
+	rec, err := inst.Install(context.Background(), "oci://"+host+"/acme/prysm-plugin-bar:1.4.0", InstallOptions{})
+	if err != nil {
+		t.Fatalf("Install: %v", err)
+	}
+	if rec.Name != "bar" || rec.Version != "1.4.0" {
+		t.Errorf("rec = %+v", rec)
+	}
+}
+
+func TestInstaller_RejectsPlainHTTP(t *testing.T) {
+	inst := NewInstaller(t.TempDir(), "")
+	if _, err := inst.Install(context.Background(), "http://example.com/prysm-plugin-foo", InstallOptions{}); err == nil {
+		t.Fatal("expected plaintext http to be rejected")
+	}
+}
+
+func TestNameFromRef(t *testing.T) {
+	tests := map[string]string{
+		"https://example.com/dl/prysm-plugin-foo":                   "foo",
+		"https://example.com/prysm-plugin-foo_linux_amd64.tar.gz":   "foo",
+		"https://example.com/prysm-plugin-foo-bar-darwin-arm64.zip": "foo-bar",
+		"https://example.com/prysm-plugin-baz.exe?token=x":          "baz",
+		"acme/prysm-plugin-qux":                                     "qux",
+	}
+	for in, want := range tests {
+		if got := nameFromRef(in); got != want {
+			t.Errorf("nameFromRef(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestParseOCIRef(t *testing.T) {
+	tests := []struct {
+		in                    string
+		host, repo, reference string
+		wantErr               bool
+	}{
+		{in: "ghcr.io/acme/prysm-plugin-foo:1.0.0", host: "ghcr.io", repo: "acme/prysm-plugin-foo", reference: "1.0.0"},
+		{in: "ghcr.io/acme/prysm-plugin-foo", host: "ghcr.io", repo: "acme/prysm-plugin-foo", reference: "latest"},
+		{in: "localhost:5000/foo@sha256:abc", host: "localhost:5000", repo: "foo", reference: "sha256:abc"},
+		{in: "localhost:5000/foo", host: "localhost:5000", repo: "foo", reference: "latest"},
+		{in: "nohost", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseOCIRef(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseOCIRef(%q) expected error", tt.in)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseOCIRef(%q): %v", tt.in, err)
+			continue
+		}
+		if got.Host != tt.host || got.Repository != tt.repo || got.Reference != tt.reference {
+			t.Errorf("parseOCIRef(%q) = %+v", tt.in, got)
+		}
+	}
+}
+
+func TestRemove(t *testing.T) {
+	home := t.TempDir()
+	pluginsDir := filepath.Join(home, "plugins")
+	bin := filepath.Join(pluginsDir, "prysm-plugin-foo")
+	if err := writeExecutable(bin, []byte("x")); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveInstallRecords(home, map[string]InstallRecord{
+		"foo": {Name: "foo", Path: bin},
+		"bar": {Name: "bar"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	path, err := Remove(home, "foo")
+	if err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if path != bin {
+		t.Errorf("path = %q, want %q", path, bin)
+	}
+	if _, err := os.Stat(bin); !os.IsNotExist(err) {
+		t.Error("binary still present after Remove")
+	}
+	records, _ := LoadInstallRecords(home)
+	if _, ok := records["foo"]; ok {
+		t.Error("install record for foo not cleared")
+	}
+	if _, ok := records["bar"]; !ok {
+		t.Error("unrelated install record removed")
+	}
+
+	if _, err := Remove(home, "missing"); err == nil {
+		t.Error("expected error removing a plugin that is not installed")
+	}
+}
+
+func TestRegistryIndex_Search(t *testing.T) {
+	idx := &RegistryIndex{Plugins: []RegistryEntry{
+		{Name: "vault", Description: "HashiCorp Vault helpers", Publisher: "acme"},
+		{Name: "terraform", Description: "Run terraform against clusters", Publisher: "prysm"},
+		{Name: "tf-lint", Description: "Lint plans", Publisher: "acme"},
+	}}
+
+	names := func(entries []RegistryEntry) string {
+		var out []string
+		for _, e := range entries {
+			out = append(out, e.Name)
+		}
+		return strings.Join(out, ",")
+	}
+
+	tests := map[string]string{
+		"":          "terraform,tf-lint,vault",
+		"TF":        "tf-lint",
+		"terraform": "terraform",
+		"acme":      "tf-lint,vault",
+		"cluster":   "terraform",
+		"nomatch":   "",
+	}
+	for q, want := range tests {
+		if got := names(idx.Search(q)); got != want {
+			t.Errorf("Search(%q) = %q, want %q", q, got, want)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// LockFile pins the exact plugin set in $PRYSM_HOME/plugins.lock. It is meant
+// to be shared across a team: installs and upgrades refuse to deviate from it
+// and binaries that no longer match are not loaded.
+const LockFile = "plugins.lock"
+
+// LockEntry pins one plugin to a version and binary hash.
+type LockEntry struct {
+	Version string `json:"version,omitempty"`
+	SHA256  string `json:"sha256"`
+}
+
+// Lock is the parsed plugins.lock.
+type Lock struct {
+	Plugins map[string]LockEntry `json:"plugins"`
+}
+
+// LockPath returns the lockfile location under homeDir.
+func LockPath(homeDir string) string {
+	return filepath.Join(homeDir, LockFile)
+}
+
+// LoadLock reads plugins.lock. A missing file returns nil and no error, which
+// means nothing is pinned.
+func LoadLock(homeDir string) (*Lock, error) {
+	data, err := os.ReadFile(LockPath(homeDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read %s: %w", LockFile, err)
+	}
+	var lock Lock
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", LockFile, err)
+	}
+	if lock.Plugins == nil {
+		lock.Plugins = make(map[string]LockEntry)
+	}
+	return &lock, nil
+}
+
+// SaveLock writes plugins.lock with stable key order.
+func SaveLock(homeDir string, lock *Lock) error {
+	if err := os.MkdirAll(homeDir, 0o700); err != nil {
+		return fmt.Errorf("create prysm home: %w", err)
+	}
+	data, err := json.MarshalIndent(lock, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(LockPath(homeDir), append(data, '\n'), 0o644)
+}
+
+// Pinned returns the lock entry for name, if the lock pins it. Safe on a nil Lock.
+func (l *Lock) Pinned(name string) (LockEntry, bool) {
+	if l == nil {
+		return LockEntry{}, false
+	}
+	e, ok := l.Plugins[name]
+	return e, ok
+}
+
+// CheckVersion rejects installing a version other than the pinned one.
+func (l *Lock) CheckVersion(name, version string) error {
+	pin, ok := l.Pinned(name)
+	if !ok || pin.Version == "" || version == "" || pin.Version == version {
+		return nil
+	}
+	return fmt.Errorf("plugin %q is pinned to v%s in %s (got v%s); update the lockfile with `prysm plugin lock` to change it", name, pin.Version, LockFile, version)
+}
+
+// CheckHash rejects a binary whose SHA-256 differs from the pinned one.
+func (l *Lock) CheckHash(name, sha256Hex string) error {
+	pin, ok := l.Pinned(name)
+	if !ok || pin.SHA256 == "" || pin.SHA256 == sha256Hex {
+		return nil
+	}
+	return fmt.Errorf("plugin %q does not match %s (sha256 %s, pinned %s)", name, LockFile, sha256Hex, pin.SHA256)
+}
+
+// LockDrift describes a difference between plugins.lock and what is installed.
+type LockDrift struct {
+	Name    string `json:"name"`
+	Problem string `json:"problem"`
+}
+
+// CheckInstalled compares the lock against binaries in the plugins dir and
+// returns every missing, modified or unpinned plugin, sorted by name.
+func (l *Lock) CheckInstalled(homeDir string) ([]LockDrift, error) {
+	var drift []LockDrift
+	installed := make(map[string]string)
+	for _, d := range DiscoverExternal(homeDir) {
+		if filepath.Dir(d.Path) != filepath.Join(homeDir, "plugins") {
+			continue
+		}
+		sum, err := HashFile(d.Path)
+		if err != nil {
+			return nil, err
+		}
+		installed[d.Name] = sum
+	}
+	if l != nil {
+		for name, pin := range l.Plugins {
+			sum, ok := installed[name]
+			switch {
+			case !ok:
+				drift = append(drift, LockDrift{Name: name, Problem: "pinned but not installed"})
+			case pin.SHA256 != "" && pin.SHA256 != sum:
+				drift = append(drift, LockDrift{Name: name, Problem: fmt.Sprintf("binary hash %s differs from pinned %s", sum, pin.SHA256)})
+			}
+		}
+	}
+	for name := range installed {
+		if _, ok := l.Pinned(name); !ok {
+			drift = append(drift, LockDrift{Name: name, Problem: "installed but not pinned"})
+		}
+	}
+	sort.Slice(drift, func(i, j int) bool { return drift[i].Name < drift[j].Name })
+	return drift, nil
+}
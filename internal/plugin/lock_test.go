@@ -0,0 +1,112 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadLock_Missing(t *testing.T) {
+	lock, err := LoadLock(t.TempDir())
+	if err != nil || lock != nil {
+		t.Fatalf("LoadLock = %v, %v; want nil, nil", lock, err)
+	}
+	// A nil lock pins nothing.
+	if err := lock.CheckVersion("foo", "1.0.0"); err != nil {
+		t.Errorf("CheckVersion on nil lock: %v", err)
+	}
+	if err := lock.CheckHash("foo", "abc"); err != nil {
+		t.Errorf("CheckHash on nil lock: %v", err)
+	}
+}
+
+func TestInstaller_HonorsLock(t *testing.T) {
+	binary := []byte("terraform-1.3.0")
+	mux := http.NewServeMux()
+	srv := httptest.NewTLSServer(mux)
+	defer srv.Close()
+	mux.HandleFunc("/index.json", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(RegistryIndex{Plugins: []RegistryEntry{{
+			Name:      "terraform",
+			Version:   "1.3.0",
+			Platforms: map[string]RegistryArtifact{"linux-amd64": {URL: srv.URL + "/tf", SHA256: sha256Hex(binary)}},
+		}}})
+	})
+	mux.HandleFunc("/tf", func(w http.ResponseWriter, r *http.Request) { w.Write(binary) })
+
+	inst := newTestInstaller(t, srv)
+	if err := SaveLock(inst.HomeDir, &Lock{Plugins: map[string]LockEntry{"terraform": {Version: "1.2.0"}}}); err != nil {
+		t.Fatal(err)
+	}
+	_, err := inst.Install(context.Background(), "terraform", InstallOptions{})
+	if err == nil || !strings.Contains(err.Error(), "pinned to v1.2.0") {
+		t.Fatalf("err = %v, want pinned version error", err)
+	}
+
+	if err := SaveLock(inst.HomeDir, &Lock{Plugins: map[string]LockEntry{"terraform": {Version: "1.3.0", SHA256: sha256Hex([]byte("other"))}}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := inst.Install(context.Background(), "terraform", InstallOptions{}); err == nil || !strings.Contains(err.Error(), "does not match") {
+		t.Fatalf("err = %v, want hash mismatch", err)
+	}
+
+	if err := SaveLock(inst.HomeDir, &Lock{Plugins: map[string]LockEntry{"terraform": {Version: "1.3.0", SHA256: sha256Hex(binary)}}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := inst.Install(context.Background(), "terraform", InstallOptions{}); err != nil {
+		t.Fatalf("pinned install: %v", err)
+	}
+}
+
+func TestLock_CheckInstalled(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("PATH", "")
+	pluginsDir := filepath.Join(home, "plugins")
+	for name, content := range map[string]string{"good": "good", "tampered": "evil", "extra": "extra"} {
+		if err := writeExecutable(filepath.Join(pluginsDir, pluginPrefix+name), []byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	lock := &Lock{Plugins: map[string]LockEntry{
+		"good":     {SHA256: sha256Hex([]byte("good"))},
+		"tampered": {SHA256: sha256Hex([]byte("original"))},
+		"missing":  {SHA256: sha256Hex([]byte("missing"))},
+	}}
+
+	drift, err := lock.CheckInstalled(home)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]string)
+	for _, d := range drift {
+		got[d.Name] = d.Problem
+	}
+	if len(got) != 3 {
+		t.Fatalf("drift = %+v", drift)
+	}
+	if !strings.Contains(got["tampered"], "differs") || !strings.Contains(got["missing"], "not installed") || !strings.Contains(got["extra"], "not pinned") {
+		t.Errorf("drift = %+v", drift)
+	}
+
+	m := NewManager(nil, home, false)
+	if err := SaveLock(home, lock); err != nil {
+		t.Fatal(err)
+	}
+	hash := func(name string) string {
+		sum, err := HashFile(filepath.Join(pluginsDir, pluginPrefix+name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sum
+	}
+	if err := m.checkLock("tampered", hash("tampered")); err == nil {
+		t.Error("manager should refuse a binary that differs from its pin")
+	}
+	if err := m.checkLock("good", hash("good")); err != nil {
+		t.Errorf("checkLock(good): %v", err)
+	}
+}
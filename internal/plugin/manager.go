@@ -2,6 +2,7 @@ package plugin
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -20,11 +21,15 @@ type Manager struct {
 	homeDir   string
 	debug     bool
 	clients   []*goplugin.Client // for cleanup
+	sigPolicy SignaturePolicy
+	cache     *ManifestCache
 }
 
 type externalEntry struct {
-	disc   DiscoveredPlugin
-	plugin Plugin // lazy-loaded
+	disc     DiscoveredPlugin
+	plugin   Plugin         // lazy-loaded
+	cmd      *cobra.Command // placeholder registered on the root, if any
+	manifest *Manifest      // from the manifest cache or the loaded plugin
 }
 
 // NewManager creates a new plugin manager.
@@ -35,15 +40,22 @@ func NewManager(hostSvc HostServices, homeDir string, debug bool) *Manager {
 		hostSvc:   hostSvc,
 		homeDir:   homeDir,
 		debug:     debug,
+		cache:     LoadManifestCache(homeDir),
 	}
 }
 
+// SetSignaturePolicy configures how external plugin binaries are verified before they run.
+func (m *Manager) SetSignaturePolicy(p SignaturePolicy) {
+	m.sigPolicy = p
+}
+
 // RegisterBuiltin registers an in-process plugin.
 func (m *Manager) RegisterBuiltin(name string, p Plugin) {
 	m.builtins[name] = p
 }
 
-// DiscoverExternal scans for external plugin binaries.
+// DiscoverExternal scans for external plugin binaries and attaches any cached
+// manifests that still match the binary on disk.
 func (m *Manager) DiscoverExternalPlugins() {
 	discovered := DiscoverExternal(m.homeDir)
 	for _, d := range discovered {
@@ -53,8 +65,13 @@ func (m *Manager) DiscoverExternalPlugins() {
 			}
 			continue
 		}
-		m.externals[d.Name] = &externalEntry{disc: d}
+		entry := &externalEntry{disc: d}
+		if manifest, ok := m.cache.Get(d.Name, d.Path); ok {
+			entry.manifest = &manifest
+		}
+		m.externals[d.Name] = entry
 	}
+	m.saveCache()
 }
 
 // RegisterCommands adds plugin commands to the root Cobra command.
@@ -91,6 +108,7 @@ func (m *Manager) RegisterCommands(rootCmd *cobra.Command) {
 		}
 		cmd := m.buildExternalCommand(name, entry)
 		rootCmd.AddCommand(cmd)
+		entry.cmd = cmd
 		existing[name] = true
 	}
 }
@@ -108,12 +126,16 @@ func (m *Manager) ListPlugins() []PluginInfo {
 		})
 	}
 	for name, entry := range m.externals {
-		list = append(list, PluginInfo{
+		info := PluginInfo{
 			Name:        name,
 			Description: "external plugin at " + entry.disc.Path,
 			Type:        "external",
 			Path:        entry.disc.Path,
-		})
+		}
+		if entry.manifest != nil {
+			info.Version = entry.manifest.Version
+		}
+		list = append(list, info)
 	}
 	return list
 }
@@ -135,6 +157,54 @@ func (m *Manager) GetPlugin(name string) Plugin {
 	return nil
 }
 
+// OwnsCommand reports whether cmd is the placeholder registered for an external plugin.
+func (m *Manager) OwnsCommand(cmd *cobra.Command) bool {
+	for _, entry := range m.externals {
+		if entry.cmd != nil && entry.cmd == cmd {
+			return true
+		}
+	}
+	return false
+}
+
+// ExternalVersion loads an external plugin and returns the version reported by its manifest.
+func (m *Manager) ExternalVersion(name string) (string, error) {
+	entry, ok := m.externals[name]
+	if !ok {
+		return "", fmt.Errorf("external plugin %q not found", name)
+	}
+	if entry.manifest != nil {
+		return entry.manifest.Version, nil
+	}
+	if entry.plugin == nil {
+		if err := m.loadExternal(entry); err != nil {
+			return "", err
+		}
+	}
+	return entry.manifest.Version, nil
+}
+
+// RefreshManifest starts the installed external plugin name once and caches
+// its manifest, so its hooks, help and completions are known without
+// starting it again. Install and upgrade call it.
+func (m *Manager) RefreshManifest(name string) error {
+	if _, ok := m.builtins[name]; ok {
+		return fmt.Errorf("plugin %q conflicts with a builtin", name)
+	}
+	for _, d := range DiscoverExternal(m.homeDir) {
+		if d.Name != name {
+			continue
+		}
+		entry := &externalEntry{disc: d}
+		if err := m.loadExternal(entry); err != nil {
+			return err
+		}
+		m.externals[name] = entry
+		return nil
+	}
+	return fmt.Errorf("external plugin %q not found", name)
+}
+
 // Shutdown kills all external plugin subprocesses.
 func (m *Manager) Shutdown() {
 	for _, c := range m.clients {
@@ -212,11 +282,23 @@ func BuildCobraCommand(spec CommandSpec, p Plugin, opts RequestOptions) *cobra.C
 
 // buildExternalCommand creates a placeholder Cobra command for an external plugin.
 // Flag parsing is disabled; all args are passed through to the plugin's Execute.
+// Help text and completions come from the cached manifest, so neither starts
+// the plugin subprocess.
 func (m *Manager) buildExternalCommand(name string, entry *externalEntry) *cobra.Command {
+	short := fmt.Sprintf("External plugin: %s", name)
+	if entry.manifest != nil && entry.manifest.Description != "" {
+		short = entry.manifest.Description
+	}
 	return &cobra.Command{
 		Use:                name,
-		Short:              fmt.Sprintf("External plugin: %s", name),
+		Short:              short,
 		DisableFlagParsing: true,
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if entry.manifest == nil {
+				return nil, cobra.ShellCompDirectiveDefault
+			}
+			return completeCommandSpecs(entry.manifest.Commands, args), cobra.ShellCompDirectiveNoFileComp
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if entry.plugin == nil {
 				if err := m.loadExternal(entry); err != nil {
@@ -244,8 +326,24 @@ func (m *Manager) buildExternalCommand(name string, entry *externalEntry) *cobra
 	}
 }
 
-// loadExternal starts an external plugin subprocess and connects via gRPC.
+// loadExternal verifies an external plugin's signature and plugins.lock pin,
+// then starts its subprocess, connects via gRPC and serves it host services
+// scoped to its manifest.
 func (m *Manager) loadExternal(entry *externalEntry) error {
+	if err := m.sigPolicy.Verify(entry.disc.Path); err != nil {
+		if errors.Is(err, ErrUnsigned) {
+			return fmt.Errorf("%s has no %s signature; trust its publisher key via plugin_trusted_keys or rerun with --allow-unsigned", entry.disc.Path, SignatureSuffix)
+		}
+		return fmt.Errorf("verify plugin %q: %w", entry.disc.Name, err)
+	}
+	sum, err := HashFile(entry.disc.Path)
+	if err != nil {
+		return fmt.Errorf("hash plugin %q: %w", entry.disc.Name, err)
+	}
+	if err := m.checkLock(entry.disc.Name, sum); err != nil {
+		return err
+	}
+
 	client := goplugin.NewClient(&goplugin.ClientConfig{
 		HandshakeConfig: HandshakeConfig,
 		Plugins: map[string]goplugin.Plugin{
@@ -276,9 +374,71 @@ func (m *Manager) loadExternal(entry *externalEntry) error {
 
 	entry.plugin = p
 	m.clients = append(m.clients, client)
+
+	manifest := p.Manifest()
+	entry.manifest = &manifest
+	p.ServeHost(m.hostFor(entry.disc.Name, sum, manifest))
+	if err := m.cache.Put(entry.disc.Name, entry.disc.Path, manifest); err == nil {
+		m.saveCache()
+	}
 	return nil
 }
 
+// checkLock refuses to run a binary that differs from its plugins.lock pin.
+func (m *Manager) checkLock(name, sum string) error {
+	lock, err := LoadLock(m.homeDir)
+	if err != nil {
+		return err
+	}
+	if _, pinned := lock.Pinned(name); !pinned {
+		return nil
+	}
+	return lock.CheckHash(name, sum)
+}
+
+// hostFor returns the host services an external plugin may use: restricted
+// to the scopes in its manifest, with grants tied to the binary's SHA-256.
+// The plugin's command name is used over the name it reports, so it cannot
+// claim another plugin's grants or secrets.
+func (m *Manager) hostFor(name, sum string, manifest Manifest) HostServices {
+	h, ok := m.hostSvc.(*BuiltinHostServices)
+	if !ok {
+		return m.hostSvc
+	}
+	manifest.Name = name
+	return h.ForPlugin(manifest, false).ForBinary(sum)
+}
+
+func (m *Manager) saveCache() {
+	if m.homeDir == "" {
+		return
+	}
+	if err := m.cache.Save(); err != nil && m.debug {
+		log.Printf("[plugin] %v", err)
+	}
+}
+
+// completeCommandSpecs returns the subcommand names available after args.
+func completeCommandSpecs(specs []CommandSpec, args []string) []string {
+	for _, arg := range args {
+		var next []CommandSpec
+		for _, s := range specs {
+			if s.Name == arg {
+				next = s.Subcommands
+				break
+			}
+		}
+		specs = next
+	}
+	var names []string
+	for _, s := range specs {
+		if !s.Hidden {
+			names = append(names, s.Name+"\t"+s.Short)
+		}
+	}
+	return names
+}
+
 // buildCommandPath reconstructs the command path from cobra for plugin routing.
 func buildCommandPath(cmd *cobra.Command) string {
 	var parts []string
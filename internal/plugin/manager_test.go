@@ -21,6 +21,14 @@ func (m *mockHostServices) GetConfig(context.Context) (*HostConfig, error)
 func (m *mockHostServices) Log(context.Context, LogLevel, string) error         { return nil }
 func (m *mockHostServices) PromptInput(context.Context, string, bool) (string, error) { return "", nil }
 func (m *mockHostServices) PromptConfirm(context.Context, string) (bool, error)  { return false, nil }
+func (m *mockHostServices) GetSecret(context.Context, string) (string, bool, error) {
+	return "", false, nil
+}
+func (m *mockHostServices) SetSecret(context.Context, string, string) error { return nil }
+func (m *mockHostServices) RenderTable(context.Context, []string, [][]string) error {
+	return nil
+}
+func (m *mockHostServices) RenderJSON(context.Context, []byte) error { return nil }
 
 // mockPlugin is a minimal in-process plugin for testing.
 type mockPlugin struct {
@@ -295,3 +303,37 @@ func TestManager_RegisterCommands(t *testing.T) {
 		t.Errorf("expected 2 commands (existing + newcmd), got %d", len(rootCmd.Commands()))
 	}
 }
+
+func TestManager_OwnsCommand(t *testing.T) {
+	dir := t.TempDir()
+	pluginsDir := filepath.Join(dir, "plugins")
+	if err := os.MkdirAll(pluginsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"prysm-plugin-extra", "prysm-plugin-existing"} {
+		if err := os.WriteFile(filepath.Join(pluginsDir, name), []byte("#!/bin/sh"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rootCmd := cobra.Command{}
+	builtin := &cobra.Command{Use: "existing"}
+	rootCmd.AddCommand(builtin)
+
+	m := NewManager(nil, dir, false)
+	m.DiscoverExternalPlugins()
+	m.RegisterCommands(&rootCmd)
+
+	for _, c := range rootCmd.Commands() {
+		switch c.Name() {
+		case "extra":
+			if !m.OwnsCommand(c) {
+				t.Error("OwnsCommand(extra) = false, want true")
+			}
+		case "existing":
+			if m.OwnsCommand(c) {
+				t.Error("OwnsCommand(existing) = true for a shadowing builtin command")
+			}
+		}
+	}
+}
@@ -0,0 +1,281 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	mediaTypeOCIIndex      = "application/vnd.oci.image.index.v1+json"
+	mediaTypeOCIManifest   = "application/vnd.oci.image.manifest.v1+json"
+	mediaTypeDockerList    = "application/vnd.docker.distribution.manifest.list.v2+json"
+	mediaTypeDockerSchema2 = "application/vnd.docker.distribution.manifest.v2+json"
+	ociTitleAnnotation     = "org.opencontainers.image.title"
+)
+
+type ociDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Platform    *struct {
+		OS           string `json:"os"`
+		Architecture string `json:"architecture"`
+	} `json:"platform,omitempty"`
+}
+
+type ociManifest struct {
+	MediaType string          `json:"mediaType"`
+	Manifests []ociDescriptor `json:"manifests"` // image index
+	Layers    []ociDescriptor `json:"layers"`    // image manifest
+}
+
+// ociRef is a parsed "host/repo[:tag|@digest]" reference.
+type ociRef struct {
+	Host       string
+	Repository string
+	Reference  string // tag or digest
+}
+
+func parseOCIRef(ref string) (ociRef, error) {
+	host, rest, ok := strings.Cut(ref, "/")
+	if !ok || host == "" || rest == "" {
+		return ociRef{}, fmt.Errorf("invalid oci reference %q (want oci://host/repo[:tag])", ref)
+	}
+	out := ociRef{Host: host, Reference: "latest"}
+	if repo, digest, ok := strings.Cut(rest, "@"); ok {
+		out.Repository, out.Reference = repo, digest
+		return out, nil
+	}
+	if idx := strings.LastIndex(rest, ":"); idx > strings.LastIndex(rest, "/") {
+		out.Repository, out.Reference = rest[:idx], rest[idx+1:]
+	} else {
+		out.Repository = rest
+	}
+	return out, nil
+}
+
+// ociRepository returns the repository path of an oci reference (without tag).
+func ociRepository(ref string) string {
+	r, err := parseOCIRef(ref)
+	if err != nil {
+		return ref
+	}
+	return r.Repository
+}
+
+// fetchOCI pulls a plugin artifact from an OCI registry, along with its
+// detached signature: a layer titled prysm-plugin-<name>.minisig in the same
+// manifest (nil when there is none). The returned version is the tag (empty
+// for "latest" or digest references). Blob digests double as the integrity
+// check.
+func (i *Installer) fetchOCI(ctx context.Context, rawRef, nameHint string) ([]byte, []byte, string, error) {
+	ref, err := parseOCIRef(rawRef)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	c := &ociClient{http: i.HTTPClient, ref: ref}
+
+	m, err := c.manifest(ctx, ref.Reference)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if len(m.Manifests) > 0 {
+		var picked string
+		for _, d := range m.Manifests {
+			if d.Platform != nil && d.Platform.OS == i.GOOS && d.Platform.Architecture == i.GOARCH {
+				picked = d.Digest
+				break
+			}
+		}
+		if picked == "" {
+			return nil, nil, "", fmt.Errorf("oci://%s has no manifest for %s/%s", rawRef, i.GOOS, i.GOARCH)
+		}
+		if m, err = c.manifest(ctx, picked); err != nil {
+			return nil, nil, "", err
+		}
+	}
+
+	name := nameHint
+	if name == "" {
+		name = nameFromRef(ref.Repository)
+	}
+	var layer, sigLayer *ociDescriptor
+	for idx := range m.Layers {
+		l := &m.Layers[idx]
+		title := l.Annotations[ociTitleAnnotation]
+		switch {
+		case strings.HasSuffix(title, SignatureSuffix):
+			if title == pluginPrefix+name+SignatureSuffix {
+				sigLayer = l
+			}
+		case title == pluginPrefix+name:
+			layer = l
+		case layer == nil:
+			layer = l
+		}
+	}
+	if layer == nil {
+		return nil, nil, "", fmt.Errorf("oci://%s has no layers", rawRef)
+	}
+
+	data, err := c.blob(ctx, layer.Digest)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if err := verifySHA256(data, layer.Digest); err != nil {
+		return nil, nil, "", fmt.Errorf("integrity check failed for oci://%s: %w", rawRef, err)
+	}
+	var sig []byte
+	if sigLayer != nil {
+		if sig, err = c.blob(ctx, sigLayer.Digest); err != nil {
+			return nil, nil, "", fmt.Errorf("fetch plugin signature: %w", err)
+		}
+		if err := verifySHA256(sig, sigLayer.Digest); err != nil {
+			return nil, nil, "", fmt.Errorf("integrity check failed for the signature of oci://%s: %w", rawRef, err)
+		}
+	}
+
+	version := ref.Reference
+	if version == "latest" || strings.HasPrefix(version, "sha256:") {
+		version = ""
+	}
+	return data, sig, version, nil
+}
+
+// ociClient speaks just enough of the OCI distribution API to pull one
+// artifact, including the anonymous bearer-token dance used by public registries.
+type ociClient struct {
+	http  *http.Client
+	ref   ociRef
+	token string
+}
+
+func (c *ociClient) manifest(ctx context.Context, reference string) (*ociManifest, error) {
+	endpoint := fmt.Sprintf("https://%s/v2/%s/manifests/%s", c.ref.Host, c.ref.Repository, reference)
+	accept := strings.Join([]string{mediaTypeOCIIndex, mediaTypeOCIManifest, mediaTypeDockerList, mediaTypeDockerSchema2}, ", ")
+	body, err := c.get(ctx, endpoint, accept)
+	if err != nil {
+		return nil, err
+	}
+	var m ociManifest
+	if err := json.Unmarshal(body, &m); err != nil {
+		return nil, fmt.Errorf("parse oci manifest: %w", err)
+	}
+	return &m, nil
+}
+
+func (c *ociClient) blob(ctx context.Context, digest string) ([]byte, error) {
+	endpoint := fmt.Sprintf("https://%s/v2/%s/blobs/%s", c.ref.Host, c.ref.Repository, digest)
+	return c.get(ctx, endpoint, "")
+}
+
+func (c *ociClient) get(ctx context.Context, endpoint, accept string) ([]byte, error) {
+	for attempt := 0; attempt < 2; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+		if err != nil {
+			return nil, err
+		}
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		if c.token != "" {
+			req.Header.Set("Authorization", "Bearer "+c.token)
+		}
+		resp, err := c.http.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("oci request %s: %w", endpoint, err)
+		}
+		if resp.StatusCode == http.StatusUnauthorized && c.token == "" {
+			challenge := resp.Header.Get("WWW-Authenticate")
+			resp.Body.Close()
+			if err := c.authenticate(ctx, challenge); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("read oci response: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("oci request %s: HTTP %d", endpoint, resp.StatusCode)
+		}
+		return body, nil
+	}
+	return nil, fmt.Errorf("oci request %s: unauthorized", endpoint)
+}
+
+// authenticate fetches an anonymous pull token from the realm advertised in a
+// `WWW-Authenticate: Bearer realm=...,service=...,scope=...` challenge.
+func (c *ociClient) authenticate(ctx context.Context, challenge string) error {
+	params := parseAuthChallenge(challenge)
+	realm := params["realm"]
+	if realm == "" {
+		return fmt.Errorf("oci registry %s requires authentication", c.ref.Host)
+	}
+	u, err := url.Parse(realm)
+	if err != nil {
+		return fmt.Errorf("invalid oci auth realm %q: %w", realm, err)
+	}
+	q := u.Query()
+	if s := params["service"]; s != "" {
+		q.Set("service", s)
+	}
+	scope := params["scope"]
+	if scope == "" {
+		scope = fmt.Sprintf("repository:%s:pull", c.ref.Repository)
+	}
+	q.Set("scope", scope)
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("oci token request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("oci token request: HTTP %d", resp.StatusCode)
+	}
+	var tok struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tok); err != nil {
+		return fmt.Errorf("parse oci token: %w", err)
+	}
+	c.token = tok.Token
+	if c.token == "" {
+		c.token = tok.AccessToken
+	}
+	if c.token == "" {
+		return fmt.Errorf("oci token response did not contain a token")
+	}
+	return nil
+}
+
+func parseAuthChallenge(challenge string) map[string]string {
+	out := make(map[string]string)
+	scheme, rest, ok := strings.Cut(strings.TrimSpace(challenge), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return out
+	}
+	for _, part := range strings.Split(rest, ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		out[strings.ToLower(k)] = strings.Trim(v, `"`)
+	}
+	return out
+}
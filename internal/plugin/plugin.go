@@ -18,6 +18,8 @@ type Manifest struct {
 	Version     string
 	Description string
 	Commands    []CommandSpec
+	Scopes      []Scope    // capabilities the plugin needs from HostServices
+	Hooks       []HookSpec // commands the plugin wants to run before/after (see HookRunner)
 }
 
 // CommandSpec describes a command or subcommand tree exposed by a plugin.
@@ -55,6 +57,14 @@ type HostServices interface {
 	Log(ctx context.Context, level LogLevel, message string) error
 	PromptInput(ctx context.Context, label string, isSecret bool) (string, error)
 	PromptConfirm(ctx context.Context, label string) (bool, error)
+	// GetSecret and SetSecret access the calling plugin's encrypted secret
+	// store; SetSecret with an empty value deletes the key.
+	GetSecret(ctx context.Context, key string) (string, bool, error)
+	SetSecret(ctx context.Context, key, value string) error
+	// RenderTable and RenderJSON write command output in the user's selected
+	// output format, so plugins don't hand-format text.
+	RenderTable(ctx context.Context, headers []string, rows [][]string) error
+	RenderJSON(ctx context.Context, data []byte) error
 }
 
 // AuthContext contains the authenticated user's context from the CLI session.
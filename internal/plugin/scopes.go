@@ -0,0 +1,156 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Scope is a capability a plugin declares in its Manifest. Host services refuse
+// calls that need an undeclared scope, and ask the user before first use of a
+// declared one.
+type Scope string
+
+const (
+	ScopeAPIRead  Scope = "api:read"  // GET/HEAD via APIRequest, GetAuthContext (without token)
+	ScopeAPIWrite Scope = "api:write" // mutating APIRequest calls and the session token
+	ScopePrompt   Scope = "prompt"    // PromptInput and PromptConfirm
+	ScopeExec     Scope = "exec"      // running local processes (see Authorize)
+)
+
+var scopeDescriptions = map[Scope]string{
+	ScopeAPIRead:  "read data from the Prysm API as you",
+	ScopeAPIWrite: "make changes through the Prysm API and use your session token",
+	ScopePrompt:   "prompt you for input",
+	ScopeExec:     "run local commands",
+}
+
+// grantsFile stores the scopes the user has approved, per plugin binary.
+const grantsFile = "grants.json"
+
+// GrantKey identifies an external plugin binary in the grant store.
+func GrantKey(pluginName, sha256 string) string {
+	return pluginName + "@sha256:" + sha256
+}
+
+// ParseScope validates a scope string from a manifest.
+func ParseScope(s string) (Scope, error) {
+	scope := Scope(strings.TrimSpace(strings.ToLower(s)))
+	if _, ok := scopeDescriptions[scope]; !ok {
+		return "", fmt.Errorf("unknown plugin scope %q", s)
+	}
+	return scope, nil
+}
+
+// Authorize checks scope against host when it enforces plugin scopes. Plugins
+// call it before actions the host cannot mediate itself, such as spawning
+// processes (ScopeExec).
+func Authorize(ctx context.Context, host HostServices, scope Scope) error {
+	if a, ok := host.(interface {
+		Authorize(context.Context, Scope) error
+	}); ok {
+		return a.Authorize(ctx, scope)
+	}
+	return nil
+}
+
+// GrantStore persists approved plugin scopes in $PRYSM_HOME/plugins/grants.json,
+// keyed by GrantKey.
+type GrantStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewGrantStore returns the grant store under homeDir.
+func NewGrantStore(homeDir string) *GrantStore {
+	return &GrantStore{path: filepath.Join(homeDir, "plugins", grantsFile)}
+}
+
+// Granted reports whether the user has approved scope for key.
+func (g *GrantStore) Granted(key string, scope Scope) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	grants, err := g.load()
+	if err != nil {
+		return false
+	}
+	for _, s := range grants[key] {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Grant records approval of scope for key.
+func (g *GrantStore) Grant(key string, scope Scope) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	grants, err := g.load()
+	if err != nil {
+		return err
+	}
+	for _, s := range grants[key] {
+		if s == scope {
+			return nil
+		}
+	}
+	grants[key] = append(grants[key], scope)
+	sort.Slice(grants[key], func(i, j int) bool { return grants[key][i] < grants[key][j] })
+	return g.save(grants)
+}
+
+// Revoke drops every grant held by any binary of pluginName.
+func (g *GrantStore) Revoke(pluginName string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	grants, err := g.load()
+	if err != nil {
+		return err
+	}
+	changed := false
+	for key := range grants {
+		if key == pluginName || strings.HasPrefix(key, pluginName+"@") {
+			delete(grants, key)
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return g.save(grants)
+}
+
+func (g *GrantStore) load() (map[string][]Scope, error) {
+	grants := make(map[string][]Scope)
+	data, err := os.ReadFile(g.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return grants, nil
+		}
+		return nil, fmt.Errorf("read plugin grants: %w", err)
+	}
+	if err := json.Unmarshal(data, &grants); err != nil {
+		return nil, fmt.Errorf("parse plugin grants: %w", err)
+	}
+	return grants, nil
+}
+
+func (g *GrantStore) save(grants map[string][]Scope) error {
+	if err := os.MkdirAll(filepath.Dir(g.path), 0o700); err != nil {
+		return fmt.Errorf("create plugins dir: %w", err)
+	}
+	data, err := json.MarshalIndent(grants, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(g.path, data, 0o600); err != nil {
+		return fmt.Errorf("write plugin grants: %w", err)
+	}
+	return nil
+}
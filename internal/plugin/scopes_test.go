@@ -0,0 +1,29 @@
+package plugin
+
+import "testing"
+
+func TestGrantStore_KeyedByBinary(t *testing.T) {
+	g := NewGrantStore(t.TempDir())
+	if err := g.Grant(GrantKey("deploy", "aaa"), ScopeExec); err != nil {
+		t.Fatalf("Grant: %v", err)
+	}
+	if err := g.Grant(GrantKey("deploy-tools", "ccc"), ScopeExec); err != nil {
+		t.Fatalf("Grant: %v", err)
+	}
+	if !g.Granted(GrantKey("deploy", "aaa"), ScopeExec) {
+		t.Error("grant was not persisted")
+	}
+	if g.Granted(GrantKey("deploy", "bbb"), ScopeExec) {
+		t.Error("a replaced binary inherited the grant")
+	}
+
+	if err := g.Revoke("deploy"); err != nil {
+		t.Fatalf("Revoke: %v", err)
+	}
+	if g.Granted(GrantKey("deploy", "aaa"), ScopeExec) {
+		t.Error("Revoke kept the grant")
+	}
+	if !g.Granted(GrantKey("deploy-tools", "ccc"), ScopeExec) {
+		t.Error("Revoke dropped another plugin's grant")
+	}
+}
@@ -0,0 +1,58 @@
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/prysmsh/cli/internal/minisign"
+)
+
+// SignatureSuffix is appended to a plugin binary path to locate its detached
+// minisign signature (prysm-plugin-foo -> prysm-plugin-foo.minisig).
+const SignatureSuffix = ".minisig"
+
+// ErrUnsigned is returned when a plugin has no signature file.
+var ErrUnsigned = errors.New("plugin is not signed")
+
+// SignaturePolicy controls verification of external plugin binaries before they run.
+type SignaturePolicy struct {
+	// TrustedKeys are minisign public keys, either the bare base64 line or the
+	// full contents of a minisign.pub file.
+	TrustedKeys []string
+	// AllowUnsigned lets plugins without a signature file run. A signature that
+	// is present but invalid is still rejected.
+	AllowUnsigned bool
+}
+
+// Verify checks the plugin binary at path against path+SignatureSuffix.
+func (p SignaturePolicy) Verify(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read plugin binary: %w", err)
+	}
+	sig, err := os.ReadFile(path + SignatureSuffix)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("read plugin signature: %w", err)
+	}
+	return p.VerifyBytes(data, sig)
+}
+
+// VerifyBytes checks data against a minisign signature. A nil/empty sig is
+// treated as unsigned.
+func (p SignaturePolicy) VerifyBytes(data, sig []byte) error {
+	if len(sig) == 0 {
+		if p.AllowUnsigned {
+			return nil
+		}
+		return ErrUnsigned
+	}
+	if len(p.TrustedKeys) == 0 {
+		return fmt.Errorf("plugin is signed but no trusted keys are configured (set plugin_trusted_keys in config.yaml)")
+	}
+
+	if err := minisign.Verify(p.TrustedKeys, data, sig); err != nil {
+		return fmt.Errorf("verify plugin signature: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package plugin
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+type testSigner struct {
+	id   [8]byte
+	priv ed25519.PrivateKey
+	pub  ed25519.PublicKey
+}
+
+func newTestSigner(t *testing.T, id byte) *testSigner {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &testSigner{priv: priv, pub: pub}
+	s.id[0] = id
+	return s
+}
+
+func (s *testSigner) publicKey() string {
+	b := append([]byte("Ed"), s.id[:]...)
+	b = append(b, s.pub...)
+	return "untrusted comment: minisign public key\n" + base64.StdEncoding.EncodeToString(b) + "\n"
+}
+
+func (s *testSigner) sign(data []byte, prehash bool, trusted string) []byte {
+	alg, msg := "Ed", data
+	if prehash {
+		h := blake2b.Sum512(data)
+		alg, msg = "ED", h[:]
+	}
+	sig := ed25519.Sign(s.priv, msg)
+	blob := append([]byte(alg), s.id[:]...)
+	blob = append(blob, sig...)
+	global := ed25519.Sign(s.priv, append(append([]byte{}, sig...), trusted...))
+	return []byte(fmt.Sprintf("untrusted comment: signature\n%s\ntrusted comment: %s\n%s\n",
+		base64.StdEncoding.EncodeToString(blob), trusted, base64.StdEncoding.EncodeToString(global)))
+}
+
+func TestSignaturePolicy_VerifyBytes(t *testing.T) {
+	signer := newTestSigner(t, 1)
+	other := newTestSigner(t, 2)
+	data := []byte("plugin-binary")
+	policy := SignaturePolicy{TrustedKeys: []string{signer.publicKey()}}
+
+	if err := policy.VerifyBytes(data, signer.sign(data, false, "timestamp:1")); err != nil {
+		t.Errorf("Ed signature: %v", err)
+	}
+	if err := policy.VerifyBytes(data, signer.sign(data, true, "timestamp:1")); err != nil {
+		t.Errorf("ED (prehashed) signature: %v", err)
+	}
+	if err := policy.VerifyBytes(data, other.sign(data, false, "x")); err == nil || !strings.Contains(err.Error(), "untrusted key") {
+		t.Errorf("untrusted key err = %v", err)
+	}
+	if err := policy.VerifyBytes([]byte("tampered"), signer.sign(data, false, "x")); err == nil {
+		t.Error("expected tampered data to fail verification")
+	}
+
+	forged := strings.Replace(string(signer.sign(data, false, "original")), "trusted comment: original", "trusted comment: forged", 1)
+	if err := policy.VerifyBytes(data, []byte(forged)); err == nil {
+		t.Error("expected modified trusted comment to fail verification")
+	}
+
+	if err := policy.VerifyBytes(data, nil); !errors.Is(err, ErrUnsigned) {
+		t.Errorf("unsigned err = %v, want ErrUnsigned", err)
+	}
+	policy.AllowUnsigned = true
+	if err := policy.VerifyBytes(data, nil); err != nil {
+		t.Errorf("unsigned with AllowUnsigned: %v", err)
+	}
+	if err := policy.VerifyBytes([]byte("tampered"), signer.sign(data, false, "x")); err == nil {
+		t.Error("AllowUnsigned must not accept an invalid signature")
+	}
+}
+
+func TestSignaturePolicy_Verify(t *testing.T) {
+	signer := newTestSigner(t, 1)
+	data := []byte("plugin-binary")
+	path := filepath.Join(t.TempDir(), "prysm-plugin-foo")
+	if err := os.WriteFile(path, data, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	policy := SignaturePolicy{TrustedKeys: []string{signer.publicKey()}}
+
+	if err := policy.Verify(path); !errors.Is(err, ErrUnsigned) {
+		t.Errorf("err = %v, want ErrUnsigned", err)
+	}
+	if err := os.WriteFile(path+SignatureSuffix, signer.sign(data, true, "x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := policy.Verify(path); err != nil {
+		t.Errorf("Verify: %v", err)
+	}
+}
+
+func TestInstaller_Signatures(t *testing.T) {
+	signer := newTestSigner(t, 1)
+	binary := []byte("signed-plugin")
+	mux := http.NewServeMux()
+	mux.HandleFunc("/prysm-plugin-foo", func(w http.ResponseWriter, r *http.Request) { w.Write(binary) })
+	mux.HandleFunc("/prysm-plugin-foo.minisig", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(signer.sign(binary, true, "file:prysm-plugin-foo"))
+	})
+	mux.HandleFunc("/prysm-plugin-bar", func(w http.ResponseWriter, r *http.Request) { w.Write(binary) })
+	mux.HandleFunc("/prysm-plugin-baz", func(w http.ResponseWriter, r *http.Request) { w.Write(binary) })
+	mux.HandleFunc("/prysm-plugin-baz.minisig", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	})
+	srv := httptest.NewTLSServer(mux)
+	defer srv.Close()
+
+	inst := newTestInstaller(t, srv)
+	inst.Signatures = SignaturePolicy{TrustedKeys: []string{signer.publicKey()}}
+	opts := InstallOptions{SHA256: sha256Hex(binary)}
+
+	rec, err := inst.Install(context.Background(), srv.URL+"/prysm-plugin-foo", opts)
+	if err != nil {
+		t.Fatalf("Install signed: %v", err)
+	}
+	if err := inst.Signatures.Verify(rec.Path); err != nil {
+		t.Errorf("installed plugin does not verify: %v", err)
+	}
+
+	if _, err := inst.Install(context.Background(), srv.URL+"/prysm-plugin-bar", opts); err == nil || !strings.Contains(err.Error(), "not signed") {
+		t.Errorf("unsigned install err = %v, want not signed", err)
+	}
+	if _, err := os.Stat(filepath.Join(inst.PluginsDir(), "prysm-plugin-bar")); !os.IsNotExist(err) {
+		t.Error("unsigned binary should not be written")
+	}
+
+	// Only a missing signature counts as unsigned; other failures abort even
+	// when unsigned plugins are allowed.
+	inst.Signatures.AllowUnsigned = true
+	if _, err := inst.Install(context.Background(), srv.URL+"/prysm-plugin-baz", opts); err == nil || !strings.Contains(err.Error(), "HTTP 503") {
+		t.Errorf("signature fetch failure err = %v, want HTTP 503", err)
+	}
+}
+
+func TestInstaller_OCISignature(t *testing.T) {
+	signer := newTestSigner(t, 1)
+	binary := []byte("oci-plugin")
+	sig := signer.sign(binary, true, "file:prysm-plugin-bar")
+	digest := "sha256:" + sha256Hex(binary)
+	sigDigest := "sha256:" + sha256Hex(sig)
+
+	layers := fmt.Sprintf(`{"digest":%q,"annotations":{"org.opencontainers.image.title":"prysm-plugin-bar.minisig"}},{"digest":%q,"annotations":{"org.opencontainers.image.title":"prysm-plugin-bar"}}`, sigDigest, digest)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/acme/prysm-plugin-bar/manifests/signed", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"mediaType":%q,"layers":[%s]}`, mediaTypeOCIManifest, layers)
+	})
+	mux.HandleFunc("/v2/acme/prysm-plugin-bar/manifests/unsigned", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"mediaType":%q,"layers":[{"digest":%q}]}`, mediaTypeOCIManifest, digest)
+	})
+	mux.HandleFunc("/v2/acme/prysm-plugin-bar/blobs/"+digest, func(w http.ResponseWriter, r *http.Request) { w.Write(binary) })
+	mux.HandleFunc("/v2/acme/prysm-plugin-bar/blobs/"+sigDigest, func(w http.ResponseWriter, r *http.Request) { w.Write(sig) })
+	srv := httptest.NewTLSServer(mux)
+	defer srv.Close()
+
+	inst := newTestInstaller(t, srv)
+	inst.Signatures = SignaturePolicy{TrustedKeys: []string{signer.publicKey()}}
+	host := strings.TrimPrefix(srv.URL, "https://")
+
+	rec, err := inst.Install(context.Background(), "oci://"+host+"/acme/prysm-plugin-bar:signed", InstallOptions{})
+	if err != nil {
+		t.Fatalf("Install signed: %v", err)
+	}
+	if err := inst.Signatures.Verify(rec.Path); err != nil {
+		t.Errorf("installed plugin does not verify: %v", err)
+	}
+	if _, err := inst.Install(context.Background(), "oci://"+host+"/acme/prysm-plugin-bar:unsigned", InstallOptions{Force: true}); err == nil || !strings.Contains(err.Error(), "not signed") {
+		t.Errorf("unsigned install err = %v, want not signed", err)
+	}
+}
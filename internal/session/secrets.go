@@ -0,0 +1,143 @@
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// SecretsFile is the default file name for the secret store under $PRYSM_HOME.
+const SecretsFile = "secrets.json"
+
+// SecretStore keeps small secrets (tokens, cloud credentials) encrypted at rest
+// with the same AES-GCM scheme as the session file. Values are grouped by
+// namespace so each plugin only sees its own entries.
+type SecretStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewSecretStore creates a secret store writing to the provided path. The
+// encryption key lives next to it in path + ".key".
+func NewSecretStore(path string) *SecretStore {
+	return &SecretStore{path: path}
+}
+
+// Get returns the secret stored under namespace/key and whether it exists.
+func (s *SecretStore) Get(namespace, key string) (string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	secrets, err := s.load()
+	if err != nil {
+		return "", false, err
+	}
+	enc, ok := secrets[namespace][key]
+	if !ok {
+		return "", false, nil
+	}
+	k, err := s.keyStore().loadKey()
+	if err != nil {
+		return "", false, fmt.Errorf("load secret encryption key: %w", err)
+	}
+	plain, err := decryptString(k, enc)
+	if err != nil {
+		return "", false, fmt.Errorf("decrypt secret %q: %w", key, err)
+	}
+	return plain, true, nil
+}
+
+// Set stores value under namespace/key. An empty value deletes the entry.
+func (s *SecretStore) Set(namespace, key, value string) error {
+	if key == "" {
+		return errors.New("secret key is required")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	secrets, err := s.load()
+	if err != nil {
+		return err
+	}
+	if value == "" {
+		if _, ok := secrets[namespace][key]; !ok {
+			return nil
+		}
+		delete(secrets[namespace], key)
+		if len(secrets[namespace]) == 0 {
+			delete(secrets, namespace)
+		}
+		return s.save(secrets)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("ensure secrets directory: %w", err)
+	}
+	k, err := s.keyStore().getOrCreateKey()
+	if err != nil {
+		return fmt.Errorf("get secret encryption key: %w", err)
+	}
+	enc, err := encryptString(k, value)
+	if err != nil {
+		return fmt.Errorf("encrypt secret %q: %w", key, err)
+	}
+	if secrets[namespace] == nil {
+		secrets[namespace] = make(map[string]string)
+	}
+	secrets[namespace][key] = enc
+	return s.save(secrets)
+}
+
+// DeleteNamespace removes every secret in namespace.
+func (s *SecretStore) DeleteNamespace(namespace string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	secrets, err := s.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := secrets[namespace]; !ok {
+		return nil
+	}
+	delete(secrets, namespace)
+	return s.save(secrets)
+}
+
+// keyStore reuses Store's key handling; the key file is path + ".key".
+func (s *SecretStore) keyStore() *Store {
+	return &Store{path: s.path}
+}
+
+func (s *SecretStore) load() (map[string]map[string]string, error) {
+	secrets := make(map[string]map[string]string)
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return secrets, nil
+		}
+		return nil, fmt.Errorf("open secrets file: %w", err)
+	}
+	if err := json.Unmarshal(data, &secrets); err != nil {
+		return nil, fmt.Errorf("decode secrets: %w", err)
+	}
+	return secrets, nil
+}
+
+func (s *SecretStore) save(secrets map[string]map[string]string) error {
+	data, err := json.MarshalIndent(secrets, "", "  ")
+	if err != nil {
+		return err
+	}
+	tempFile := s.path + ".tmp"
+	if err := os.WriteFile(tempFile, data, 0o600); err != nil {
+		return fmt.Errorf("write secrets: %w", err)
+	}
+	if err := os.Rename(tempFile, s.path); err != nil {
+		return fmt.Errorf("atomically replace secrets file: %w", err)
+	}
+	return nil
+}
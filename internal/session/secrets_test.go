@@ -0,0 +1,72 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSecretStoreSetGet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secrets.json")
+	store := NewSecretStore(path)
+
+	if _, ok, err := store.Get("plugin:onboard", "agent-token"); err != nil || ok {
+		t.Fatalf("Get on empty store = %v, %v", ok, err)
+	}
+	if err := store.Set("plugin:onboard", "agent-token", "s3cret"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	got, ok, err := store.Get("plugin:onboard", "agent-token")
+	if err != nil || !ok || got != "s3cret" {
+		t.Fatalf("Get = %q, %v, %v", got, ok, err)
+	}
+	if _, ok, _ := store.Get("plugin:other", "agent-token"); ok {
+		t.Error("secret leaked across namespaces")
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(raw), "s3cret") {
+		t.Error("secret stored in plaintext")
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("secrets file mode = %v, %v", info.Mode().Perm(), err)
+	}
+}
+
+func TestSecretStoreDelete(t *testing.T) {
+	store := NewSecretStore(filepath.Join(t.TempDir(), "secrets.json"))
+	if err := store.Set("plugin:a", "k1", "v1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Set("plugin:a", "k2", "v2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Set("plugin:b", "k1", "v1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Set("plugin:a", "k1", ""); err != nil {
+		t.Fatalf("Set empty: %v", err)
+	}
+	if _, ok, _ := store.Get("plugin:a", "k1"); ok {
+		t.Error("empty Set did not delete the secret")
+	}
+	if _, ok, _ := store.Get("plugin:a", "k2"); !ok {
+		t.Error("unrelated secret deleted")
+	}
+
+	if err := store.DeleteNamespace("plugin:a"); err != nil {
+		t.Fatalf("DeleteNamespace: %v", err)
+	}
+	if _, ok, _ := store.Get("plugin:a", "k2"); ok {
+		t.Error("DeleteNamespace left secrets behind")
+	}
+	if _, ok, _ := store.Get("plugin:b", "k1"); !ok {
+		t.Error("DeleteNamespace removed another namespace")
+	}
+}
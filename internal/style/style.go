@@ -6,6 +6,7 @@ import (
 	"os"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 )
 
 // Style is a Lipgloss style (exported so callers can store style references).
@@ -109,6 +110,12 @@ func Renderer(w io.Writer) *lipgloss.Renderer {
 	return lipgloss.NewRenderer(w)
 }
 
+// DisableColor turns off colors and text attributes for all styles rendered to
+// stdout (used for --no-color and NO_COLOR).
+func DisableColor() {
+	lipgloss.DefaultRenderer().SetColorProfile(termenv.Ascii)
+}
+
 // RenderVersion returns a styled version string: "prysm version 1.2.3".
 func RenderVersion(name, version string) string {
 	s := Title.Render(name) + MutedStyle.Render("version "+version)
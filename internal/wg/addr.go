@@ -0,0 +1,111 @@
+package wg
+
+import (
+	"fmt"
+	"log"
+	"net/netip"
+	"strings"
+)
+
+// addressToCIDR turns an overlay or allowed-IP address into a prefix: bare
+// IPv4 addresses become /32 and bare IPv6 addresses /128. Prefixes are
+// returned in canonical form.
+func addressToCIDR(addr string) (string, error) {
+	addr = strings.TrimSpace(addr)
+	if strings.Contains(addr, "/") {
+		p, err := netip.ParsePrefix(addr)
+		if err != nil {
+			return "", fmt.Errorf("invalid prefix %q: %w", addr, err)
+		}
+		return p.Masked().String(), nil
+	}
+	a, err := netip.ParseAddr(addr)
+	if err != nil {
+		return "", fmt.Errorf("invalid address %q: %w", addr, err)
+	}
+	a = a.Unmap()
+	return netip.PrefixFrom(a, a.BitLen()).String(), nil
+}
+
+// parseOverlayAddrs parses the device address assigned by the control
+// plane: one address, or a comma-separated IPv4/IPv6 pair on dual-stack
+// meshes, each optionally with a prefix length. The interface is always
+// assigned host addresses; peers are reached through their allowed IPs.
+func parseOverlayAddrs(s string) ([]netip.Addr, error) {
+	var addrs []netip.Addr
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		host, _, _ := strings.Cut(part, "/")
+		a, err := netip.ParseAddr(host)
+		if err != nil {
+			return nil, fmt.Errorf("invalid overlay address %q: %w", part, err)
+		}
+		addrs = append(addrs, a.Unmap())
+	}
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("empty overlay address")
+	}
+	// IPv4 first, so OverlayIP stays the familiar address on dual-stack meshes.
+	for i, a := range addrs {
+		if a.Is4() && i > 0 {
+			addrs[0], addrs[i] = addrs[i], addrs[0]
+			break
+		}
+	}
+	return addrs, nil
+}
+
+// normalizeAllowedIPs converts the control plane's allowed IPs into
+// canonical prefixes, skipping (and reporting) entries that do not parse.
+func normalizeAllowedIPs(ips []string) ([]string, []error) {
+	var (
+		out  = make([]string, 0, len(ips))
+		errs []error
+	)
+	for _, ip := range ips {
+		cidr, err := addressToCIDR(ip)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		out = append(out, cidr)
+	}
+	return out, errs
+}
+
+// peerConfigFromWG builds the tunnel configuration for a control plane
+// peer. Invalid allowed IPs are logged and dropped rather than failing the
+// whole peer.
+func peerConfigFromWG(p WGPeer) PeerConfig {
+	allowed, errs := normalizeAllowedIPs(p.AllowedIPs)
+	for _, err := range errs {
+		log.Printf("wireguard: peer %s: %v", truncateKey(p.PublicKey), err)
+	}
+	return PeerConfig{
+		PublicKey:  p.PublicKey,
+		Endpoint:   p.Endpoint,
+		AllowedIPs: allowed,
+	}
+}
+
+// PeerOverlayIP returns the peer's overlay address: the first allowed IP
+// that is a single host (/32 or /128).
+func PeerOverlayIP(p PeerConfig) string {
+	for _, cidr := range p.AllowedIPs {
+		pfx, err := netip.ParsePrefix(cidr)
+		if err == nil && pfx.IsSingleIP() {
+			return pfx.Addr().String()
+		}
+	}
+	return ""
+}
+
+// isIPv6 reports whether an address or prefix is IPv6.
+func isIPv6(addrOrCIDR string) bool {
+	host, _, _ := strings.Cut(addrOrCIDR, "/")
+	a, err := netip.ParseAddr(host)
+	return err == nil && a.Unmap().Is6()
+}
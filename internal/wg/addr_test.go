@@ -0,0 +1,105 @@
+package wg
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAddressToCIDR(t *testing.T) {
+	tests := []struct {
+		in, want string
+		wantErr  bool
+	}{
+		{in: "100.96.0.7", want: "100.96.0.7/32"},
+		{in: "fd7a:115c:a1e0::7", want: "fd7a:115c:a1e0::7/128"},
+		{in: "::ffff:100.96.0.7", want: "100.96.0.7/32"},
+		{in: "10.1.2.3/16", want: "10.1.0.0/16"},
+		{in: "fd7a:115c:a1e0::1/48", want: "fd7a:115c:a1e0::/48"},
+		{in: " 100.96.0.8/32 ", want: "100.96.0.8/32"},
+		{in: "not-an-ip", wantErr: true},
+		{in: "fd7a::/129", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := addressToCIDR(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("addressToCIDR(%q) = %q, %v; want %q (error %v)", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestParseOverlayAddrs(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    []string
+		wantErr bool
+	}{
+		{in: "100.96.0.7", want: []string{"100.96.0.7"}},
+		{in: "100.96.0.7/32", want: []string{"100.96.0.7"}},
+		{in: "fd7a::7/128", want: []string{"fd7a::7"}},
+		{in: "fd7a::7/128, 100.96.0.7/32", want: []string{"100.96.0.7", "fd7a::7"}},
+		{in: "100.96.0.7,fd7a::7", want: []string{"100.96.0.7", "fd7a::7"}},
+		{in: "", wantErr: true},
+		{in: "100.96.0.7,bogus", wantErr: true},
+	}
+	for _, tt := range tests {
+		addrs, err := parseOverlayAddrs(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseOverlayAddrs(%q) error = %v, want error %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		var got []string
+		for _, a := range addrs {
+			got = append(got, a.String())
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseOverlayAddrs(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestPeerConfigFromWG(t *testing.T) {
+	pc := peerConfigFromWG(WGPeer{
+		PublicKey:  "key",
+		Endpoint:   "[fd00::1]:51820",
+		AllowedIPs: []string{"fd7a::5", "100.96.0.5", "bogus", "10.2.0.0/16"},
+	})
+	want := []string{"fd7a::5/128", "100.96.0.5/32", "10.2.0.0/16"}
+	if !reflect.DeepEqual(pc.AllowedIPs, want) {
+		t.Errorf("AllowedIPs = %v, want %v", pc.AllowedIPs, want)
+	}
+	if pc.Endpoint != "[fd00::1]:51820" {
+		t.Errorf("Endpoint = %q", pc.Endpoint)
+	}
+	if got := PeerOverlayIP(pc); got != "fd7a::5" {
+		t.Errorf("PeerOverlayIP = %q, want fd7a::5", got)
+	}
+	if got := PeerOverlayIP(PeerConfig{AllowedIPs: []string{"10.2.0.0/16"}}); got != "" {
+		t.Errorf("PeerOverlayIP without host route = %q, want empty", got)
+	}
+}
+
+func TestResolveEndpointIPv6Literal(t *testing.T) {
+	for _, ep := range []string{"[fd00::1]:51820", "[fe80::1%eth0]:51820", "192.0.2.1:51820"} {
+		got, err := resolveEndpoint(ep)
+		if err != nil || got != ep {
+			t.Errorf("resolveEndpoint(%q) = %q, %v; want it unchanged", ep, got, err)
+		}
+	}
+	if _, err := resolveEndpoint("fd00::1:51820"); err == nil {
+		t.Error("unbracketed IPv6 endpoint should be rejected")
+	}
+}
+
+func TestIsIPv6(t *testing.T) {
+	for in, want := range map[string]bool{
+		"fd7a::/48":       true,
+		"fd7a::1":         true,
+		"10.0.0.0/8":      false,
+		"::ffff:10.0.0.1": false,
+		"not-an-address":  false,
+	} {
+		if got := isIPv6(in); got != want {
+			t.Errorf("isIPv6(%q) = %v, want %v", in, got, want)
+		}
+	}
+}
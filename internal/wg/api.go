@@ -34,6 +34,7 @@ type WGPeer struct {
 	DERPRegion      string   `json:"derp_region,omitempty"`
 	MLKEMPublicKey  string   `json:"mlkem_public_key,omitempty"`  // peer's ML-KEM-768 encapsulation key (base64)
 	MLKEMCiphertext string   `json:"mlkem_ciphertext,omitempty"` // ciphertext from encapsulator→us (base64)
+	PresharedKey    string   `json:"preshared_key,omitempty"`    // configured pairwise PSK (base64), see SubmitPresharedKeys
 }
 
 // WGConfig is the WireGuard configuration returned by the control plane.
@@ -130,16 +131,16 @@ func SetupMeshWireGuard(ctx context.Context, apiClient *api.Client, homeDir, dev
 	}
 
 	tun := NewTunnel(privKey, overlayAddr, 0)
+	tun.meshCIDR = cfg.Config.CIDR
 
+	static := syncPresharedKeys(homeDir, cfg.Peers)
 	for _, p := range cfg.Peers {
-		pc := PeerConfig{
-			PublicKey:  p.PublicKey,
-			Endpoint:   p.Endpoint,
-			AllowedIPs: p.AllowedIPs,
-		}
-		if dk != nil && p.MLKEMPublicKey != "" {
-			pc.PresharedKey = resolvePSK(ctx, apiClient, dk, deviceID, pubKey, p)
+		pc := peerConfigFromWG(p)
+		var pq string
+		if dk != nil && p.MLKEMPublicKey != "" && static[p.PublicKey] == "" {
+			pq = resolvePSK(ctx, apiClient, dk, deviceID, pubKey, p)
 		}
+		pc.PresharedKey = peerPSK(p.PublicKey, static[p.PublicKey], pq)
 		tun.peers = append(tun.peers, pc)
 	}
 
@@ -156,6 +157,17 @@ func SetupMeshWireGuard(ctx context.Context, apiClient *api.Client, homeDir, dev
 // Returns the Tunnel and the DERPBind (caller must wire DERPBind.DeliverPacket
 // to the DERP client's WGPacketHandler).
 func SetupMeshWireGuardDERP(ctx context.Context, apiClient *api.Client, homeDir, deviceID string, sender DERPSender) (*Tunnel, *DERPBind, error) {
+	return setupMeshWireGuardDERP(ctx, apiClient, homeDir, deviceID, sender, (*Tunnel).StartWithDERPBind)
+}
+
+// SetupMeshWireGuardNetstack is like SetupMeshWireGuardDERP but runs the
+// tunnel on a user-space network stack, so it works without root or a TUN
+// device. Reach peers through the returned Tunnel's DialContext.
+func SetupMeshWireGuardNetstack(ctx context.Context, apiClient *api.Client, homeDir, deviceID string, sender DERPSender) (*Tunnel, *DERPBind, error) {
+	return setupMeshWireGuardDERP(ctx, apiClient, homeDir, deviceID, sender, (*Tunnel).StartNetstackWithDERPBind)
+}
+
+func setupMeshWireGuardDERP(ctx context.Context, apiClient *api.Client, homeDir, deviceID string, sender DERPSender, start func(*Tunnel, *DERPBind) error) (*Tunnel, *DERPBind, error) {
 	privKey, pubKey, err := EnsureKeyPair(homeDir)
 	if err != nil {
 		return nil, nil, fmt.Errorf("ensure wireguard keypair: %w", err)
@@ -187,21 +199,21 @@ func SetupMeshWireGuardDERP(ctx context.Context, apiClient *api.Client, homeDir,
 
 	bind := NewDERPBind(sender)
 	tun := NewTunnel(privKey, overlayAddr, 0)
+	tun.meshCIDR = cfg.Config.CIDR
 
+	static := syncPresharedKeys(homeDir, cfg.Peers)
 	for _, p := range cfg.Peers {
 		fmt.Fprintf(os.Stderr, "wireguard: adding peer %s endpoint=%s allowed=%v\n", p.PublicKey[:8], p.Endpoint, p.AllowedIPs)
-		pc := PeerConfig{
-			PublicKey:  p.PublicKey,
-			Endpoint:   p.Endpoint,
-			AllowedIPs: p.AllowedIPs,
-		}
-		if dk != nil && p.MLKEMPublicKey != "" {
-			pc.PresharedKey = resolvePSK(ctx, apiClient, dk, deviceID, pubKey, p)
+		pc := peerConfigFromWG(p)
+		var pq string
+		if dk != nil && p.MLKEMPublicKey != "" && static[p.PublicKey] == "" {
+			pq = resolvePSK(ctx, apiClient, dk, deviceID, pubKey, p)
 		}
+		pc.PresharedKey = peerPSK(p.PublicKey, static[p.PublicKey], pq)
 		tun.peers = append(tun.peers, pc)
 	}
 
-	if err := tun.StartWithDERPBind(bind); err != nil {
+	if err := start(tun, bind); err != nil {
 		bind.Close()
 		return nil, nil, fmt.Errorf("start wireguard tunnel: %w", err)
 	}
@@ -0,0 +1,241 @@
+package wg
+
+import (
+	"fmt"
+	"log"
+)
+
+// exitRoutes cover all of IPv4 and IPv6 without replacing the default
+// routes, so the original defaults stay in place for bypass routes and take
+// over again when the tunnel interface goes away. IPv6 is routed into the
+// tunnel even though exit nodes only forward IPv4: dropping it there is what
+// keeps it from leaking around the exit node.
+var exitRoutes = []string{"0.0.0.0/1", "128.0.0.0/1", "::/1", "8000::/1"}
+
+// exitDefaultRoutes are added to the exit peer's allowed IPs.
+var exitDefaultRoutes = []string{"0.0.0.0/0", "::/0"}
+
+// activeExitRoutes returns exitRoutes, minus the IPv6 ones on hosts without
+// IPv6, where there is nothing to leak and the routes cannot be added.
+func activeExitRoutes() []string {
+	if ipv6Enabled() {
+		return exitRoutes
+	}
+	var out []string
+	for _, cidr := range exitRoutes {
+		if !isIPv6(cidr) {
+			out = append(out, cidr)
+		}
+	}
+	return out
+}
+
+// defaultMeshCIDR is assumed to hold the overlay network when the control
+// plane does not say.
+const defaultMeshCIDR = "100.64.0.0/10"
+
+// exitMeshCIDR returns the overlay network, which exit-node forwarding
+// leaves alone.
+func (t *Tunnel) exitMeshCIDR() string {
+	if t.meshCIDR != "" {
+		return t.meshCIDR
+	}
+	return defaultMeshCIDR
+}
+
+// allowedIPs returns the allowed IPs to configure for p: its own, any
+// accepted subnet routes it advertises, and the default routes when p is the
+// exit node.
+func (t *Tunnel) allowedIPs(p PeerConfig) []string {
+	subnets := t.subnets[p.PublicKey]
+	isExit := t.exitPeer != "" && p.PublicKey == t.exitPeer
+	if len(subnets) == 0 && !isExit {
+		return p.AllowedIPs
+	}
+	ips := append(append([]string{}, p.AllowedIPs...), subnets...)
+	if isExit {
+		ips = append(ips, exitDefaultRoutes...)
+	}
+	return ips
+}
+
+// SetExitPeer routes all traffic through the peer with the given public
+// key. Add bypass routes for the relay and control plane first, or the tunnel
+// would route its own transport through itself.
+func (t *Tunnel) SetExitPeer(publicKey string) error {
+	if t.wgDevice == nil {
+		return fmt.Errorf("wireguard tunnel is not running")
+	}
+	if t.tnet != nil {
+		return fmt.Errorf("exit nodes need a TUN device and are not available in user-space mode")
+	}
+	t.peersMu.Lock()
+	defer t.peersMu.Unlock()
+
+	var peer, prev *PeerConfig
+	for i := range t.peers {
+		switch t.peers[i].PublicKey {
+		case publicKey:
+			peer = &t.peers[i]
+		case t.exitPeer:
+			prev = &t.peers[i]
+		}
+	}
+	if peer == nil {
+		return fmt.Errorf("peer %s is not configured", truncateKey(publicKey))
+	}
+
+	prevKey := t.exitPeer
+	t.exitPeer = publicKey
+	if prev != nil {
+		if err := t.configurePeer(*prev); err != nil {
+			log.Printf("wireguard: failed to reconfigure previous exit peer %s: %v", truncateKey(prev.PublicKey), err)
+		}
+	}
+	var added []string
+	err := t.configurePeer(*peer)
+	if err == nil {
+		for _, cidr := range activeExitRoutes() {
+			if err = addRoute(cidr, t.interfaceName); err != nil {
+				err = fmt.Errorf("route: %w", err)
+				break
+			}
+			added = append(added, cidr)
+		}
+	}
+	if err != nil {
+		t.rollbackExitPeerLocked(peer, prev, prevKey, added)
+		return err
+	}
+	return nil
+}
+
+// rollbackExitPeerLocked undoes a failed SetExitPeer: the previous exit peer,
+// if any, gets its default routes back and keeps the exit routes; otherwise
+// the routes added for the new peer are removed. peer loses the default
+// routes either way.
+func (t *Tunnel) rollbackExitPeerLocked(peer, prev *PeerConfig, prevKey string, added []string) {
+	t.exitPeer = prevKey
+	if err := t.configurePeer(*peer); err != nil {
+		log.Printf("wireguard: failed to restore peer %s: %v", truncateKey(peer.PublicKey), err)
+	}
+	if prev != nil {
+		if err := t.configurePeer(*prev); err != nil {
+			log.Printf("wireguard: failed to restore exit peer %s: %v", truncateKey(prev.PublicKey), err)
+		}
+		return
+	}
+	for _, cidr := range added {
+		if err := deleteRoute(cidr, t.interfaceName); err != nil {
+			log.Printf("wireguard: %v", err)
+		}
+	}
+}
+
+// ClearExitPeer stops routing all traffic through the exit peer.
+func (t *Tunnel) ClearExitPeer() error {
+	t.peersMu.Lock()
+	defer t.peersMu.Unlock()
+	if t.exitPeer == "" {
+		return nil
+	}
+	if t.wgDevice == nil {
+		t.exitPeer = ""
+		return nil
+	}
+	for _, cidr := range activeExitRoutes() {
+		if err := deleteRoute(cidr, t.interfaceName); err != nil {
+			log.Printf("wireguard: %v", err)
+		}
+	}
+	key := t.exitPeer
+	t.exitPeer = ""
+	for _, p := range t.peers {
+		if p.PublicKey == key {
+			return t.configurePeer(p)
+		}
+	}
+	return nil
+}
+
+// SetExitGateway makes this device an exit node: IPv4 traffic arriving from
+// mesh peers for the internet is forwarded and NATed out of the host's
+// uplink. Traffic for the mesh itself and for private ranges the device does
+// not advertise as subnet routes is not forwarded.
+func (t *Tunnel) SetExitGateway(enabled bool) error {
+	if t.wgDevice == nil {
+		return fmt.Errorf("wireguard tunnel is not running")
+	}
+	if t.tnet != nil && enabled {
+		return fmt.Errorf("acting as an exit node needs a TUN device and is not available in user-space mode")
+	}
+	t.peersMu.Lock()
+	defer t.peersMu.Unlock()
+	if enabled == (t.exitUplink != "") {
+		return nil
+	}
+	if !enabled {
+		uplink := t.exitUplink
+		t.exitUplink = ""
+		return deleteExitGateway(t.interfaceName, uplink, t.exitMeshCIDR())
+	}
+	uplink, err := addExitGateway(t.interfaceName, t.exitMeshCIDR())
+	if err != nil {
+		return err
+	}
+	t.exitUplink = uplink
+	return nil
+}
+
+// ExitGateway reports whether this device forwards traffic as an exit node.
+func (t *Tunnel) ExitGateway() bool {
+	t.peersMu.RLock()
+	defer t.peersMu.RUnlock()
+	return t.exitUplink != ""
+}
+
+// ExitPeer returns the public key of the exit peer, or "" if none is set.
+func (t *Tunnel) ExitPeer() string {
+	t.peersMu.RLock()
+	defer t.peersMu.RUnlock()
+	return t.exitPeer
+}
+
+// AddBypassRoutes routes each CIDR through the current default gateway so it
+// keeps working while an exit node is in use, and returns the routes added.
+// IPv6 CIDRs the host has no route for are skipped: they are not reachable
+// around the tunnel anyway.
+func AddBypassRoutes(cidrs []string) ([]string, error) {
+	var added []string
+	for _, cidr := range cidrs {
+		if isIPv6(cidr) && !ipv6Enabled() {
+			continue
+		}
+		if err := addBypassRoute(cidr); err != nil {
+			if isIPv6(cidr) {
+				log.Printf("wireguard: skipping IPv6 bypass: %v", err)
+				continue
+			}
+			DeleteBypassRoutes(added)
+			return nil, err
+		}
+		added = append(added, cidr)
+	}
+	return added, nil
+}
+
+// DeleteBypassRoutes removes routes added by AddBypassRoutes.
+func DeleteBypassRoutes(cidrs []string) {
+	for _, cidr := range cidrs {
+		if err := deleteBypassRoute(cidr); err != nil {
+			log.Printf("wireguard: %v", err)
+		}
+	}
+}
+
+// SetKillSwitch installs (or removes) lower-priority blackhole routes for
+// exitRoutes, so traffic is dropped instead of leaking through the default
+// route while the tunnel is down.
+func SetKillSwitch(on bool) error {
+	return setKillSwitch(on)
+}
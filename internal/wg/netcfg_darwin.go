@@ -2,13 +2,20 @@ package wg
 
 import (
 	"fmt"
+	"net/netip"
 	"os/exec"
 	"strings"
 )
 
-func configureInterface(ifaceName, overlayIP string) error {
-	if out, err := exec.Command("ifconfig", ifaceName, "inet", overlayIP+"/32", overlayIP).CombinedOutput(); err != nil {
-		return fmt.Errorf("ifconfig inet: %s: %w", strings.TrimSpace(string(out)), err)
+func configureInterface(ifaceName string, addrs []netip.Addr) error {
+	for _, a := range addrs {
+		args := []string{ifaceName, "inet", a.String() + "/32", a.String()}
+		if a.Is6() {
+			args = []string{ifaceName, "inet6", a.String(), "prefixlen", "128"}
+		}
+		if out, err := exec.Command("ifconfig", args...).CombinedOutput(); err != nil {
+			return fmt.Errorf("ifconfig %s %s: %s: %w", args[1], a, strings.TrimSpace(string(out)), err)
+		}
 	}
 	if out, err := exec.Command("ifconfig", ifaceName, "up").CombinedOutput(); err != nil {
 		return fmt.Errorf("ifconfig up: %s: %w", strings.TrimSpace(string(out)), err)
@@ -17,9 +24,85 @@ func configureInterface(ifaceName, overlayIP string) error {
 }
 
 func addRoute(cidr, ifaceName string) error {
-	out, err := exec.Command("route", "-n", "add", "-net", cidr, "-interface", ifaceName).CombinedOutput()
+	out, err := exec.Command("route", "-n", "add", routeFamily(cidr), "-net", cidr, "-interface", ifaceName).CombinedOutput()
 	if err != nil {
 		return fmt.Errorf("route add %s: %s: %w", cidr, strings.TrimSpace(string(out)), err)
 	}
 	return nil
 }
+
+func deleteRoute(cidr, ifaceName string) error {
+	out, err := exec.Command("route", "-n", "delete", routeFamily(cidr), "-net", cidr, "-interface", ifaceName).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("route delete %s: %s: %w", cidr, strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
+
+// addBypassRoute pins a host route to the current default gateway before
+// exit-node routes take over.
+func addBypassRoute(cidr string) error {
+	ip, _, _ := strings.Cut(cidr, "/")
+	out, err := exec.Command("route", "-n", "get", routeFamily(ip), "default").CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("route get default: %s: %w", strings.TrimSpace(string(out)), err)
+	}
+	var gateway string
+	for _, line := range strings.Split(string(out), "\n") {
+		if k, v, ok := strings.Cut(strings.TrimSpace(line), ":"); ok && k == "gateway" {
+			gateway = strings.TrimSpace(v)
+		}
+	}
+	if gateway == "" {
+		return fmt.Errorf("route get default: no gateway")
+	}
+	if out, err := exec.Command("route", "-n", "add", routeFamily(ip), "-host", ip, gateway).CombinedOutput(); err != nil {
+		return fmt.Errorf("route add %s: %s: %w", ip, strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
+
+func deleteBypassRoute(cidr string) error {
+	ip, _, _ := strings.Cut(cidr, "/")
+	out, err := exec.Command("route", "-n", "delete", routeFamily(ip), "-host", ip).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("route delete %s: %s: %w", ip, strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
+
+func ipv6Enabled() bool {
+	return true
+}
+
+func setKillSwitch(on bool) error {
+	if on {
+		return fmt.Errorf("the exit-node kill switch is not supported on macOS yet")
+	}
+	return nil
+}
+
+func addSubnetGateway(cidr, ifaceName string) error {
+	return fmt.Errorf("advertising subnet routes is only supported on Linux")
+}
+
+func deleteSubnetGateway(cidr, ifaceName string) error {
+	return nil
+}
+
+func addExitGateway(ifaceName, meshCIDR string) (string, error) {
+	return "", fmt.Errorf("acting as an exit node is only supported on Linux")
+}
+
+func deleteExitGateway(ifaceName, uplink, meshCIDR string) error {
+	return nil
+}
+
+// routeFamily returns the route(8) address family flag for an address or
+// prefix.
+func routeFamily(addrOrCIDR string) string {
+	if isIPv6(addrOrCIDR) {
+		return "-inet6"
+	}
+	return "-inet"
+}
@@ -2,13 +2,18 @@ package wg
 
 import (
 	"fmt"
+	"net/netip"
+	"os"
 	"os/exec"
 	"strings"
 )
 
-func configureInterface(ifaceName, overlayIP string) error {
-	if out, err := exec.Command("ip", "addr", "add", overlayIP+"/32", "dev", ifaceName).CombinedOutput(); err != nil {
-		return fmt.Errorf("ip addr add: %s: %w", strings.TrimSpace(string(out)), err)
+func configureInterface(ifaceName string, addrs []netip.Addr) error {
+	for _, a := range addrs {
+		cidr := netip.PrefixFrom(a, a.BitLen()).String()
+		if out, err := exec.Command("ip", "addr", "add", cidr, "dev", ifaceName).CombinedOutput(); err != nil {
+			return fmt.Errorf("ip addr add %s: %s: %w", cidr, strings.TrimSpace(string(out)), err)
+		}
 	}
 	if out, err := exec.Command("ip", "link", "set", ifaceName, "up").CombinedOutput(); err != nil {
 		return fmt.Errorf("ip link set up: %s: %w", strings.TrimSpace(string(out)), err)
@@ -27,3 +32,211 @@ func addRoute(cidr, ifaceName string) error {
 	}
 	return nil
 }
+
+func deleteRoute(cidr, ifaceName string) error {
+	out, err := exec.Command("ip", "route", "del", cidr, "dev", ifaceName).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("ip route del %s: %s: %w", cidr, strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
+
+// addBypassRoute routes cidr the way the kernel routes it now, pinning it to
+// the current gateway before exit-node routes take over.
+func addBypassRoute(cidr string) error {
+	ip, _, _ := strings.Cut(cidr, "/")
+	out, err := exec.Command("ip", ipFamily(ip), "route", "get", ip).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("ip route get %s: %s: %w", ip, strings.TrimSpace(string(out)), err)
+	}
+	via, dev := parseRouteGet(string(out))
+	if dev == "" {
+		return fmt.Errorf("ip route get %s: no output device in %q", ip, strings.TrimSpace(string(out)))
+	}
+	args := []string{"route", "replace", cidr}
+	if via != "" {
+		args = append(args, "via", via)
+	}
+	args = append(args, "dev", dev)
+	if out, err := exec.Command("ip", args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("ip route add %s: %s: %w", cidr, strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
+
+func deleteBypassRoute(cidr string) error {
+	out, err := exec.Command("ip", "route", "del", cidr).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("ip route del %s: %s: %w", cidr, strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
+
+// killSwitchMetric ranks the blackhole routes below the tunnel's routes, so
+// they only match while the tunnel interface is gone.
+const killSwitchMetric = "1000"
+
+func setKillSwitch(on bool) error {
+	for _, cidr := range activeExitRoutes() {
+		if on {
+			if out, err := exec.Command("ip", "route", "replace", "blackhole", cidr, "metric", killSwitchMetric).CombinedOutput(); err != nil {
+				return fmt.Errorf("ip route add blackhole %s: %s: %w", cidr, strings.TrimSpace(string(out)), err)
+			}
+			continue
+		}
+		if out, err := exec.Command("ip", "route", "del", "blackhole", cidr, "metric", killSwitchMetric).CombinedOutput(); err != nil {
+			return fmt.Errorf("ip route del blackhole %s: %s: %w", cidr, strings.TrimSpace(string(out)), err)
+		}
+	}
+	return nil
+}
+
+// parseRouteGet extracts the gateway and device from `ip route get` output,
+// e.g. "1.2.3.4 via 10.0.0.1 dev eth0 src 10.0.0.5 uid 0".
+func parseRouteGet(out string) (via, dev string) {
+	fields := strings.Fields(out)
+	for i := 0; i+1 < len(fields); i++ {
+		switch fields[i] {
+		case "via":
+			via = fields[i+1]
+		case "dev":
+			dev = fields[i+1]
+		}
+	}
+	return via, dev
+}
+
+// subnetGatewayMark tags packets that arrive on the tunnel for an advertised
+// subnet, so only mesh traffic is masqueraded on the way out.
+const subnetGatewayMark = "0x5052"
+
+// exitGatewayMark tags mesh traffic leaving through this device as an exit
+// node. It differs from subnetGatewayMark so each NAT rule only matches its
+// own traffic.
+const exitGatewayMark = "0x5045"
+
+// exitBlockedRanges are not reachable through an exit node, so peers using it
+// do not land on the host's LAN. Advertised subnets carry subnetGatewayMark
+// instead and are unaffected.
+var exitBlockedRanges = []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "169.254.0.0/16", "100.64.0.0/10"}
+
+type iptablesRule struct {
+	table, chain string
+	spec         []string
+}
+
+func subnetGatewayRules(cidr, ifaceName string) []iptablesRule {
+	return []iptablesRule{
+		{"mangle", "PREROUTING", []string{"-i", ifaceName, "-d", cidr, "-j", "MARK", "--set-mark", subnetGatewayMark}},
+		{"filter", "FORWARD", []string{"-i", ifaceName, "-d", cidr, "-j", "ACCEPT"}},
+		{"filter", "FORWARD", []string{"-o", ifaceName, "-s", cidr, "-m", "conntrack", "--ctstate", "RELATED,ESTABLISHED", "-j", "ACCEPT"}},
+		{"nat", "POSTROUTING", []string{"-m", "mark", "--mark", subnetGatewayMark, "-d", cidr, "-j", "MASQUERADE"}},
+	}
+}
+
+// exitGatewayRules forwards traffic from mesh peers out of uplink, except
+// traffic for the mesh itself and for private ranges. The drop rules come
+// before the accept rule.
+func exitGatewayRules(ifaceName, uplink, meshCIDR string) []iptablesRule {
+	rules := []iptablesRule{
+		{"mangle", "PREROUTING", []string{"-i", ifaceName, "!", "-d", meshCIDR, "-m", "mark", "!", "--mark", subnetGatewayMark, "-j", "MARK", "--set-mark", exitGatewayMark}},
+	}
+	for _, cidr := range exitBlockedRanges {
+		rules = append(rules, iptablesRule{"filter", "FORWARD", []string{"-i", ifaceName, "-d", cidr, "-m", "mark", "--mark", exitGatewayMark, "-j", "DROP"}})
+	}
+	return append(rules,
+		iptablesRule{"filter", "FORWARD", []string{"-i", ifaceName, "-o", uplink, "-m", "mark", "--mark", exitGatewayMark, "-j", "ACCEPT"}},
+		iptablesRule{"filter", "FORWARD", []string{"-i", uplink, "-o", ifaceName, "-m", "conntrack", "--ctstate", "RELATED,ESTABLISHED", "-j", "ACCEPT"}},
+		iptablesRule{"nat", "POSTROUTING", []string{"-o", uplink, "-m", "mark", "--mark", exitGatewayMark, "-j", "MASQUERADE"}},
+	)
+}
+
+func (r iptablesRule) args(op string) []string {
+	return append([]string{"-t", r.table, op, r.chain}, r.spec...)
+}
+
+// insertRules inserts rules at the top of their chains, keeping their order.
+// Rules already present are left alone.
+func insertRules(rules []iptablesRule) error {
+	for i := len(rules) - 1; i >= 0; i-- {
+		r := rules[i]
+		if exec.Command("iptables", r.args("-C")...).Run() == nil {
+			continue
+		}
+		if out, err := exec.Command("iptables", r.args("-I")...).CombinedOutput(); err != nil {
+			return fmt.Errorf("iptables -t %s -I %s: %s: %w", r.table, r.chain, strings.TrimSpace(string(out)), err)
+		}
+	}
+	return nil
+}
+
+func deleteRules(rules []iptablesRule) error {
+	var firstErr error
+	for _, r := range rules {
+		if out, err := exec.Command("iptables", r.args("-D")...).CombinedOutput(); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("iptables -t %s -D %s: %s: %w", r.table, r.chain, strings.TrimSpace(string(out)), err)
+		}
+	}
+	return firstErr
+}
+
+func enableIPForwarding() error {
+	if err := os.WriteFile("/proc/sys/net/ipv4/ip_forward", []byte("1\n"), 0o644); err != nil {
+		return fmt.Errorf("enable ip forwarding: %w", err)
+	}
+	return nil
+}
+
+// addSubnetGateway forwards mesh traffic from ifaceName to cidr, NATed to
+// this host's address on that network so replies need no return route.
+func addSubnetGateway(cidr, ifaceName string) error {
+	if err := enableIPForwarding(); err != nil {
+		return err
+	}
+	return insertRules(subnetGatewayRules(cidr, ifaceName))
+}
+
+// deleteSubnetGateway removes the rules added by addSubnetGateway. IP
+// forwarding is left on; other software on the host may rely on it.
+func deleteSubnetGateway(cidr, ifaceName string) error {
+	return deleteRules(subnetGatewayRules(cidr, ifaceName))
+}
+
+// addExitGateway forwards mesh traffic from ifaceName to the internet through
+// the host's default uplink, which it returns.
+func addExitGateway(ifaceName, meshCIDR string) (string, error) {
+	out, err := exec.Command("ip", "-4", "route", "get", "1.1.1.1").CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("find uplink: ip route get: %s: %w", strings.TrimSpace(string(out)), err)
+	}
+	_, uplink := parseRouteGet(string(out))
+	if uplink == "" || uplink == ifaceName {
+		return "", fmt.Errorf("find uplink: no default route outside the tunnel")
+	}
+	if err := enableIPForwarding(); err != nil {
+		return "", err
+	}
+	if err := insertRules(exitGatewayRules(ifaceName, uplink, meshCIDR)); err != nil {
+		return "", err
+	}
+	return uplink, nil
+}
+
+// deleteExitGateway removes the rules added by addExitGateway.
+func deleteExitGateway(ifaceName, uplink, meshCIDR string) error {
+	return deleteRules(exitGatewayRules(ifaceName, uplink, meshCIDR))
+}
+
+// ipv6Enabled reports whether the kernel has IPv6 enabled.
+func ipv6Enabled() bool {
+	_, err := os.Stat("/proc/net/if_inet6")
+	return err == nil
+}
+
+// ipFamily returns the ip(8) address family flag for an address or prefix.
+func ipFamily(addrOrCIDR string) string {
+	if isIPv6(addrOrCIDR) {
+		return "-6"
+	}
+	return "-4"
+}
@@ -0,0 +1,69 @@
+package wg
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseRouteGet(t *testing.T) {
+	tests := []struct {
+		out, via, dev string
+	}{
+		{"104.18.2.3 via 192.168.1.1 dev wlan0 src 192.168.1.20 uid 0 \n    cache \n", "192.168.1.1", "wlan0"},
+		{"10.0.0.7 dev eth0 src 10.0.0.5 uid 1000 \n    cache \n", "", "eth0"},
+		{"", "", ""},
+	}
+	for _, tt := range tests {
+		via, dev := parseRouteGet(tt.out)
+		if via != tt.via || dev != tt.dev {
+			t.Errorf("parseRouteGet(%q) = %q, %q; want %q, %q", tt.out, via, dev, tt.via, tt.dev)
+		}
+	}
+}
+
+func TestSubnetGatewayRules(t *testing.T) {
+	rules := subnetGatewayRules("10.1.0.0/16", "prysm0")
+	if len(rules) != 4 {
+		t.Fatalf("got %d rules, want 4", len(rules))
+	}
+	got := strings.Join(rules[3].args("-I"), " ")
+	want := "-t nat -I POSTROUTING -m mark --mark " + subnetGatewayMark + " -d 10.1.0.0/16 -j MASQUERADE"
+	if got != want {
+		t.Errorf("nat rule = %q, want %q", got, want)
+	}
+	for _, r := range rules {
+		if !slices.Contains(r.spec, "10.1.0.0/16") {
+			t.Errorf("rule %s/%s does not match the subnet: %v", r.table, r.chain, r.spec)
+		}
+	}
+}
+
+func TestExitGatewayRules(t *testing.T) {
+	rules := exitGatewayRules("prysm0", "eth0", "100.96.0.0/16")
+	mark := strings.Join(rules[0].spec, " ")
+	if want := "-i prysm0 ! -d 100.96.0.0/16 -m mark ! --mark " + subnetGatewayMark + " -j MARK --set-mark " + exitGatewayMark; mark != want {
+		t.Errorf("mark rule = %q, want %q", mark, want)
+	}
+	if exitGatewayMark == subnetGatewayMark {
+		t.Error("exit and subnet gateways must use different marks")
+	}
+
+	accept := slices.IndexFunc(rules, func(r iptablesRule) bool {
+		return r.chain == "FORWARD" && slices.Contains(r.spec, "ACCEPT") && slices.Contains(r.spec, "-i") && r.spec[1] == "prysm0"
+	})
+	if accept < 0 || !slices.Contains(rules[accept].spec, "eth0") {
+		t.Fatalf("no forward rule limited to the uplink: %v", rules)
+	}
+	for _, cidr := range exitBlockedRanges {
+		drop := slices.IndexFunc(rules, func(r iptablesRule) bool { return slices.Contains(r.spec, cidr) && slices.Contains(r.spec, "DROP") })
+		if drop < 0 || drop > accept {
+			t.Errorf("%s is not dropped before the forward rule", cidr)
+		}
+	}
+
+	nat := strings.Join(rules[len(rules)-1].args("-I"), " ")
+	if want := "-t nat -I POSTROUTING -o eth0 -m mark --mark " + exitGatewayMark + " -j MASQUERADE"; nat != want {
+		t.Errorf("nat rule = %q, want %q", nat, want)
+	}
+}
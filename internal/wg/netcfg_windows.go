@@ -1,11 +1,53 @@
 package wg
 
-import "fmt"
+import (
+	"fmt"
+	"net/netip"
+)
 
-func configureInterface(ifaceName, overlayIP string) error {
+func configureInterface(ifaceName string, addrs []netip.Addr) error {
 	return fmt.Errorf("WireGuard interface configuration not supported on Windows")
 }
 
 func addRoute(cidr, ifaceName string) error {
 	return fmt.Errorf("route configuration not supported on Windows")
 }
+
+func deleteRoute(cidr, ifaceName string) error {
+	return fmt.Errorf("route configuration not supported on Windows")
+}
+
+func addBypassRoute(cidr string) error {
+	return fmt.Errorf("route configuration not supported on Windows")
+}
+
+func deleteBypassRoute(cidr string) error {
+	return fmt.Errorf("route configuration not supported on Windows")
+}
+
+func ipv6Enabled() bool {
+	return true
+}
+
+func setKillSwitch(on bool) error {
+	if on {
+		return fmt.Errorf("the exit-node kill switch is not supported on Windows")
+	}
+	return nil
+}
+
+func addSubnetGateway(cidr, ifaceName string) error {
+	return fmt.Errorf("advertising subnet routes is only supported on Linux")
+}
+
+func deleteSubnetGateway(cidr, ifaceName string) error {
+	return nil
+}
+
+func addExitGateway(ifaceName, meshCIDR string) (string, error) {
+	return "", fmt.Errorf("acting as an exit node is only supported on Linux")
+}
+
+func deleteExitGateway(ifaceName, uplink, meshCIDR string) error {
+	return nil
+}
@@ -0,0 +1,69 @@
+package wg
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+
+	"golang.zx2c4.com/wireguard/device"
+	"golang.zx2c4.com/wireguard/tun/netstack"
+)
+
+// netstackInterfaceName is reported by InterfaceName for user-space tunnels,
+// which have no OS interface.
+const netstackInterfaceName = "netstack"
+
+// StartNetstackWithDERPBind brings up WireGuard over DERP on a gVisor
+// user-space network stack instead of a TUN device. It needs no privileges:
+// nothing is added to the host's interfaces or routing table, so mesh
+// traffic only flows through connections made with DialContext.
+func (t *Tunnel) StartNetstackWithDERPBind(bind *DERPBind) error {
+	addrs, err := parseOverlayAddrs(t.overlayIP)
+	if err != nil {
+		return err
+	}
+	tunDev, tnet, err := netstack.CreateNetTUN(addrs, nil, device.DefaultMTU)
+	if err != nil {
+		return fmt.Errorf("create netstack device: %w", err)
+	}
+	t.tunDevice = tunDev
+	t.tnet = tnet
+	t.interfaceName = netstackInterfaceName
+
+	logger := device.NewLogger(device.LogLevelSilent, "")
+	wgDev := device.NewDevice(t.tunDevice, bind, logger)
+	t.wgDevice = wgDev
+	t.viaDERP = true
+
+	if err := wgDev.IpcSet(fmt.Sprintf("private_key=%s\n", hexKey(t.privateKey))); err != nil {
+		wgDev.Close()
+		return fmt.Errorf("configure wireguard device: %w", err)
+	}
+	if err := wgDev.Up(); err != nil {
+		wgDev.Close()
+		return fmt.Errorf("bring up wireguard device: %w", err)
+	}
+
+	for _, p := range t.peers {
+		if err := t.addPeerDERP(p); err != nil {
+			log.Printf("wireguard: failed to add peer %s: %v", truncateKey(p.PublicKey), err)
+		}
+	}
+	return nil
+}
+
+// Userspace reports whether the tunnel runs on a user-space network stack.
+func (t *Tunnel) Userspace() bool {
+	return t.tnet != nil
+}
+
+// DialContext opens a connection to a mesh address through the user-space
+// network stack. Only tunnels started with StartNetstackWithDERPBind support
+// it; TUN-backed tunnels are reached with the regular net package.
+func (t *Tunnel) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	if t.tnet == nil {
+		return nil, fmt.Errorf("wireguard tunnel is not running in user-space mode")
+	}
+	return t.tnet.DialContext(ctx, network, address)
+}
@@ -0,0 +1,43 @@
+package wg
+
+import (
+	"context"
+	"testing"
+
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+type discardSender struct{}
+
+func (discardSender) SendWGPacket(string, []byte) error { return nil }
+
+func TestStartNetstackWithDERPBind(t *testing.T) {
+	priv, err := wgtypes.GeneratePrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	peer, _ := wgtypes.GeneratePrivateKey()
+
+	tun := NewTunnel(priv, "100.96.0.7", 0)
+	tun.peers = []PeerConfig{{PublicKey: peer.PublicKey().String(), Endpoint: "peer-device", AllowedIPs: []string{"100.96.0.8/32"}}}
+	bind := NewDERPBind(discardSender{})
+	if err := tun.StartNetstackWithDERPBind(bind); err != nil {
+		t.Fatalf("StartNetstackWithDERPBind: %v", err)
+	}
+	if !tun.Userspace() || !tun.IsRunning() || tun.InterfaceName() != netstackInterfaceName {
+		t.Errorf("userspace=%v running=%v iface=%q", tun.Userspace(), tun.IsRunning(), tun.InterfaceName())
+	}
+
+	diff := PeerDiff{Remove: tun.Peers()}
+	if err := tun.ApplyPeerDiff(diff); err != nil || len(tun.Peers()) != 0 {
+		t.Errorf("ApplyPeerDiff: %v, peers=%v", err, tun.Peers())
+	}
+
+	tun.Stop()
+	if tun.Userspace() || tun.IsRunning() {
+		t.Error("tunnel still running after Stop")
+	}
+	if _, err := tun.DialContext(context.Background(), "tcp", "100.96.0.8:80"); err == nil {
+		t.Error("DialContext succeeded on a stopped tunnel")
+	}
+}
@@ -0,0 +1,121 @@
+package wg
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+
+	"github.com/prysmsh/cli/internal/api"
+)
+
+const presharedKeysFile = "prysm0.psk.json"
+
+// GeneratePresharedKey returns a random WireGuard preshared key in the usual
+// base64 form (as printed by `wg genpsk`).
+func GeneratePresharedKey() (string, error) {
+	k, err := wgtypes.GenerateKey()
+	if err != nil {
+		return "", fmt.Errorf("generate preshared key: %w", err)
+	}
+	return k.String(), nil
+}
+
+// LoadPresharedKeys reads the per-peer preshared keys stored under homeDir,
+// keyed by the peer's WireGuard public key. A missing file is an empty set.
+func LoadPresharedKeys(homeDir string) (map[string]string, error) {
+	data, err := os.ReadFile(filepath.Join(homeDir, presharedKeysFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read preshared keys: %w", err)
+	}
+	keys := map[string]string{}
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("parse preshared keys: %w", err)
+	}
+	return keys, nil
+}
+
+// SavePresharedKeys writes the per-peer preshared keys, readable only by the
+// current user.
+func SavePresharedKeys(homeDir string, keys map[string]string) error {
+	if err := os.MkdirAll(homeDir, 0o700); err != nil {
+		return fmt.Errorf("create key dir: %w", err)
+	}
+	data, err := json.MarshalIndent(keys, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(homeDir, presharedKeysFile), append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("write preshared keys: %w", err)
+	}
+	return nil
+}
+
+// PresharedKeyAssignment pairs a peer device with the preshared key used
+// between it and this device.
+type PresharedKeyAssignment struct {
+	PeerDeviceID string `json:"peer_device_id"`
+	PresharedKey string `json:"preshared_key"`
+}
+
+// SubmitPresharedKeys registers preshared keys for this device's peers with
+// the control plane, which hands each one to the other side of the pair. A
+// pair that already has a key keeps it; the returned assignments are the
+// keys in effect.
+func SubmitPresharedKeys(ctx context.Context, apiClient *api.Client, deviceID string, keys []PresharedKeyAssignment) ([]PresharedKeyAssignment, error) {
+	payload := map[string]interface{}{
+		"device_id": deviceID,
+		"keys":      keys,
+	}
+	var resp struct {
+		Keys []PresharedKeyAssignment `json:"keys"`
+	}
+	if _, err := apiClient.Do(ctx, "POST", "/mesh/wireguard/preshared-keys", payload, &resp); err != nil {
+		return nil, fmt.Errorf("submit preshared keys: %w", err)
+	}
+	return resp.Keys, nil
+}
+
+// syncPresharedKeys returns the control plane's preshared keys for the given
+// peers and rewrites the local store to match, so keys the control plane
+// removed or rotated do not linger.
+func syncPresharedKeys(homeDir string, peers []WGPeer) map[string]string {
+	keys := make(map[string]string, len(peers))
+	for _, p := range peers {
+		if p.PresharedKey != "" {
+			keys[p.PublicKey] = p.PresharedKey
+		}
+	}
+	stored, err := LoadPresharedKeys(homeDir)
+	if err == nil && maps.Equal(stored, keys) {
+		return keys
+	}
+	if err := SavePresharedKeys(homeDir, keys); err != nil {
+		fmt.Fprintf(os.Stderr, "wireguard: %v\n", err)
+	}
+	return keys
+}
+
+// peerPSK returns the hex PSK WireGuard uses for a peer. A preshared key
+// issued by the control plane is used unchanged, since both sides of the pair
+// receive it; otherwise the ML-KEM derived PSK (possibly empty) applies.
+func peerPSK(peerPub, configured, pq string) string {
+	if configured == "" {
+		return pq
+	}
+	k, err := wgtypes.ParseKey(configured)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "wireguard: peer %s: invalid preshared key: %v\n", truncateKey(peerPub), err)
+		return pq
+	}
+	return hex.EncodeToString(k[:])
+}
@@ -0,0 +1,81 @@
+package wg
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+func TestPresharedKeyStore(t *testing.T) {
+	home := t.TempDir()
+	keys, err := LoadPresharedKeys(home)
+	if err != nil || len(keys) != 0 {
+		t.Fatalf("LoadPresharedKeys on empty home = %v, %v", keys, err)
+	}
+
+	psk, err := GeneratePresharedKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := SavePresharedKeys(home, map[string]string{"peer-a": psk}); err != nil {
+		t.Fatal(err)
+	}
+	st, err := os.Stat(filepath.Join(home, presharedKeysFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := st.Mode().Perm(); perm != 0o600 {
+		t.Errorf("permissions = %o, want 600", perm)
+	}
+
+	// The store is rebuilt from the control plane's keys: new and rotated
+	// keys replace it, keys it no longer returns are dropped.
+	psk2, _ := GeneratePresharedKey()
+	got := syncPresharedKeys(home, []WGPeer{{PublicKey: "peer-b", PresharedKey: psk2}, {PublicKey: "peer-c"}})
+	if len(got) != 1 || got["peer-b"] != psk2 {
+		t.Errorf("syncPresharedKeys = %v", got)
+	}
+	if stored, _ := LoadPresharedKeys(home); len(stored) != 1 || stored["peer-b"] != psk2 {
+		t.Errorf("stored keys = %v, want only peer-b", stored)
+	}
+
+	if err := RemoveKeyMaterial(home); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(home, presharedKeysFile)); !os.IsNotExist(err) {
+		t.Error("RemoveKeyMaterial should delete the preshared keys")
+	}
+}
+
+func TestPeerPSK(t *testing.T) {
+	static, _ := GeneratePresharedKey()
+	pq, err := DeriveBilateralPSK([]byte("ours"), []byte("theirs"), "a", "b")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := peerPSK("peer", "", pq); got != pq {
+		t.Errorf("ML-KEM only: got %q, want %q", got, pq)
+	}
+	if got := peerPSK("peer", "", ""); got != "" {
+		t.Errorf("no keys: got %q", got)
+	}
+
+	// A configured key is used unchanged, whether or not ML-KEM is available,
+	// so both sides of the pair agree.
+	k, _ := wgtypes.ParseKey(static)
+	want := hex.EncodeToString(k[:])
+	if got := peerPSK("peer", static, ""); got != want {
+		t.Errorf("static only: got %q, want %q", got, want)
+	}
+	if got := peerPSK("peer", static, pq); got != want {
+		t.Errorf("static and ML-KEM: got %q, want %q", got, want)
+	}
+
+	if got := peerPSK("peer", "not-a-key", pq); got != pq {
+		t.Errorf("peerPSK with an invalid key = %q, want the ML-KEM PSK", got)
+	}
+}
@@ -0,0 +1,78 @@
+package wg
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+// PeerStats is the per-peer state reported by the WireGuard device.
+type PeerStats struct {
+	LastHandshake time.Time // zero if no handshake has completed
+	TxBytes       int64
+	RxBytes       int64
+}
+
+// PeerStats returns handshake and traffic counters keyed by the peer's
+// base64 public key.
+func (t *Tunnel) PeerStats() (map[string]PeerStats, error) {
+	if t.wgDevice == nil {
+		return nil, fmt.Errorf("wireguard tunnel is not running")
+	}
+	uapi, err := t.wgDevice.IpcGet()
+	if err != nil {
+		return nil, fmt.Errorf("read wireguard device state: %w", err)
+	}
+	return parsePeerStats(uapi)
+}
+
+// parsePeerStats parses the peer sections of a UAPI "get" response.
+func parsePeerStats(uapi string) (map[string]PeerStats, error) {
+	stats := make(map[string]PeerStats)
+	var key string
+	var cur PeerStats
+	var sec int64
+	flush := func() {
+		if key == "" {
+			return
+		}
+		if sec > 0 {
+			cur.LastHandshake = time.Unix(sec, 0)
+		}
+		stats[key] = cur
+	}
+
+	sc := bufio.NewScanner(strings.NewReader(uapi))
+	for sc.Scan() {
+		k, v, ok := strings.Cut(sc.Text(), "=")
+		if !ok {
+			continue
+		}
+		switch k {
+		case "public_key":
+			flush()
+			raw, err := hex.DecodeString(v)
+			if err != nil {
+				return nil, fmt.Errorf("parse peer public key: %w", err)
+			}
+			pub, err := wgtypes.NewKey(raw)
+			if err != nil {
+				return nil, fmt.Errorf("parse peer public key: %w", err)
+			}
+			key, cur, sec = pub.String(), PeerStats{}, 0
+		case "last_handshake_time_sec":
+			sec, _ = strconv.ParseInt(v, 10, 64)
+		case "tx_bytes":
+			cur.TxBytes, _ = strconv.ParseInt(v, 10, 64)
+		case "rx_bytes":
+			cur.RxBytes, _ = strconv.ParseInt(v, 10, 64)
+		}
+	}
+	flush()
+	return stats, sc.Err()
+}
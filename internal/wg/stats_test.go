@@ -0,0 +1,44 @@
+package wg
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+func TestParsePeerStats(t *testing.T) {
+	a, _ := wgtypes.GeneratePrivateKey()
+	b, _ := wgtypes.GeneratePrivateKey()
+	uapi := fmt.Sprintf(`private_key=%s
+listen_port=0
+public_key=%s
+endpoint=peer-a
+last_handshake_time_sec=1760000000
+last_handshake_time_nsec=0
+tx_bytes=1200
+rx_bytes=3400
+allowed_ip=100.96.0.8/32
+public_key=%s
+last_handshake_time_sec=0
+tx_bytes=148
+rx_bytes=0
+errno=0
+`, hexKey(a), hexKey(a.PublicKey()), hexKey(b.PublicKey()))
+
+	stats, err := parsePeerStats(uapi)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stats) != 2 {
+		t.Fatalf("got %d peers, want 2", len(stats))
+	}
+	got := stats[a.PublicKey().String()]
+	if !got.LastHandshake.Equal(time.Unix(1760000000, 0)) || got.TxBytes != 1200 || got.RxBytes != 3400 {
+		t.Errorf("peer a = %+v", got)
+	}
+	if got := stats[b.PublicKey().String()]; !got.LastHandshake.IsZero() || got.TxBytes != 148 {
+		t.Errorf("peer b = %+v", got)
+	}
+}
@@ -0,0 +1,89 @@
+package wg
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+)
+
+// SetSubnetRoutes routes accepted subnet CIDRs through the peers advertising
+// them. routes is keyed by peer public key and replaces the previous set;
+// peers whose subnets did not change are left alone.
+func (t *Tunnel) SetSubnetRoutes(routes map[string][]string) error {
+	if t.wgDevice == nil {
+		return fmt.Errorf("wireguard tunnel is not running")
+	}
+	t.peersMu.Lock()
+	defer t.peersMu.Unlock()
+
+	old := t.subnets
+	t.subnets = routes
+	var errs []error
+	for _, p := range t.peers {
+		before, after := old[p.PublicKey], routes[p.PublicKey]
+		if slices.Equal(before, after) {
+			continue
+		}
+		if err := t.configurePeer(p); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if t.tnet != nil {
+			continue // netstack routes by allowed IPs; there is no OS route table
+		}
+		for _, cidr := range after {
+			if err := addRoute(cidr, t.interfaceName); err != nil {
+				errs = append(errs, fmt.Errorf("route: %w", err))
+			}
+		}
+		for _, cidr := range before {
+			if !slices.Contains(after, cidr) {
+				if err := deleteRoute(cidr, t.interfaceName); err != nil {
+					log.Printf("wireguard: %v", err)
+				}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// SetSubnetGateway forwards traffic arriving from mesh peers to each CIDR,
+// making this device the gateway for subnet routes it advertises. cidrs
+// replaces the previous set.
+func (t *Tunnel) SetSubnetGateway(cidrs []string) error {
+	if t.wgDevice == nil {
+		return fmt.Errorf("wireguard tunnel is not running")
+	}
+	if t.tnet != nil && len(cidrs) > 0 {
+		return fmt.Errorf("advertising subnet routes needs a TUN device and is not available in user-space mode")
+	}
+	t.peersMu.Lock()
+	defer t.peersMu.Unlock()
+
+	var errs []error
+	for _, cidr := range t.gateway {
+		if !slices.Contains(cidrs, cidr) {
+			if err := deleteSubnetGateway(cidr, t.interfaceName); err != nil {
+				log.Printf("wireguard: %v", err)
+			}
+		}
+	}
+	var active []string
+	for _, cidr := range cidrs {
+		if err := addSubnetGateway(cidr, t.interfaceName); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		active = append(active, cidr)
+	}
+	t.gateway = active
+	return errors.Join(errs...)
+}
+
+// SubnetGateway returns the CIDRs this device currently forwards for.
+func (t *Tunnel) SubnetGateway() []string {
+	t.peersMu.RLock()
+	defer t.peersMu.RUnlock()
+	return slices.Clone(t.gateway)
+}
@@ -0,0 +1,60 @@
+package wg
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestAllowedIPsWithSubnetsAndExit(t *testing.T) {
+	tun := &Tunnel{
+		subnets:  map[string][]string{"gw": {"10.1.0.0/16"}},
+		exitPeer: "exit",
+	}
+	tests := []struct {
+		key  string
+		want []string
+	}{
+		{"plain", []string{"100.96.0.2/32"}},
+		{"gw", []string{"100.96.0.2/32", "10.1.0.0/16"}},
+		{"exit", []string{"100.96.0.2/32", "0.0.0.0/0", "::/0"}},
+	}
+	for _, tt := range tests {
+		p := PeerConfig{PublicKey: tt.key, AllowedIPs: []string{"100.96.0.2/32"}}
+		if got := tun.allowedIPs(p); !slices.Equal(got, tt.want) {
+			t.Errorf("allowedIPs(%s) = %v, want %v", tt.key, got, tt.want)
+		}
+		if len(p.AllowedIPs) != 1 {
+			t.Errorf("allowedIPs(%s) modified the peer's own allowed IPs", tt.key)
+		}
+	}
+}
+
+func TestSetExitGatewayNotRunning(t *testing.T) {
+	tun := &Tunnel{}
+	if err := tun.SetExitGateway(true); err == nil {
+		t.Error("expected an error without a running tunnel")
+	}
+	if tun.ExitGateway() {
+		t.Error("exit gateway should stay off")
+	}
+}
+
+func TestExitRoutesCoverBothFamilies(t *testing.T) {
+	want := map[string]bool{"0.0.0.0/1": false, "128.0.0.0/1": false, "::/1": false, "8000::/1": false}
+	for _, cidr := range exitRoutes {
+		if _, ok := want[cidr]; !ok {
+			t.Errorf("unexpected exit route %s", cidr)
+		}
+		want[cidr] = true
+	}
+	for cidr, found := range want {
+		if !found {
+			t.Errorf("exit routes miss %s", cidr)
+		}
+	}
+	for _, cidr := range activeExitRoutes() {
+		if isIPv6(cidr) && !ipv6Enabled() {
+			t.Errorf("IPv6 exit route %s active without IPv6", cidr)
+		}
+	}
+}
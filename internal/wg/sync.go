@@ -0,0 +1,186 @@
+package wg
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/prysmsh/cli/internal/api"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+// PeerDiff is the change needed to bring a running tunnel's peers in line
+// with the control plane.
+type PeerDiff struct {
+	Add    []PeerConfig
+	Update []PeerConfig // endpoint or allowed IPs changed; keeps the existing PSK
+	Remove []PeerConfig
+}
+
+// Empty reports whether the diff has no changes.
+func (d PeerDiff) Empty() bool {
+	return len(d.Add) == 0 && len(d.Update) == 0 && len(d.Remove) == 0
+}
+
+// DiffPeers compares the running peers with the desired set by public key.
+// Pre-shared keys are not compared: desired peers carry none until a new
+// peer's key is negotiated.
+func DiffPeers(current, desired []PeerConfig) PeerDiff {
+	var d PeerDiff
+	have := make(map[string]PeerConfig, len(current))
+	for _, p := range current {
+		have[p.PublicKey] = p
+	}
+	want := make(map[string]bool, len(desired))
+	for _, p := range desired {
+		want[p.PublicKey] = true
+		old, ok := have[p.PublicKey]
+		switch {
+		case !ok:
+			d.Add = append(d.Add, p)
+		case old.Endpoint != p.Endpoint || !slices.Equal(old.AllowedIPs, p.AllowedIPs):
+			p.PresharedKey = old.PresharedKey
+			d.Update = append(d.Update, p)
+		}
+	}
+	for _, p := range current {
+		if !want[p.PublicKey] {
+			d.Remove = append(d.Remove, p)
+		}
+	}
+	return d
+}
+
+// PlanPeerSync fetches the control plane's peer list for deviceID and returns
+// the additions, removals and endpoint/allowed-IP changes the running tunnel
+// needs. New peers, and peers whose configured preshared key was issued,
+// rotated or revoked, get a PSK like those set up by SetupMeshWireGuardDERP.
+// Apply the result with ApplyPeerDiff; planning does network I/O, applying
+// does not.
+func PlanPeerSync(ctx context.Context, apiClient *api.Client, t *Tunnel, homeDir, deviceID string) (PeerDiff, error) {
+	cfg, err := GetConfig(ctx, apiClient, deviceID)
+	if err != nil {
+		return PeerDiff{}, err
+	}
+	remote := make(map[string]WGPeer, len(cfg.Peers))
+	desired := make([]PeerConfig, 0, len(cfg.Peers))
+	for _, p := range cfg.Peers {
+		remote[p.PublicKey] = p
+		desired = append(desired, peerConfigFromWG(p))
+	}
+
+	previous, _ := LoadPresharedKeys(homeDir)
+	static := syncPresharedKeys(homeDir, cfg.Peers)
+	diff := DiffPeers(t.Peers(), desired)
+	var rekey []PeerConfig
+	for _, p := range t.Peers() {
+		if _, ok := remote[p.PublicKey]; !ok || previous[p.PublicKey] == static[p.PublicKey] {
+			continue
+		}
+		if i := slices.IndexFunc(diff.Update, func(u PeerConfig) bool { return u.PublicKey == p.PublicKey }); i >= 0 {
+			diff.Update = slices.Delete(diff.Update, i, i+1)
+		}
+		rekey = append(rekey, peerConfigFromWG(remote[p.PublicKey]))
+	}
+	if len(diff.Add) == 0 && len(rekey) == 0 {
+		return diff, nil
+	}
+
+	_, pubKey, keyErr := EnsureKeyPair(homeDir)
+	dk, _, mlkemErr := EnsureMLKEMKeyPair(homeDir)
+	for _, pcs := range [][]PeerConfig{diff.Add, rekey} {
+		for i := range pcs {
+			p := remote[pcs[i].PublicKey]
+			var pq string
+			if static[p.PublicKey] == "" && keyErr == nil && mlkemErr == nil {
+				pq = resolvePSK(ctx, apiClient, dk, deviceID, pubKey, p)
+			}
+			pcs[i].PresharedKey = peerPSK(p.PublicKey, static[p.PublicKey], pq)
+		}
+	}
+	diff.Update = append(diff.Update, rekey...)
+	return diff, nil
+}
+
+// ApplyPeerDiff reconfigures the running device without recreating the
+// interface. Peers that fail to apply are logged and left out of the peer
+// list so the next sync retries them.
+func (t *Tunnel) ApplyPeerDiff(d PeerDiff) error {
+	if t.wgDevice == nil {
+		return fmt.Errorf("wireguard tunnel is not running")
+	}
+	t.peersMu.Lock()
+	defer t.peersMu.Unlock()
+
+	removed := make(map[string]bool, len(d.Remove)+len(d.Update))
+	for _, p := range d.Remove {
+		if err := t.removePeer(p); err != nil {
+			log.Printf("wireguard: failed to remove peer %s: %v", truncateKey(p.PublicKey), err)
+			continue
+		}
+		removed[p.PublicKey] = true
+	}
+	old := make(map[string]PeerConfig, len(t.peers))
+	for _, p := range t.peers {
+		old[p.PublicKey] = p
+	}
+	for _, p := range d.Update {
+		if t.tnet != nil {
+			break // no OS routes to prune
+		}
+		for _, cidr := range old[p.PublicKey].AllowedIPs {
+			if !slices.Contains(p.AllowedIPs, cidr) {
+				if err := deleteRoute(cidr, t.interfaceName); err != nil {
+					log.Printf("wireguard: %v", err)
+				}
+			}
+		}
+	}
+
+	var applied []PeerConfig
+	for _, p := range append(append([]PeerConfig{}, d.Update...), d.Add...) {
+		removed[p.PublicKey] = true
+		if err := t.configurePeer(p); err != nil {
+			log.Printf("wireguard: failed to configure peer %s: %v", truncateKey(p.PublicKey), err)
+			continue
+		}
+		applied = append(applied, p)
+	}
+
+	peers := make([]PeerConfig, 0, len(t.peers)+len(d.Add))
+	for _, p := range t.peers {
+		if !removed[p.PublicKey] {
+			peers = append(peers, p)
+		}
+	}
+	t.peers = append(peers, applied...)
+	return nil
+}
+
+func (t *Tunnel) configurePeer(p PeerConfig) error {
+	if t.viaDERP {
+		return t.addPeerDERP(p)
+	}
+	return t.addPeer(p)
+}
+
+// removePeer drops a peer from the device and deletes its routes.
+func (t *Tunnel) removePeer(p PeerConfig) error {
+	pubKey, err := wgtypes.ParseKey(p.PublicKey)
+	if err != nil {
+		return fmt.Errorf("parse peer public key: %w", err)
+	}
+	if err := t.wgDevice.IpcSet(fmt.Sprintf("public_key=%s\nremove=true\n", hexKey(pubKey))); err != nil {
+		return fmt.Errorf("remove peer %s: %w", truncateKey(p.PublicKey), err)
+	}
+	if t.tnet != nil {
+		return nil
+	}
+	for _, cidr := range append(append([]string{}, p.AllowedIPs...), t.subnets[p.PublicKey]...) {
+		if err := deleteRoute(cidr, t.interfaceName); err != nil {
+			log.Printf("wireguard: %v", err)
+		}
+	}
+	return nil
+}
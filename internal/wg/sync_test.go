@@ -0,0 +1,31 @@
+package wg
+
+import "testing"
+
+func TestDiffPeers(t *testing.T) {
+	current := []PeerConfig{
+		{PublicKey: "a", Endpoint: "dev-a", AllowedIPs: []string{"100.64.0.2/32"}, PresharedKey: "psk-a"},
+		{PublicKey: "b", Endpoint: "dev-b", AllowedIPs: []string{"100.64.0.3/32"}, PresharedKey: "psk-b"},
+		{PublicKey: "c", Endpoint: "dev-c", AllowedIPs: []string{"100.64.0.4/32"}},
+	}
+	desired := []PeerConfig{
+		{PublicKey: "a", Endpoint: "dev-a", AllowedIPs: []string{"100.64.0.2/32"}},
+		{PublicKey: "b", Endpoint: "dev-b", AllowedIPs: []string{"100.64.0.3/32", "10.0.0.0/16"}},
+		{PublicKey: "d", Endpoint: "dev-d", AllowedIPs: []string{"100.64.0.5/32"}},
+	}
+
+	d := DiffPeers(current, desired)
+	if len(d.Add) != 1 || d.Add[0].PublicKey != "d" {
+		t.Errorf("Add = %+v", d.Add)
+	}
+	if len(d.Update) != 1 || d.Update[0].PublicKey != "b" || d.Update[0].PresharedKey != "psk-b" {
+		t.Errorf("Update = %+v, want b with its existing PSK", d.Update)
+	}
+	if len(d.Remove) != 1 || d.Remove[0].PublicKey != "c" {
+		t.Errorf("Remove = %+v", d.Remove)
+	}
+
+	if d := DiffPeers(current, current); !d.Empty() {
+		t.Errorf("identical peer sets diff = %+v", d)
+	}
+}
@@ -1,19 +1,21 @@
 package wg
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"net"
 	"net/netip"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"golang.zx2c4.com/wireguard/conn"
 	"golang.zx2c4.com/wireguard/device"
 	"golang.zx2c4.com/wireguard/tun"
+	"golang.zx2c4.com/wireguard/tun/netstack"
 	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
 )
 
@@ -22,7 +24,7 @@ type PeerConfig struct {
 	PublicKey    string
 	Endpoint     string
 	AllowedIPs   []string
-	PresharedKey string // 32-byte hex PSK derived from ML-KEM (empty = no PSK)
+	PresharedKey string // 32-byte hex PSK: configured or ML-KEM derived, see peerPSK (empty = no PSK)
 }
 
 // Tunnel manages an embedded userspace WireGuard interface.
@@ -31,8 +33,16 @@ type Tunnel struct {
 	privateKey    wgtypes.Key
 	overlayIP     string
 	listenPort    int
+	peersMu       sync.RWMutex // guards peers; replaced wholesale by ApplyPeerDiff
 	peers         []PeerConfig
+	viaDERP       bool                // peers are reached through a DERPBind
+	exitPeer      string              // public key of the peer used as exit node; guarded by peersMu
+	subnets       map[string][]string // accepted subnet CIDRs by peer public key; guarded by peersMu
+	gateway       []string            // subnet CIDRs forwarded for mesh peers; guarded by peersMu
+	exitUplink    string              // uplink interface while acting as an exit node; guarded by peersMu
+	meshCIDR      string              // overlay network, never forwarded as exit traffic
 	tunDevice     tun.Device
+	tnet          *netstack.Net // set when running on a user-space network stack
 	wgDevice      *device.Device
 }
 
@@ -73,6 +83,18 @@ func EnsureKeyPair(homeDir string) (privKey wgtypes.Key, pubKeyB64 string, err e
 	return privKey, pubKey, nil
 }
 
+// RemoveKeyMaterial deletes the WireGuard and ML-KEM keys and the peer
+// preshared keys stored under homeDir, so the next connect registers the device with fresh keys.
+func RemoveKeyMaterial(homeDir string) error {
+	var errs []error
+	for _, name := range []string{"prysm0.key", "prysm0.pub", "prysm0.mlkem.key", presharedKeysFile} {
+		if err := os.Remove(filepath.Join(homeDir, name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // NewTunnel constructs a Tunnel that is ready to Start.
 func NewTunnel(privateKey wgtypes.Key, overlayIP string, listenPort int) *Tunnel {
 	return &Tunnel{
@@ -138,7 +160,7 @@ func (t *Tunnel) Start() error {
 	}
 
 	// Assign IP address and bring interface up (macOS ifconfig — always available).
-	if err := configureInterface(t.interfaceName, t.overlayIP); err != nil {
+	if err := t.configureInterface(); err != nil {
 		wgDev.Close()
 		return fmt.Errorf("configure interface: %w", err)
 	}
@@ -192,6 +214,7 @@ func (t *Tunnel) StartWithDERPBind(bind *DERPBind) error {
 	// Use DERP bind instead of UDP — packets flow through the DERP WebSocket relay.
 	wgDev := device.NewDevice(t.tunDevice, bind, logger)
 	t.wgDevice = wgDev
+	t.viaDERP = true
 
 	var uapi strings.Builder
 	uapi.WriteString(fmt.Sprintf("private_key=%s\n", hexKey(t.privateKey)))
@@ -205,7 +228,7 @@ func (t *Tunnel) StartWithDERPBind(bind *DERPBind) error {
 		return fmt.Errorf("bring up wireguard device: %w", err)
 	}
 
-	if err := configureInterface(t.interfaceName, t.overlayIP); err != nil {
+	if err := t.configureInterface(); err != nil {
 		wgDev.Close()
 		return fmt.Errorf("configure interface: %w", err)
 	}
@@ -219,6 +242,16 @@ func (t *Tunnel) StartWithDERPBind(bind *DERPBind) error {
 	return nil
 }
 
+// configureInterface assigns the overlay addresses to the TUN interface and
+// brings it up.
+func (t *Tunnel) configureInterface() error {
+	addrs, err := parseOverlayAddrs(t.overlayIP)
+	if err != nil {
+		return err
+	}
+	return configureInterface(t.interfaceName, addrs)
+}
+
 // addPeerDERP configures a peer for DERP transport. The endpoint is the peer's
 // DERP device ID (not a UDP address), so wireguard-go routes packets through
 // the DERPBind which sends them via the DERP WebSocket relay.
@@ -240,7 +273,7 @@ func (t *Tunnel) addPeerDERP(p PeerConfig) error {
 	}
 	uapi.WriteString(fmt.Sprintf("persistent_keepalive_interval=%d\n", 25))
 	uapi.WriteString("replace_allowed_ips=true\n")
-	for _, cidr := range p.AllowedIPs {
+	for _, cidr := range t.allowedIPs(p) {
 		uapi.WriteString(fmt.Sprintf("allowed_ip=%s\n", cidr))
 	}
 
@@ -250,6 +283,9 @@ func (t *Tunnel) addPeerDERP(p PeerConfig) error {
 	}
 	fmt.Fprintf(os.Stderr, "wireguard: peer %s configured OK\n", truncateKey(p.PublicKey))
 
+	if t.tnet != nil {
+		return nil // netstack routes by allowed IPs; there is no OS route table
+	}
 	for _, cidr := range p.AllowedIPs {
 		if err := addRoute(cidr, t.interfaceName); err != nil {
 			return fmt.Errorf("route: %w", err)
@@ -261,7 +297,9 @@ func (t *Tunnel) addPeerDERP(p PeerConfig) error {
 
 // AddPeer adds a peer to the running tunnel.
 func (t *Tunnel) AddPeer(p PeerConfig) error {
+	t.peersMu.Lock()
 	t.peers = append(t.peers, p)
+	t.peersMu.Unlock()
 	if t.wgDevice == nil {
 		return nil // not started yet, will be applied on Start
 	}
@@ -273,6 +311,7 @@ func (t *Tunnel) Stop() error {
 	if t.wgDevice != nil {
 		t.wgDevice.Close()
 		t.wgDevice = nil
+		t.tunDevice = nil // closed by the device
 	}
 	if t.tunDevice != nil {
 		t.tunDevice.Close()
@@ -280,14 +319,35 @@ func (t *Tunnel) Stop() error {
 	}
 
 	// Clean up routes.
-	if t.interfaceName != "" {
-		for _, p := range t.peers {
+	if t.interfaceName != "" && t.tnet == nil {
+		if t.exitPeer != "" {
+			for _, cidr := range activeExitRoutes() {
+				_ = deleteRoute(cidr, t.interfaceName)
+			}
+			t.exitPeer = ""
+		}
+		for _, p := range t.Peers() {
 			for _, cidr := range p.AllowedIPs {
-				_ = exec.Command("route", "-n", "delete", "-net", cidr, "-interface", t.interfaceName).Run()
+				_ = deleteRoute(cidr, t.interfaceName)
+			}
+		}
+		for _, cidrs := range t.subnets {
+			for _, cidr := range cidrs {
+				_ = deleteRoute(cidr, t.interfaceName)
 			}
 		}
-		t.interfaceName = ""
+		for _, cidr := range t.gateway {
+			_ = deleteSubnetGateway(cidr, t.interfaceName)
+		}
+		if t.exitUplink != "" {
+			_ = deleteExitGateway(t.interfaceName, t.exitUplink, t.exitMeshCIDR())
+		}
 	}
+	t.interfaceName = ""
+	t.tnet = nil
+	t.subnets = nil
+	t.gateway = nil
+	t.exitUplink = ""
 
 	return nil
 }
@@ -313,7 +373,7 @@ func (t *Tunnel) addPeer(p PeerConfig) error {
 	}
 	uapi.WriteString(fmt.Sprintf("persistent_keepalive_interval=%d\n", 25))
 	uapi.WriteString("replace_allowed_ips=true\n")
-	for _, cidr := range p.AllowedIPs {
+	for _, cidr := range t.allowedIPs(p) {
 		uapi.WriteString(fmt.Sprintf("allowed_ip=%s\n", cidr))
 	}
 
@@ -361,7 +421,7 @@ func truncateKey(key string) string {
 
 // GetPeers returns the current peer list.
 func (t *Tunnel) GetPeers() []PeerConfig {
-	return t.peers
+	return t.Peers()
 }
 
 // PrivateKeyBase64 returns the private key in base64 encoding (for NE config).
@@ -371,6 +431,8 @@ func (t *Tunnel) PrivateKeyBase64() string {
 
 // Peers returns the configured peer list.
 func (t *Tunnel) Peers() []PeerConfig {
+	t.peersMu.RLock()
+	defer t.peersMu.RUnlock()
 	return t.peers
 }
 
@@ -381,6 +443,8 @@ func (t *Tunnel) RetriggerHandshake(p PeerConfig) error {
 	if err != nil {
 		return err
 	}
+	t.peersMu.RLock()
+	defer t.peersMu.RUnlock()
 	// Remove peer to reset handshake state.
 	removeUAPI := fmt.Sprintf("public_key=%s\nremove=true\n", hexKey(pubKey))
 	_ = t.wgDevice.IpcSet(removeUAPI)
@@ -397,6 +461,22 @@ func (t *Tunnel) InterfaceName() string {
 	return t.interfaceName
 }
 
+// OverlayIP returns this device's overlay address, the IPv4 one on
+// dual-stack meshes.
 func (t *Tunnel) OverlayIP() string {
-	return t.overlayIP
+	addrs, err := parseOverlayAddrs(t.overlayIP)
+	if err != nil {
+		return t.overlayIP
+	}
+	return addrs[0].String()
+}
+
+// OverlayAddrs returns all of this device's overlay addresses, IPv4 first.
+func (t *Tunnel) OverlayAddrs() []string {
+	addrs, _ := parseOverlayAddrs(t.overlayIP)
+	out := make([]string, len(addrs))
+	for i, a := range addrs {
+		out[i] = a.String()
+	}
+	return out
 }
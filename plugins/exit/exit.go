@@ -41,6 +41,7 @@ func (p *ExitPlugin) Manifest() plugin.Manifest {
 		Name:        "exit-proxy",
 		Version:     "0.1.0",
 		Description: "SOCKS5 proxy through DERP exit peers",
+		Scopes:      []plugin.Scope{plugin.ScopeAPIRead, plugin.ScopeAPIWrite},
 		Commands: []plugin.CommandSpec{
 			{
 				Name:               "use",
@@ -124,6 +124,8 @@ type GetManifestResponse struct {
 	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
 	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
 	Commands      []*CommandSpec         `protobuf:"bytes,4,rep,name=commands,proto3" json:"commands,omitempty"`
+	Scopes        []string               `protobuf:"bytes,5,rep,name=scopes,proto3" json:"scopes,omitempty"` // e.g. "api:read", "api:write", "prompt", "exec"
+	Hooks         []*HookSpec            `protobuf:"bytes,6,rep,name=hooks,proto3" json:"hooks,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -186,6 +188,72 @@ func (x *GetManifestResponse) GetCommands() []*CommandSpec {
 	return nil
 }
 
+func (x *GetManifestResponse) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *GetManifestResponse) GetHooks() []*HookSpec {
+	if x != nil {
+		return x.Hooks
+	}
+	return nil
+}
+
+type HookSpec struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Phase         string                 `protobuf:"bytes,1,opt,name=phase,proto3" json:"phase,omitempty"`     // "before" or "after"
+	Command       string                 `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"` // command path such as "connect k8s"; "*" matches every command
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HookSpec) Reset() {
+	*x = HookSpec{}
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HookSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HookSpec) ProtoMessage() {}
+
+func (x *HookSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HookSpec.ProtoReflect.Descriptor instead.
+func (*HookSpec) Descriptor() ([]byte, []int) {
+	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *HookSpec) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *HookSpec) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
 type CommandSpec struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -198,7 +266,7 @@ type CommandSpec struct {
 
 func (x *CommandSpec) Reset() {
 	*x = CommandSpec{}
-	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[2]
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -210,7 +278,7 @@ func (x *CommandSpec) String() string {
 func (*CommandSpec) ProtoMessage() {}
 
 func (x *CommandSpec) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[2]
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -223,7 +291,7 @@ func (x *CommandSpec) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CommandSpec.ProtoReflect.Descriptor instead.
 func (*CommandSpec) Descriptor() ([]byte, []int) {
-	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{2}
+	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *CommandSpec) GetName() string {
@@ -261,13 +329,14 @@ type ExecuteRequest struct {
 	WorkingDir    string                 `protobuf:"bytes,3,opt,name=working_dir,json=workingDir,proto3" json:"working_dir,omitempty"`
 	OutputFormat  string                 `protobuf:"bytes,4,opt,name=output_format,json=outputFormat,proto3" json:"output_format,omitempty"`
 	Debug         bool                   `protobuf:"varint,5,opt,name=debug,proto3" json:"debug,omitempty"`
+	HostServiceId uint32                 `protobuf:"varint,6,opt,name=host_service_id,json=hostServiceId,proto3" json:"host_service_id,omitempty"` // go-plugin broker ID serving HostService for this plugin
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ExecuteRequest) Reset() {
 	*x = ExecuteRequest{}
-	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[3]
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -279,7 +348,7 @@ func (x *ExecuteRequest) String() string {
 func (*ExecuteRequest) ProtoMessage() {}
 
 func (x *ExecuteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[3]
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -292,7 +361,7 @@ func (x *ExecuteRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExecuteRequest.ProtoReflect.Descriptor instead.
 func (*ExecuteRequest) Descriptor() ([]byte, []int) {
-	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{3}
+	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ExecuteRequest) GetArgs() []string {
@@ -330,6 +399,13 @@ func (x *ExecuteRequest) GetDebug() bool {
 	return false
 }
 
+func (x *ExecuteRequest) GetHostServiceId() uint32 {
+	if x != nil {
+		return x.HostServiceId
+	}
+	return 0
+}
+
 type ExecuteResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ExitCode      int32                  `protobuf:"varint,1,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
@@ -341,7 +417,7 @@ type ExecuteResponse struct {
 
 func (x *ExecuteResponse) Reset() {
 	*x = ExecuteResponse{}
-	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[4]
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -353,7 +429,7 @@ func (x *ExecuteResponse) String() string {
 func (*ExecuteResponse) ProtoMessage() {}
 
 func (x *ExecuteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[4]
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -366,7 +442,7 @@ func (x *ExecuteResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExecuteResponse.ProtoReflect.Descriptor instead.
 func (*ExecuteResponse) Descriptor() ([]byte, []int) {
-	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{4}
+	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ExecuteResponse) GetExitCode() int32 {
@@ -390,6 +466,142 @@ func (x *ExecuteResponse) GetStdout() string {
 	return ""
 }
 
+type RunHookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Phase         string                 `protobuf:"bytes,1,opt,name=phase,proto3" json:"phase,omitempty"`
+	Command       string                 `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`
+	Args          []string               `protobuf:"bytes,3,rep,name=args,proto3" json:"args,omitempty"`
+	Flags         map[string]string      `protobuf:"bytes,4,rep,name=flags,proto3" json:"flags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // flags set explicitly on the command line
+	WorkingDir    string                 `protobuf:"bytes,5,opt,name=working_dir,json=workingDir,proto3" json:"working_dir,omitempty"`
+	Error         string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`                                         // "after" hooks only: the command's error, if it failed
+	HostServiceId uint32                 `protobuf:"varint,7,opt,name=host_service_id,json=hostServiceId,proto3" json:"host_service_id,omitempty"` // go-plugin broker ID serving HostService for this plugin
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunHookRequest) Reset() {
+	*x = RunHookRequest{}
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunHookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunHookRequest) ProtoMessage() {}
+
+func (x *RunHookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunHookRequest.ProtoReflect.Descriptor instead.
+func (*RunHookRequest) Descriptor() ([]byte, []int) {
+	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RunHookRequest) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *RunHookRequest) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *RunHookRequest) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *RunHookRequest) GetFlags() map[string]string {
+	if x != nil {
+		return x.Flags
+	}
+	return nil
+}
+
+func (x *RunHookRequest) GetWorkingDir() string {
+	if x != nil {
+		return x.WorkingDir
+	}
+	return ""
+}
+
+func (x *RunHookRequest) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *RunHookRequest) GetHostServiceId() uint32 {
+	if x != nil {
+		return x.HostServiceId
+	}
+	return 0
+}
+
+type RunHookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"` // non-empty aborts the command ("before" hooks)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunHookResponse) Reset() {
+	*x = RunHookResponse{}
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunHookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunHookResponse) ProtoMessage() {}
+
+func (x *RunHookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunHookResponse.ProtoReflect.Descriptor instead.
+func (*RunHookResponse) Descriptor() ([]byte, []int) {
+	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RunHookResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
 type GetAuthContextRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -398,7 +610,7 @@ type GetAuthContextRequest struct {
 
 func (x *GetAuthContextRequest) Reset() {
 	*x = GetAuthContextRequest{}
-	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[5]
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -410,7 +622,7 @@ func (x *GetAuthContextRequest) String() string {
 func (*GetAuthContextRequest) ProtoMessage() {}
 
 func (x *GetAuthContextRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[5]
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -423,7 +635,7 @@ func (x *GetAuthContextRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAuthContextRequest.ProtoReflect.Descriptor instead.
 func (*GetAuthContextRequest) Descriptor() ([]byte, []int) {
-	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{5}
+	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{8}
 }
 
 type GetAuthContextResponse struct {
@@ -440,7 +652,7 @@ type GetAuthContextResponse struct {
 
 func (x *GetAuthContextResponse) Reset() {
 	*x = GetAuthContextResponse{}
-	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[6]
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -452,7 +664,7 @@ func (x *GetAuthContextResponse) String() string {
 func (*GetAuthContextResponse) ProtoMessage() {}
 
 func (x *GetAuthContextResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[6]
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -465,7 +677,7 @@ func (x *GetAuthContextResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAuthContextResponse.ProtoReflect.Descriptor instead.
 func (*GetAuthContextResponse) Descriptor() ([]byte, []int) {
-	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{6}
+	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *GetAuthContextResponse) GetToken() string {
@@ -521,7 +733,7 @@ type APIRequestRequest struct {
 
 func (x *APIRequestRequest) Reset() {
 	*x = APIRequestRequest{}
-	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[7]
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -533,7 +745,7 @@ func (x *APIRequestRequest) String() string {
 func (*APIRequestRequest) ProtoMessage() {}
 
 func (x *APIRequestRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[7]
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -546,7 +758,7 @@ func (x *APIRequestRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use APIRequestRequest.ProtoReflect.Descriptor instead.
 func (*APIRequestRequest) Descriptor() ([]byte, []int) {
-	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{7}
+	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *APIRequestRequest) GetMethod() string {
@@ -580,7 +792,7 @@ type APIRequestResponse struct {
 
 func (x *APIRequestResponse) Reset() {
 	*x = APIRequestResponse{}
-	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[8]
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -592,7 +804,7 @@ func (x *APIRequestResponse) String() string {
 func (*APIRequestResponse) ProtoMessage() {}
 
 func (x *APIRequestResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[8]
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -605,7 +817,7 @@ func (x *APIRequestResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use APIRequestResponse.ProtoReflect.Descriptor instead.
 func (*APIRequestResponse) Descriptor() ([]byte, []int) {
-	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{8}
+	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *APIRequestResponse) GetStatusCode() int32 {
@@ -630,7 +842,7 @@ type GetConfigRequest struct {
 
 func (x *GetConfigRequest) Reset() {
 	*x = GetConfigRequest{}
-	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[9]
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -642,7 +854,7 @@ func (x *GetConfigRequest) String() string {
 func (*GetConfigRequest) ProtoMessage() {}
 
 func (x *GetConfigRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[9]
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -655,7 +867,7 @@ func (x *GetConfigRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetConfigRequest.ProtoReflect.Descriptor instead.
 func (*GetConfigRequest) Descriptor() ([]byte, []int) {
-	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{9}
+	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{12}
 }
 
 type GetConfigResponse struct {
@@ -670,7 +882,7 @@ type GetConfigResponse struct {
 
 func (x *GetConfigResponse) Reset() {
 	*x = GetConfigResponse{}
-	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[10]
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -682,7 +894,7 @@ func (x *GetConfigResponse) String() string {
 func (*GetConfigResponse) ProtoMessage() {}
 
 func (x *GetConfigResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[10]
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -695,7 +907,7 @@ func (x *GetConfigResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetConfigResponse.ProtoReflect.Descriptor instead.
 func (*GetConfigResponse) Descriptor() ([]byte, []int) {
-	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{10}
+	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *GetConfigResponse) GetApiBaseUrl() string {
@@ -736,7 +948,7 @@ type LogRequest struct {
 
 func (x *LogRequest) Reset() {
 	*x = LogRequest{}
-	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[11]
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -748,7 +960,7 @@ func (x *LogRequest) String() string {
 func (*LogRequest) ProtoMessage() {}
 
 func (x *LogRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[11]
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -761,7 +973,7 @@ func (x *LogRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogRequest.ProtoReflect.Descriptor instead.
 func (*LogRequest) Descriptor() ([]byte, []int) {
-	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{11}
+	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *LogRequest) GetLevel() LogLevel {
@@ -786,7 +998,7 @@ type LogResponse struct {
 
 func (x *LogResponse) Reset() {
 	*x = LogResponse{}
-	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[12]
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -798,7 +1010,7 @@ func (x *LogResponse) String() string {
 func (*LogResponse) ProtoMessage() {}
 
 func (x *LogResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[12]
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -811,7 +1023,7 @@ func (x *LogResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogResponse.ProtoReflect.Descriptor instead.
 func (*LogResponse) Descriptor() ([]byte, []int) {
-	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{12}
+	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{15}
 }
 
 type PromptInputRequest struct {
@@ -824,7 +1036,7 @@ type PromptInputRequest struct {
 
 func (x *PromptInputRequest) Reset() {
 	*x = PromptInputRequest{}
-	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[13]
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -836,7 +1048,7 @@ func (x *PromptInputRequest) String() string {
 func (*PromptInputRequest) ProtoMessage() {}
 
 func (x *PromptInputRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[13]
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -849,7 +1061,7 @@ func (x *PromptInputRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PromptInputRequest.ProtoReflect.Descriptor instead.
 func (*PromptInputRequest) Descriptor() ([]byte, []int) {
-	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{13}
+	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *PromptInputRequest) GetLabel() string {
@@ -875,7 +1087,7 @@ type PromptInputResponse struct {
 
 func (x *PromptInputResponse) Reset() {
 	*x = PromptInputResponse{}
-	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[14]
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -887,7 +1099,7 @@ func (x *PromptInputResponse) String() string {
 func (*PromptInputResponse) ProtoMessage() {}
 
 func (x *PromptInputResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[14]
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -900,7 +1112,7 @@ func (x *PromptInputResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PromptInputResponse.ProtoReflect.Descriptor instead.
 func (*PromptInputResponse) Descriptor() ([]byte, []int) {
-	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{14}
+	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *PromptInputResponse) GetValue() string {
@@ -919,7 +1131,7 @@ type PromptConfirmRequest struct {
 
 func (x *PromptConfirmRequest) Reset() {
 	*x = PromptConfirmRequest{}
-	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[15]
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -931,7 +1143,7 @@ func (x *PromptConfirmRequest) String() string {
 func (*PromptConfirmRequest) ProtoMessage() {}
 
 func (x *PromptConfirmRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[15]
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -944,7 +1156,7 @@ func (x *PromptConfirmRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PromptConfirmRequest.ProtoReflect.Descriptor instead.
 func (*PromptConfirmRequest) Descriptor() ([]byte, []int) {
-	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{15}
+	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *PromptConfirmRequest) GetLabel() string {
@@ -963,7 +1175,7 @@ type PromptConfirmResponse struct {
 
 func (x *PromptConfirmResponse) Reset() {
 	*x = PromptConfirmResponse{}
-	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[16]
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -975,7 +1187,7 @@ func (x *PromptConfirmResponse) String() string {
 func (*PromptConfirmResponse) ProtoMessage() {}
 
 func (x *PromptConfirmResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[16]
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -988,7 +1200,7 @@ func (x *PromptConfirmResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PromptConfirmResponse.ProtoReflect.Descriptor instead.
 func (*PromptConfirmResponse) Descriptor() ([]byte, []int) {
-	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{16}
+	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *PromptConfirmResponse) GetConfirmed() bool {
@@ -998,36 +1210,533 @@ func (x *PromptConfirmResponse) GetConfirmed() bool {
 	return false
 }
 
-var File_proto_plugin_v1_plugin_proto protoreflect.FileDescriptor
+type GetSecretRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_proto_plugin_v1_plugin_proto_rawDesc = "" +
-	"\n" +
-	"\x1cproto/plugin/v1/plugin.proto\x12\tplugin.v1\"\x14\n" +
-	"\x12GetManifestRequest\"\x99\x01\n" +
-	"\x13GetManifestResponse\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
-	"\aversion\x18\x02 \x01(\tR\aversion\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x122\n" +
-	"\bcommands\x18\x04 \x03(\v2\x16.plugin.v1.CommandSpecR\bcommands\"\x85\x01\n" +
-	"\vCommandSpec\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
-	"\x05short\x18\x02 \x01(\tR\x05short\x12\x12\n" +
-	"\x04long\x18\x03 \x01(\tR\x04long\x128\n" +
-	"\vsubcommands\x18\x04 \x03(\v2\x16.plugin.v1.CommandSpecR\vsubcommands\"\xee\x01\n" +
-	"\x0eExecuteRequest\x12\x12\n" +
-	"\x04args\x18\x01 \x03(\tR\x04args\x124\n" +
-	"\x03env\x18\x02 \x03(\v2\".plugin.v1.ExecuteRequest.EnvEntryR\x03env\x12\x1f\n" +
-	"\vworking_dir\x18\x03 \x01(\tR\n" +
-	"workingDir\x12#\n" +
-	"\routput_format\x18\x04 \x01(\tR\foutputFormat\x12\x14\n" +
-	"\x05debug\x18\x05 \x01(\bR\x05debug\x1a6\n" +
+func (x *GetSecretRequest) Reset() {
+	*x = GetSecretRequest{}
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSecretRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSecretRequest) ProtoMessage() {}
+
+func (x *GetSecretRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSecretRequest.ProtoReflect.Descriptor instead.
+func (*GetSecretRequest) Descriptor() ([]byte, []int) {
+	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetSecretRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type GetSecretResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Found         bool                   `protobuf:"varint,2,opt,name=found,proto3" json:"found,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSecretResponse) Reset() {
+	*x = GetSecretResponse{}
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSecretResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSecretResponse) ProtoMessage() {}
+
+func (x *GetSecretResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSecretResponse.ProtoReflect.Descriptor instead.
+func (*GetSecretResponse) Descriptor() ([]byte, []int) {
+	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetSecretResponse) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *GetSecretResponse) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+type SetSecretRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"` // empty deletes the secret
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetSecretRequest) Reset() {
+	*x = SetSecretRequest{}
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetSecretRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetSecretRequest) ProtoMessage() {}
+
+func (x *SetSecretRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetSecretRequest.ProtoReflect.Descriptor instead.
+func (*SetSecretRequest) Descriptor() ([]byte, []int) {
+	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SetSecretRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SetSecretRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type SetSecretResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetSecretResponse) Reset() {
+	*x = SetSecretResponse{}
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetSecretResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetSecretResponse) ProtoMessage() {}
+
+func (x *SetSecretResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetSecretResponse.ProtoReflect.Descriptor instead.
+func (*SetSecretResponse) Descriptor() ([]byte, []int) {
+	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{23}
+}
+
+type TableRow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cells         []string               `protobuf:"bytes,1,rep,name=cells,proto3" json:"cells,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TableRow) Reset() {
+	*x = TableRow{}
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TableRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TableRow) ProtoMessage() {}
+
+func (x *TableRow) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TableRow.ProtoReflect.Descriptor instead.
+func (*TableRow) Descriptor() ([]byte, []int) {
+	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *TableRow) GetCells() []string {
+	if x != nil {
+		return x.Cells
+	}
+	return nil
+}
+
+type RenderTableRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Headers       []string               `protobuf:"bytes,1,rep,name=headers,proto3" json:"headers,omitempty"`
+	Rows          []*TableRow            `protobuf:"bytes,2,rep,name=rows,proto3" json:"rows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenderTableRequest) Reset() {
+	*x = RenderTableRequest{}
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenderTableRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderTableRequest) ProtoMessage() {}
+
+func (x *RenderTableRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderTableRequest.ProtoReflect.Descriptor instead.
+func (*RenderTableRequest) Descriptor() ([]byte, []int) {
+	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RenderTableRequest) GetHeaders() []string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *RenderTableRequest) GetRows() []*TableRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+type RenderTableResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenderTableResponse) Reset() {
+	*x = RenderTableResponse{}
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenderTableResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderTableResponse) ProtoMessage() {}
+
+func (x *RenderTableResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderTableResponse.ProtoReflect.Descriptor instead.
+func (*RenderTableResponse) Descriptor() ([]byte, []int) {
+	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{26}
+}
+
+type RenderJSONRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Json          []byte                 `protobuf:"bytes,1,opt,name=json,proto3" json:"json,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenderJSONRequest) Reset() {
+	*x = RenderJSONRequest{}
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenderJSONRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderJSONRequest) ProtoMessage() {}
+
+func (x *RenderJSONRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderJSONRequest.ProtoReflect.Descriptor instead.
+func (*RenderJSONRequest) Descriptor() ([]byte, []int) {
+	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *RenderJSONRequest) GetJson() []byte {
+	if x != nil {
+		return x.Json
+	}
+	return nil
+}
+
+type RenderJSONResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenderJSONResponse) Reset() {
+	*x = RenderJSONResponse{}
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenderJSONResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderJSONResponse) ProtoMessage() {}
+
+func (x *RenderJSONResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderJSONResponse.ProtoReflect.Descriptor instead.
+func (*RenderJSONResponse) Descriptor() ([]byte, []int) {
+	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{28}
+}
+
+type AuthorizeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Scope         string                 `protobuf:"bytes,1,opt,name=scope,proto3" json:"scope,omitempty"` // e.g. "exec", for actions the host cannot mediate itself
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthorizeRequest) Reset() {
+	*x = AuthorizeRequest{}
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthorizeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthorizeRequest) ProtoMessage() {}
+
+func (x *AuthorizeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthorizeRequest.ProtoReflect.Descriptor instead.
+func (*AuthorizeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *AuthorizeRequest) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+type AuthorizeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthorizeResponse) Reset() {
+	*x = AuthorizeResponse{}
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthorizeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthorizeResponse) ProtoMessage() {}
+
+func (x *AuthorizeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_plugin_v1_plugin_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthorizeResponse.ProtoReflect.Descriptor instead.
+func (*AuthorizeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_plugin_v1_plugin_proto_rawDescGZIP(), []int{30}
+}
+
+var File_proto_plugin_v1_plugin_proto protoreflect.FileDescriptor
+
+const file_proto_plugin_v1_plugin_proto_rawDesc = "" +
+	"\n" +
+	"\x1cproto/plugin/v1/plugin.proto\x12\tplugin.v1\"\x14\n" +
+	"\x12GetManifestRequest\"\xdc\x01\n" +
+	"\x13GetManifestResponse\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x122\n" +
+	"\bcommands\x18\x04 \x03(\v2\x16.plugin.v1.CommandSpecR\bcommands\x12\x16\n" +
+	"\x06scopes\x18\x05 \x03(\tR\x06scopes\x12)\n" +
+	"\x05hooks\x18\x06 \x03(\v2\x13.plugin.v1.HookSpecR\x05hooks\":\n" +
+	"\bHookSpec\x12\x14\n" +
+	"\x05phase\x18\x01 \x01(\tR\x05phase\x12\x18\n" +
+	"\acommand\x18\x02 \x01(\tR\acommand\"\x85\x01\n" +
+	"\vCommandSpec\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05short\x18\x02 \x01(\tR\x05short\x12\x12\n" +
+	"\x04long\x18\x03 \x01(\tR\x04long\x128\n" +
+	"\vsubcommands\x18\x04 \x03(\v2\x16.plugin.v1.CommandSpecR\vsubcommands\"\x96\x02\n" +
+	"\x0eExecuteRequest\x12\x12\n" +
+	"\x04args\x18\x01 \x03(\tR\x04args\x124\n" +
+	"\x03env\x18\x02 \x03(\v2\".plugin.v1.ExecuteRequest.EnvEntryR\x03env\x12\x1f\n" +
+	"\vworking_dir\x18\x03 \x01(\tR\n" +
+	"workingDir\x12#\n" +
+	"\routput_format\x18\x04 \x01(\tR\foutputFormat\x12\x14\n" +
+	"\x05debug\x18\x05 \x01(\bR\x05debug\x12&\n" +
+	"\x0fhost_service_id\x18\x06 \x01(\rR\rhostServiceId\x1a6\n" +
 	"\bEnvEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\\\n" +
 	"\x0fExecuteResponse\x12\x1b\n" +
 	"\texit_code\x18\x01 \x01(\x05R\bexitCode\x12\x14\n" +
 	"\x05error\x18\x02 \x01(\tR\x05error\x12\x16\n" +
-	"\x06stdout\x18\x03 \x01(\tR\x06stdout\"\x17\n" +
+	"\x06stdout\x18\x03 \x01(\tR\x06stdout\"\xa9\x02\n" +
+	"\x0eRunHookRequest\x12\x14\n" +
+	"\x05phase\x18\x01 \x01(\tR\x05phase\x12\x18\n" +
+	"\acommand\x18\x02 \x01(\tR\acommand\x12\x12\n" +
+	"\x04args\x18\x03 \x03(\tR\x04args\x12:\n" +
+	"\x05flags\x18\x04 \x03(\v2$.plugin.v1.RunHookRequest.FlagsEntryR\x05flags\x12\x1f\n" +
+	"\vworking_dir\x18\x05 \x01(\tR\n" +
+	"workingDir\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\x12&\n" +
+	"\x0fhost_service_id\x18\a \x01(\rR\rhostServiceId\x1a8\n" +
+	"\n" +
+	"FlagsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"'\n" +
+	"\x0fRunHookResponse\x12\x14\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\"\x17\n" +
 	"\x15GetAuthContextRequest\"\xba\x01\n" +
 	"\x16GetAuthContextResponse\x12\x14\n" +
 	"\x05token\x18\x01 \x01(\tR\x05token\x12\x15\n" +
@@ -1066,7 +1775,28 @@ const file_proto_plugin_v1_plugin_proto_rawDesc = "" +
 	"\x14PromptConfirmRequest\x12\x14\n" +
 	"\x05label\x18\x01 \x01(\tR\x05label\"5\n" +
 	"\x15PromptConfirmResponse\x12\x1c\n" +
-	"\tconfirmed\x18\x01 \x01(\bR\tconfirmed*\xa6\x01\n" +
+	"\tconfirmed\x18\x01 \x01(\bR\tconfirmed\"$\n" +
+	"\x10GetSecretRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\"?\n" +
+	"\x11GetSecretResponse\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x14\n" +
+	"\x05found\x18\x02 \x01(\bR\x05found\":\n" +
+	"\x10SetSecretRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"\x13\n" +
+	"\x11SetSecretResponse\" \n" +
+	"\bTableRow\x12\x14\n" +
+	"\x05cells\x18\x01 \x03(\tR\x05cells\"W\n" +
+	"\x12RenderTableRequest\x12\x18\n" +
+	"\aheaders\x18\x01 \x03(\tR\aheaders\x12'\n" +
+	"\x04rows\x18\x02 \x03(\v2\x13.plugin.v1.TableRowR\x04rows\"\x15\n" +
+	"\x13RenderTableResponse\"'\n" +
+	"\x11RenderJSONRequest\x12\x12\n" +
+	"\x04json\x18\x01 \x01(\fR\x04json\"\x14\n" +
+	"\x12RenderJSONResponse\"(\n" +
+	"\x10AuthorizeRequest\x12\x14\n" +
+	"\x05scope\x18\x01 \x01(\tR\x05scope\"\x13\n" +
+	"\x11AuthorizeResponse*\xa6\x01\n" +
 	"\bLogLevel\x12\x19\n" +
 	"\x15LOG_LEVEL_UNSPECIFIED\x10\x00\x12\x12\n" +
 	"\x0eLOG_LEVEL_INFO\x10\x01\x12\x15\n" +
@@ -1074,10 +1804,11 @@ const file_proto_plugin_v1_plugin_proto_rawDesc = "" +
 	"\x11LOG_LEVEL_WARNING\x10\x03\x12\x13\n" +
 	"\x0fLOG_LEVEL_ERROR\x10\x04\x12\x13\n" +
 	"\x0fLOG_LEVEL_DEBUG\x10\x05\x12\x13\n" +
-	"\x0fLOG_LEVEL_PLAIN\x10\x062\x9f\x01\n" +
+	"\x0fLOG_LEVEL_PLAIN\x10\x062\xe1\x01\n" +
 	"\rPluginService\x12L\n" +
 	"\vGetManifest\x12\x1d.plugin.v1.GetManifestRequest\x1a\x1e.plugin.v1.GetManifestResponse\x12@\n" +
-	"\aExecute\x12\x19.plugin.v1.ExecuteRequest\x1a\x1a.plugin.v1.ExecuteResponse2\xcf\x03\n" +
+	"\aExecute\x12\x19.plugin.v1.ExecuteRequest\x1a\x1a.plugin.v1.ExecuteResponse\x12@\n" +
+	"\aRunHook\x12\x19.plugin.v1.RunHookRequest\x1a\x1a.plugin.v1.RunHookResponse2\xc0\x06\n" +
 	"\vHostService\x12U\n" +
 	"\x0eGetAuthContext\x12 .plugin.v1.GetAuthContextRequest\x1a!.plugin.v1.GetAuthContextResponse\x12I\n" +
 	"\n" +
@@ -1085,7 +1816,13 @@ const file_proto_plugin_v1_plugin_proto_rawDesc = "" +
 	"\tGetConfig\x12\x1b.plugin.v1.GetConfigRequest\x1a\x1c.plugin.v1.GetConfigResponse\x124\n" +
 	"\x03Log\x12\x15.plugin.v1.LogRequest\x1a\x16.plugin.v1.LogResponse\x12L\n" +
 	"\vPromptInput\x12\x1d.plugin.v1.PromptInputRequest\x1a\x1e.plugin.v1.PromptInputResponse\x12R\n" +
-	"\rPromptConfirm\x12\x1f.plugin.v1.PromptConfirmRequest\x1a .plugin.v1.PromptConfirmResponseB8Z6github.com/warp-run/prysm-cli/proto/plugin/v1;pluginv1b\x06proto3"
+	"\rPromptConfirm\x12\x1f.plugin.v1.PromptConfirmRequest\x1a .plugin.v1.PromptConfirmResponse\x12F\n" +
+	"\tGetSecret\x12\x1b.plugin.v1.GetSecretRequest\x1a\x1c.plugin.v1.GetSecretResponse\x12F\n" +
+	"\tSetSecret\x12\x1b.plugin.v1.SetSecretRequest\x1a\x1c.plugin.v1.SetSecretResponse\x12L\n" +
+	"\vRenderTable\x12\x1d.plugin.v1.RenderTableRequest\x1a\x1e.plugin.v1.RenderTableResponse\x12I\n" +
+	"\n" +
+	"RenderJSON\x12\x1c.plugin.v1.RenderJSONRequest\x1a\x1d.plugin.v1.RenderJSONResponse\x12F\n" +
+	"\tAuthorize\x12\x1b.plugin.v1.AuthorizeRequest\x1a\x1c.plugin.v1.AuthorizeResponseB1Z/github.com/prysmsh/cli/proto/plugin/v1;pluginv1b\x06proto3"
 
 var (
 	file_proto_plugin_v1_plugin_proto_rawDescOnce sync.Once
@@ -1100,54 +1837,84 @@ func file_proto_plugin_v1_plugin_proto_rawDescGZIP() []byte {
 }
 
 var file_proto_plugin_v1_plugin_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_proto_plugin_v1_plugin_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_proto_plugin_v1_plugin_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
 var file_proto_plugin_v1_plugin_proto_goTypes = []any{
 	(LogLevel)(0),                  // 0: plugin.v1.LogLevel
 	(*GetManifestRequest)(nil),     // 1: plugin.v1.GetManifestRequest
 	(*GetManifestResponse)(nil),    // 2: plugin.v1.GetManifestResponse
-	(*CommandSpec)(nil),            // 3: plugin.v1.CommandSpec
-	(*ExecuteRequest)(nil),         // 4: plugin.v1.ExecuteRequest
-	(*ExecuteResponse)(nil),        // 5: plugin.v1.ExecuteResponse
-	(*GetAuthContextRequest)(nil),  // 6: plugin.v1.GetAuthContextRequest
-	(*GetAuthContextResponse)(nil), // 7: plugin.v1.GetAuthContextResponse
-	(*APIRequestRequest)(nil),      // 8: plugin.v1.APIRequestRequest
-	(*APIRequestResponse)(nil),     // 9: plugin.v1.APIRequestResponse
-	(*GetConfigRequest)(nil),       // 10: plugin.v1.GetConfigRequest
-	(*GetConfigResponse)(nil),      // 11: plugin.v1.GetConfigResponse
-	(*LogRequest)(nil),             // 12: plugin.v1.LogRequest
-	(*LogResponse)(nil),            // 13: plugin.v1.LogResponse
-	(*PromptInputRequest)(nil),     // 14: plugin.v1.PromptInputRequest
-	(*PromptInputResponse)(nil),    // 15: plugin.v1.PromptInputResponse
-	(*PromptConfirmRequest)(nil),   // 16: plugin.v1.PromptConfirmRequest
-	(*PromptConfirmResponse)(nil),  // 17: plugin.v1.PromptConfirmResponse
-	nil,                            // 18: plugin.v1.ExecuteRequest.EnvEntry
+	(*HookSpec)(nil),               // 3: plugin.v1.HookSpec
+	(*CommandSpec)(nil),            // 4: plugin.v1.CommandSpec
+	(*ExecuteRequest)(nil),         // 5: plugin.v1.ExecuteRequest
+	(*ExecuteResponse)(nil),        // 6: plugin.v1.ExecuteResponse
+	(*RunHookRequest)(nil),         // 7: plugin.v1.RunHookRequest
+	(*RunHookResponse)(nil),        // 8: plugin.v1.RunHookResponse
+	(*GetAuthContextRequest)(nil),  // 9: plugin.v1.GetAuthContextRequest
+	(*GetAuthContextResponse)(nil), // 10: plugin.v1.GetAuthContextResponse
+	(*APIRequestRequest)(nil),      // 11: plugin.v1.APIRequestRequest
+	(*APIRequestResponse)(nil),     // 12: plugin.v1.APIRequestResponse
+	(*GetConfigRequest)(nil),       // 13: plugin.v1.GetConfigRequest
+	(*GetConfigResponse)(nil),      // 14: plugin.v1.GetConfigResponse
+	(*LogRequest)(nil),             // 15: plugin.v1.LogRequest
+	(*LogResponse)(nil),            // 16: plugin.v1.LogResponse
+	(*PromptInputRequest)(nil),     // 17: plugin.v1.PromptInputRequest
+	(*PromptInputResponse)(nil),    // 18: plugin.v1.PromptInputResponse
+	(*PromptConfirmRequest)(nil),   // 19: plugin.v1.PromptConfirmRequest
+	(*PromptConfirmResponse)(nil),  // 20: plugin.v1.PromptConfirmResponse
+	(*GetSecretRequest)(nil),       // 21: plugin.v1.GetSecretRequest
+	(*GetSecretResponse)(nil),      // 22: plugin.v1.GetSecretResponse
+	(*SetSecretRequest)(nil),       // 23: plugin.v1.SetSecretRequest
+	(*SetSecretResponse)(nil),      // 24: plugin.v1.SetSecretResponse
+	(*TableRow)(nil),               // 25: plugin.v1.TableRow
+	(*RenderTableRequest)(nil),     // 26: plugin.v1.RenderTableRequest
+	(*RenderTableResponse)(nil),    // 27: plugin.v1.RenderTableResponse
+	(*RenderJSONRequest)(nil),      // 28: plugin.v1.RenderJSONRequest
+	(*RenderJSONResponse)(nil),     // 29: plugin.v1.RenderJSONResponse
+	(*AuthorizeRequest)(nil),       // 30: plugin.v1.AuthorizeRequest
+	(*AuthorizeResponse)(nil),      // 31: plugin.v1.AuthorizeResponse
+	nil,                            // 32: plugin.v1.ExecuteRequest.EnvEntry
+	nil,                            // 33: plugin.v1.RunHookRequest.FlagsEntry
 }
 var file_proto_plugin_v1_plugin_proto_depIdxs = []int32{
-	3,  // 0: plugin.v1.GetManifestResponse.commands:type_name -> plugin.v1.CommandSpec
-	3,  // 1: plugin.v1.CommandSpec.subcommands:type_name -> plugin.v1.CommandSpec
-	18, // 2: plugin.v1.ExecuteRequest.env:type_name -> plugin.v1.ExecuteRequest.EnvEntry
-	0,  // 3: plugin.v1.LogRequest.level:type_name -> plugin.v1.LogLevel
-	1,  // 4: plugin.v1.PluginService.GetManifest:input_type -> plugin.v1.GetManifestRequest
-	4,  // 5: plugin.v1.PluginService.Execute:input_type -> plugin.v1.ExecuteRequest
-	6,  // 6: plugin.v1.HostService.GetAuthContext:input_type -> plugin.v1.GetAuthContextRequest
-	8,  // 7: plugin.v1.HostService.APIRequest:input_type -> plugin.v1.APIRequestRequest
-	10, // 8: plugin.v1.HostService.GetConfig:input_type -> plugin.v1.GetConfigRequest
-	12, // 9: plugin.v1.HostService.Log:input_type -> plugin.v1.LogRequest
-	14, // 10: plugin.v1.HostService.PromptInput:input_type -> plugin.v1.PromptInputRequest
-	16, // 11: plugin.v1.HostService.PromptConfirm:input_type -> plugin.v1.PromptConfirmRequest
-	2,  // 12: plugin.v1.PluginService.GetManifest:output_type -> plugin.v1.GetManifestResponse
-	5,  // 13: plugin.v1.PluginService.Execute:output_type -> plugin.v1.ExecuteResponse
-	7,  // 14: plugin.v1.HostService.GetAuthContext:output_type -> plugin.v1.GetAuthContextResponse
-	9,  // 15: plugin.v1.HostService.APIRequest:output_type -> plugin.v1.APIRequestResponse
-	11, // 16: plugin.v1.HostService.GetConfig:output_type -> plugin.v1.GetConfigResponse
-	13, // 17: plugin.v1.HostService.Log:output_type -> plugin.v1.LogResponse
-	15, // 18: plugin.v1.HostService.PromptInput:output_type -> plugin.v1.PromptInputResponse
-	17, // 19: plugin.v1.HostService.PromptConfirm:output_type -> plugin.v1.PromptConfirmResponse
-	12, // [12:20] is the sub-list for method output_type
-	4,  // [4:12] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	4,  // 0: plugin.v1.GetManifestResponse.commands:type_name -> plugin.v1.CommandSpec
+	3,  // 1: plugin.v1.GetManifestResponse.hooks:type_name -> plugin.v1.HookSpec
+	4,  // 2: plugin.v1.CommandSpec.subcommands:type_name -> plugin.v1.CommandSpec
+	32, // 3: plugin.v1.ExecuteRequest.env:type_name -> plugin.v1.ExecuteRequest.EnvEntry
+	33, // 4: plugin.v1.RunHookRequest.flags:type_name -> plugin.v1.RunHookRequest.FlagsEntry
+	0,  // 5: plugin.v1.LogRequest.level:type_name -> plugin.v1.LogLevel
+	25, // 6: plugin.v1.RenderTableRequest.rows:type_name -> plugin.v1.TableRow
+	1,  // 7: plugin.v1.PluginService.GetManifest:input_type -> plugin.v1.GetManifestRequest
+	5,  // 8: plugin.v1.PluginService.Execute:input_type -> plugin.v1.ExecuteRequest
+	7,  // 9: plugin.v1.PluginService.RunHook:input_type -> plugin.v1.RunHookRequest
+	9,  // 10: plugin.v1.HostService.GetAuthContext:input_type -> plugin.v1.GetAuthContextRequest
+	11, // 11: plugin.v1.HostService.APIRequest:input_type -> plugin.v1.APIRequestRequest
+	13, // 12: plugin.v1.HostService.GetConfig:input_type -> plugin.v1.GetConfigRequest
+	15, // 13: plugin.v1.HostService.Log:input_type -> plugin.v1.LogRequest
+	17, // 14: plugin.v1.HostService.PromptInput:input_type -> plugin.v1.PromptInputRequest
+	19, // 15: plugin.v1.HostService.PromptConfirm:input_type -> plugin.v1.PromptConfirmRequest
+	21, // 16: plugin.v1.HostService.GetSecret:input_type -> plugin.v1.GetSecretRequest
+	23, // 17: plugin.v1.HostService.SetSecret:input_type -> plugin.v1.SetSecretRequest
+	26, // 18: plugin.v1.HostService.RenderTable:input_type -> plugin.v1.RenderTableRequest
+	28, // 19: plugin.v1.HostService.RenderJSON:input_type -> plugin.v1.RenderJSONRequest
+	30, // 20: plugin.v1.HostService.Authorize:input_type -> plugin.v1.AuthorizeRequest
+	2,  // 21: plugin.v1.PluginService.GetManifest:output_type -> plugin.v1.GetManifestResponse
+	6,  // 22: plugin.v1.PluginService.Execute:output_type -> plugin.v1.ExecuteResponse
+	8,  // 23: plugin.v1.PluginService.RunHook:output_type -> plugin.v1.RunHookResponse
+	10, // 24: plugin.v1.HostService.GetAuthContext:output_type -> plugin.v1.GetAuthContextResponse
+	12, // 25: plugin.v1.HostService.APIRequest:output_type -> plugin.v1.APIRequestResponse
+	14, // 26: plugin.v1.HostService.GetConfig:output_type -> plugin.v1.GetConfigResponse
+	16, // 27: plugin.v1.HostService.Log:output_type -> plugin.v1.LogResponse
+	18, // 28: plugin.v1.HostService.PromptInput:output_type -> plugin.v1.PromptInputResponse
+	20, // 29: plugin.v1.HostService.PromptConfirm:output_type -> plugin.v1.PromptConfirmResponse
+	22, // 30: plugin.v1.HostService.GetSecret:output_type -> plugin.v1.GetSecretResponse
+	24, // 31: plugin.v1.HostService.SetSecret:output_type -> plugin.v1.SetSecretResponse
+	27, // 32: plugin.v1.HostService.RenderTable:output_type -> plugin.v1.RenderTableResponse
+	29, // 33: plugin.v1.HostService.RenderJSON:output_type -> plugin.v1.RenderJSONResponse
+	31, // 34: plugin.v1.HostService.Authorize:output_type -> plugin.v1.AuthorizeResponse
+	21, // [21:35] is the sub-list for method output_type
+	7,  // [7:21] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_proto_plugin_v1_plugin_proto_init() }
@@ -1161,7 +1928,7 @@ func file_proto_plugin_v1_plugin_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_plugin_v1_plugin_proto_rawDesc), len(file_proto_plugin_v1_plugin_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   18,
+			NumMessages:   33,
 			NumExtensions: 0,
 			NumServices:   2,
 		},
@@ -21,6 +21,7 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	PluginService_GetManifest_FullMethodName = "/plugin.v1.PluginService/GetManifest"
 	PluginService_Execute_FullMethodName     = "/plugin.v1.PluginService/Execute"
+	PluginService_RunHook_FullMethodName     = "/plugin.v1.PluginService/RunHook"
 )
 
 // PluginServiceClient is the client API for PluginService service.
@@ -31,6 +32,7 @@ const (
 type PluginServiceClient interface {
 	GetManifest(ctx context.Context, in *GetManifestRequest, opts ...grpc.CallOption) (*GetManifestResponse, error)
 	Execute(ctx context.Context, in *ExecuteRequest, opts ...grpc.CallOption) (*ExecuteResponse, error)
+	RunHook(ctx context.Context, in *RunHookRequest, opts ...grpc.CallOption) (*RunHookResponse, error)
 }
 
 type pluginServiceClient struct {
@@ -61,6 +63,16 @@ func (c *pluginServiceClient) Execute(ctx context.Context, in *ExecuteRequest, o
 	return out, nil
 }
 
+func (c *pluginServiceClient) RunHook(ctx context.Context, in *RunHookRequest, opts ...grpc.CallOption) (*RunHookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RunHookResponse)
+	err := c.cc.Invoke(ctx, PluginService_RunHook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PluginServiceServer is the server API for PluginService service.
 // All implementations must embed UnimplementedPluginServiceServer
 // for forward compatibility.
@@ -69,6 +81,7 @@ func (c *pluginServiceClient) Execute(ctx context.Context, in *ExecuteRequest, o
 type PluginServiceServer interface {
 	GetManifest(context.Context, *GetManifestRequest) (*GetManifestResponse, error)
 	Execute(context.Context, *ExecuteRequest) (*ExecuteResponse, error)
+	RunHook(context.Context, *RunHookRequest) (*RunHookResponse, error)
 	mustEmbedUnimplementedPluginServiceServer()
 }
 
@@ -85,6 +98,9 @@ func (UnimplementedPluginServiceServer) GetManifest(context.Context, *GetManifes
 func (UnimplementedPluginServiceServer) Execute(context.Context, *ExecuteRequest) (*ExecuteResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method Execute not implemented")
 }
+func (UnimplementedPluginServiceServer) RunHook(context.Context, *RunHookRequest) (*RunHookResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RunHook not implemented")
+}
 func (UnimplementedPluginServiceServer) mustEmbedUnimplementedPluginServiceServer() {}
 func (UnimplementedPluginServiceServer) testEmbeddedByValue()                       {}
 
@@ -142,6 +158,24 @@ func _PluginService_Execute_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PluginService_RunHook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunHookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PluginServiceServer).RunHook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PluginService_RunHook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PluginServiceServer).RunHook(ctx, req.(*RunHookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PluginService_ServiceDesc is the grpc.ServiceDesc for PluginService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -157,6 +191,10 @@ var PluginService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Execute",
 			Handler:    _PluginService_Execute_Handler,
 		},
+		{
+			MethodName: "RunHook",
+			Handler:    _PluginService_RunHook_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/plugin/v1/plugin.proto",
@@ -169,6 +207,11 @@ const (
 	HostService_Log_FullMethodName            = "/plugin.v1.HostService/Log"
 	HostService_PromptInput_FullMethodName    = "/plugin.v1.HostService/PromptInput"
 	HostService_PromptConfirm_FullMethodName  = "/plugin.v1.HostService/PromptConfirm"
+	HostService_GetSecret_FullMethodName      = "/plugin.v1.HostService/GetSecret"
+	HostService_SetSecret_FullMethodName      = "/plugin.v1.HostService/SetSecret"
+	HostService_RenderTable_FullMethodName    = "/plugin.v1.HostService/RenderTable"
+	HostService_RenderJSON_FullMethodName     = "/plugin.v1.HostService/RenderJSON"
+	HostService_Authorize_FullMethodName      = "/plugin.v1.HostService/Authorize"
 )
 
 // HostServiceClient is the client API for HostService service.
@@ -183,6 +226,11 @@ type HostServiceClient interface {
 	Log(ctx context.Context, in *LogRequest, opts ...grpc.CallOption) (*LogResponse, error)
 	PromptInput(ctx context.Context, in *PromptInputRequest, opts ...grpc.CallOption) (*PromptInputResponse, error)
 	PromptConfirm(ctx context.Context, in *PromptConfirmRequest, opts ...grpc.CallOption) (*PromptConfirmResponse, error)
+	GetSecret(ctx context.Context, in *GetSecretRequest, opts ...grpc.CallOption) (*GetSecretResponse, error)
+	SetSecret(ctx context.Context, in *SetSecretRequest, opts ...grpc.CallOption) (*SetSecretResponse, error)
+	RenderTable(ctx context.Context, in *RenderTableRequest, opts ...grpc.CallOption) (*RenderTableResponse, error)
+	RenderJSON(ctx context.Context, in *RenderJSONRequest, opts ...grpc.CallOption) (*RenderJSONResponse, error)
+	Authorize(ctx context.Context, in *AuthorizeRequest, opts ...grpc.CallOption) (*AuthorizeResponse, error)
 }
 
 type hostServiceClient struct {
@@ -253,6 +301,56 @@ func (c *hostServiceClient) PromptConfirm(ctx context.Context, in *PromptConfirm
 	return out, nil
 }
 
+func (c *hostServiceClient) GetSecret(ctx context.Context, in *GetSecretRequest, opts ...grpc.CallOption) (*GetSecretResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSecretResponse)
+	err := c.cc.Invoke(ctx, HostService_GetSecret_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hostServiceClient) SetSecret(ctx context.Context, in *SetSecretRequest, opts ...grpc.CallOption) (*SetSecretResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetSecretResponse)
+	err := c.cc.Invoke(ctx, HostService_SetSecret_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hostServiceClient) RenderTable(ctx context.Context, in *RenderTableRequest, opts ...grpc.CallOption) (*RenderTableResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RenderTableResponse)
+	err := c.cc.Invoke(ctx, HostService_RenderTable_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hostServiceClient) RenderJSON(ctx context.Context, in *RenderJSONRequest, opts ...grpc.CallOption) (*RenderJSONResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RenderJSONResponse)
+	err := c.cc.Invoke(ctx, HostService_RenderJSON_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hostServiceClient) Authorize(ctx context.Context, in *AuthorizeRequest, opts ...grpc.CallOption) (*AuthorizeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuthorizeResponse)
+	err := c.cc.Invoke(ctx, HostService_Authorize_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // HostServiceServer is the server API for HostService service.
 // All implementations must embed UnimplementedHostServiceServer
 // for forward compatibility.
@@ -265,6 +363,11 @@ type HostServiceServer interface {
 	Log(context.Context, *LogRequest) (*LogResponse, error)
 	PromptInput(context.Context, *PromptInputRequest) (*PromptInputResponse, error)
 	PromptConfirm(context.Context, *PromptConfirmRequest) (*PromptConfirmResponse, error)
+	GetSecret(context.Context, *GetSecretRequest) (*GetSecretResponse, error)
+	SetSecret(context.Context, *SetSecretRequest) (*SetSecretResponse, error)
+	RenderTable(context.Context, *RenderTableRequest) (*RenderTableResponse, error)
+	RenderJSON(context.Context, *RenderJSONRequest) (*RenderJSONResponse, error)
+	Authorize(context.Context, *AuthorizeRequest) (*AuthorizeResponse, error)
 	mustEmbedUnimplementedHostServiceServer()
 }
 
@@ -293,6 +396,21 @@ func (UnimplementedHostServiceServer) PromptInput(context.Context, *PromptInputR
 func (UnimplementedHostServiceServer) PromptConfirm(context.Context, *PromptConfirmRequest) (*PromptConfirmResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method PromptConfirm not implemented")
 }
+func (UnimplementedHostServiceServer) GetSecret(context.Context, *GetSecretRequest) (*GetSecretResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetSecret not implemented")
+}
+func (UnimplementedHostServiceServer) SetSecret(context.Context, *SetSecretRequest) (*SetSecretResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetSecret not implemented")
+}
+func (UnimplementedHostServiceServer) RenderTable(context.Context, *RenderTableRequest) (*RenderTableResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RenderTable not implemented")
+}
+func (UnimplementedHostServiceServer) RenderJSON(context.Context, *RenderJSONRequest) (*RenderJSONResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RenderJSON not implemented")
+}
+func (UnimplementedHostServiceServer) Authorize(context.Context, *AuthorizeRequest) (*AuthorizeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Authorize not implemented")
+}
 func (UnimplementedHostServiceServer) mustEmbedUnimplementedHostServiceServer() {}
 func (UnimplementedHostServiceServer) testEmbeddedByValue()                     {}
 
@@ -422,6 +540,96 @@ func _HostService_PromptConfirm_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _HostService_GetSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostServiceServer).GetSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HostService_GetSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostServiceServer).GetSecret(ctx, req.(*GetSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HostService_SetSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostServiceServer).SetSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HostService_SetSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostServiceServer).SetSecret(ctx, req.(*SetSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HostService_RenderTable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenderTableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostServiceServer).RenderTable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HostService_RenderTable_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostServiceServer).RenderTable(ctx, req.(*RenderTableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HostService_RenderJSON_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenderJSONRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostServiceServer).RenderJSON(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HostService_RenderJSON_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostServiceServer).RenderJSON(ctx, req.(*RenderJSONRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HostService_Authorize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthorizeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostServiceServer).Authorize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HostService_Authorize_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostServiceServer).Authorize(ctx, req.(*AuthorizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // HostService_ServiceDesc is the grpc.ServiceDesc for HostService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -453,6 +661,26 @@ var HostService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PromptConfirm",
 			Handler:    _HostService_PromptConfirm_Handler,
 		},
+		{
+			MethodName: "GetSecret",
+			Handler:    _HostService_GetSecret_Handler,
+		},
+		{
+			MethodName: "SetSecret",
+			Handler:    _HostService_SetSecret_Handler,
+		},
+		{
+			MethodName: "RenderTable",
+			Handler:    _HostService_RenderTable_Handler,
+		},
+		{
+			MethodName: "RenderJSON",
+			Handler:    _HostService_RenderJSON_Handler,
+		},
+		{
+			MethodName: "Authorize",
+			Handler:    _HostService_Authorize_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/plugin/v1/plugin.proto",
@@ -46,6 +46,15 @@ require_cmd go
 require_cmd tar
 require_cmd gzip
 require_cmd zip
+require_cmd minisign
+
+# Releases must be signed with the key whose public half is embedded in
+# internal/cmd/update.go (releasePublicKey); otherwise every client refuses to
+# update to or from this build.
+if [[ -z "${MINISIGN_SECRET_KEY:-}" ]]; then
+  echo "error: MINISIGN_SECRET_KEY must be set to build a release" >&2
+  exit 1
+fi
 
 export PATH="$(go env GOPATH)/bin:${PATH}"
 
@@ -158,6 +167,32 @@ generate_sha256sums() {
   echo "Generated $sums_path"
 }
 
+# Check that MINISIGN_SECRET_KEY matches the public key compiled into the CLI.
+check_release_key() {
+  local embedded derived pub_file
+  embedded="$(sed -n 's/^const releasePublicKey = "\(.*\)"$/\1/p' "${ROOT_DIR}/internal/cmd/update.go")"
+  if [[ -z "$embedded" ]]; then
+    echo "error: releasePublicKey in internal/cmd/update.go is empty" >&2
+    exit 1
+  fi
+  pub_file="$(mktemp)"
+  minisign -R -f -s "$MINISIGN_SECRET_KEY" -p "$pub_file" >/dev/null
+  derived="$(tail -n 1 "$pub_file")"
+  rm -f "$pub_file"
+  if [[ "$derived" != "$embedded" ]]; then
+    echo "error: MINISIGN_SECRET_KEY does not match releasePublicKey in internal/cmd/update.go" >&2
+    exit 1
+  fi
+}
+
+# Sign SHA256SUMS with minisign so `prysm update` can verify releases.
+# Requires MINISIGN_SECRET_KEY (path to the release secret key).
+sign_sha256sums() {
+  local sums_path="${DIST_ROOT}/SHA256SUMS"
+  minisign -S -s "$MINISIGN_SECRET_KEY" -m "$sums_path" -x "${sums_path}.minisig" -t "prysm-cli v${VERSION}"
+  echo "Signed $sums_path"
+}
+
 generate_release_notes() {
   local notes_path="${DIST_ROOT}/RELEASE_NOTES.md"
   local generated_at
@@ -245,6 +280,8 @@ targets=(
   "windows amd64"
 )
 
+check_release_key
+
 pushd "$ROOT_DIR" >/dev/null
 for combo in "${targets[@]}"; do
   os="${combo%% *}"
@@ -263,6 +300,7 @@ done
 popd >/dev/null
 
 generate_sha256sums
+sign_sha256sums
 notes_file="$(generate_release_notes)"
 publish_github_release "$notes_file"
 